@@ -43,7 +43,7 @@ func executeComputeActionIterative(ctx *core.EngineContext, action core.Action,
 	count := 0
 	_, err := visitLeaves(ctx.State, steps, true, func(ref leafRef, selections []selectedValue) error {
 		itemEvalData := buildEvalDataForSelections(evalData, selections)
-		result, err := operators.EvaluateJsonLogic(action.Logic, itemEvalData)
+		result, err := operators.EvaluateJsonLogicWithRoot(action.Logic, itemEvalData, evalData)
 		if err != nil {
 			return fmt.Errorf("failed to evaluate compute logic: %w", err)
 		}
@@ -80,6 +80,17 @@ func buildEvalDataForSelections(base map[string]interface{}, selections []select
 			for k2, v2 := range v.Fields {
 				out[k2] = v2
 			}
+			if v.Currency != "" {
+				out["currency"] = v.Currency
+			}
+			if v.Unit != "" {
+				out["unit"] = v.Unit
+				if units, ok := base[operators.UnitsKey].(*core.UnitSet); ok {
+					if amount, ok := units.ItemBaseAmount(*v); ok {
+						out["baseAmount"] = amount
+					}
+				}
+			}
 		case map[string]interface{}:
 			for k2, v2 := range v {
 				out[k2] = v2
@@ -0,0 +1,56 @@
+package actions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/internal/benchdata"
+	"github.com/dolphin-sistemas/computations-engine/pipeline"
+)
+
+// BenchmarkBuildEvalDataForSelections mede as cópias por elemento de um compute em items[*]
+func BenchmarkBuildEvalDataForSelections(b *testing.B) {
+	for _, items := range benchdata.ItemCounts {
+		ctx, err := core.NewEngineContext(benchdata.Order(items), benchdata.Context)
+		if err != nil {
+			b.Fatalf("NewEngineContext failed: %v", err)
+		}
+		evalData, err := pipeline.BuildEvaluationDataWithError(ctx)
+		if err != nil {
+			b.Fatalf("BuildEvaluationDataWithError failed: %v", err)
+		}
+		b.Run(fmt.Sprintf("items=%d", items), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for j := range ctx.State.Items {
+					buildEvalDataForSelections(evalData, []selectedValue{{Key: "items", Value: &ctx.State.Items[j]}})
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkExecuteCompute mede um compute em items[*] (dados de avaliação + avaliação por item)
+func BenchmarkExecuteCompute(b *testing.B) {
+	action := core.Action{
+		Type:   "compute",
+		Target: "items[*].itemTotal",
+		Logic:  map[string]interface{}{"*": []interface{}{map[string]interface{}{"var": "amount"}, map[string]interface{}{"var": "price"}}},
+	}
+	for _, items := range benchdata.ItemCounts {
+		ctx, err := core.NewEngineContext(benchdata.Order(items), benchdata.Context)
+		if err != nil {
+			b.Fatalf("NewEngineContext failed: %v", err)
+		}
+		b.Run(fmt.Sprintf("items=%d", items), func(b *testing.B) {
+			benchdata.SkipLarge(b, items, 1)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := ExecuteAction(ctx, action); err != nil {
+					b.Fatalf("ExecuteAction failed: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+// ExecuteConvertCurrencyAction executa ação "convertCurrency": converte os campos numéricos
+// params.fields dos itens em outra moeda para params.to (padrão: a moeda do State), com as
+// cotações da execução, arredonda nas casas da moeda e passa o item para essa moeda.
+// Depois da conversão os itens podem ser agregados juntos (sumPath rejeita moedas misturadas).
+func ExecuteConvertCurrencyAction(ctx *core.EngineContext, action core.Action, evalData map[string]interface{}) (*core.Reason, *core.Violation, error) {
+	to := ctx.State.Currency
+	if raw, ok := action.Params["to"]; ok {
+		code, ok := raw.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("convertCurrency: params.to must be a string")
+		}
+		to = strings.ToUpper(code)
+	}
+	if to == "" {
+		return nil, nil, fmt.Errorf("convertCurrency requires params.to (or state.currency)")
+	}
+	if !pkg.IsCurrency(to) {
+		return nil, nil, fmt.Errorf("convertCurrency: unknown currency %q", to)
+	}
+
+	rawFields, ok := action.Params["fields"].([]interface{})
+	if !ok || len(rawFields) == 0 {
+		return nil, nil, fmt.Errorf("convertCurrency requires params.fields (item fields to convert)")
+	}
+	fields := make([]string, len(rawFields))
+	for i, f := range rawFields {
+		if fields[i], ok = f.(string); !ok || fields[i] == "" {
+			return nil, nil, fmt.Errorf("convertCurrency: params.fields[%d] must be a field name", i)
+		}
+	}
+
+	converted := 0
+	for i := range ctx.State.Items {
+		from := ctx.State.ItemCurrency(i)
+		if from == "" || from == to {
+			continue
+		}
+		rate, err := ctx.ExchangeRates.Rate(from, to)
+		if err != nil {
+			return nil, nil, fmt.Errorf("convertCurrency: item %s: %w", ctx.State.Items[i].ID, err)
+		}
+		item := &ctx.State.Items[i]
+		for _, field := range fields {
+			value, exists := item.Fields[field]
+			if !exists || value == nil {
+				continue
+			}
+			amount, err := toFloat64(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("convertCurrency: item %s: field %s: %w", item.ID, field, err)
+			}
+			item.Fields[field], _ = pkg.RoundCurrency(amount*rate, to)
+		}
+		item.Currency = to
+		converted++
+	}
+
+	return &core.Reason{Message: fmt.Sprintf("converted %d items to %s", converted, to)}, nil, nil
+}
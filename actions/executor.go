@@ -7,16 +7,22 @@ import (
 	"github.com/dolphin-sistemas/computations-engine/pipeline"
 )
 
+// Types são os tipos de ação aceitos por ExecuteAction
+var Types = []string{"set", "compute", "validate", "add", "multiply", "tieredDiscount", "taxes", "installments", "promotion", "convertCurrency"}
+
 // ExecuteActions executa uma lista de ações sobre o State
 func ExecuteActions(ctx *core.EngineContext, actions []core.Action) ([]core.Reason, []core.Violation, error) {
 	var reasons []core.Reason
 	var violations []core.Violation
 
-	for _, action := range actions {
+	for i, action := range actions {
 		reason, violation, err := ExecuteAction(ctx, action)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error executing action %s: %w", action.Type, err)
 		}
+		if ctx.Coverage != nil {
+			ctx.Coverage.RecordAction(ctx.RuleID, i, violation != nil)
+		}
 
 		if reason != nil {
 			reasons = append(reasons, *reason)
@@ -31,7 +37,11 @@ func ExecuteActions(ctx *core.EngineContext, actions []core.Action) ([]core.Reas
 
 // ExecuteAction executa uma única ação
 func ExecuteAction(ctx *core.EngineContext, action core.Action) (*core.Reason, *core.Violation, error) {
-	evalData := pipeline.BuildEvaluationData(ctx)
+	evalData, err := pipeline.EvaluationData(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build evaluation data: %w", err)
+	}
+	defer invalidateEvalData(ctx, action)
 
 	switch action.Type {
 	case "set":
@@ -44,7 +54,45 @@ func ExecuteAction(ctx *core.EngineContext, action core.Action) (*core.Reason, *
 		return ExecuteAddAction(ctx, action, evalData)
 	case "multiply":
 		return ExecuteMultiplyAction(ctx, action, evalData)
+	case "tieredDiscount":
+		return ExecuteTieredDiscountAction(ctx, action, evalData)
+	case "taxes":
+		return ExecuteTaxesAction(ctx, action, evalData)
+	case "installments":
+		return ExecuteInstallmentsAction(ctx, action, evalData)
+	case "promotion":
+		return ExecutePromotionAction(ctx, action, evalData)
+	case "convertCurrency":
+		return ExecuteConvertCurrencyAction(ctx, action, evalData)
 	default:
 		return nil, nil, fmt.Errorf("unknown action type: %s", action.Type)
 	}
 }
+
+// invalidateEvalData marca nos dados de avaliação o que a ação pode ter alterado: o item do
+// target (items[i]...), todos os itens (items[*]...) ou a parte do estado (campos e totais).
+// Ações que alteram o estado fora do target (promotion, convertCurrency) invalidam tudo.
+func invalidateEvalData(ctx *core.EngineContext, action core.Action) {
+	switch action.Type {
+	case "validate":
+		return
+	case "promotion", "convertCurrency":
+		ctx.Eval.Invalidate()
+		return
+	}
+
+	steps, err := ParsePath(action.Target)
+	if err != nil || len(steps) == 0 {
+		ctx.Eval.Invalidate()
+		return
+	}
+	first := steps[0]
+	switch {
+	case first.Key != "items":
+		ctx.Eval.InvalidateState()
+	case first.HasIndex:
+		ctx.Eval.InvalidateItem(first.Index)
+	default:
+		ctx.Eval.InvalidateItems()
+	}
+}
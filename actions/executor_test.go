@@ -0,0 +1,67 @@
+package actions
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/pipeline"
+)
+
+func init() {
+	pipeline.GetValue = GetValue
+}
+
+// TestExecuteAction_EvalDataMatchesRebuild: depois de cada ação, os dados de avaliação reaproveitados
+// (pipeline.EvaluationData) são iguais aos montados do zero, e leituras veem as escritas anteriores
+func TestExecuteAction_EvalDataMatchesRebuild(t *testing.T) {
+	ctx, err := core.NewEngineContext(*fuzzState(), core.ContextMeta{TenantID: "t1", Now: "2025-01-15T12:00:00Z"})
+	if err != nil {
+		t.Fatalf("NewEngineContext failed: %v", err)
+	}
+	ctx.Projections = map[string]core.Projection{
+		"prices":     {Path: "items[*].price"},
+		"lineTotals": {Logic: map[string]interface{}{"*": []interface{}{map[string]interface{}{"var": "amount"}, map[string]interface{}{"var": "price"}}}},
+	}
+
+	v := func(name string) map[string]interface{} { return map[string]interface{}{"var": name} }
+	steps := []core.Action{
+		{Type: "compute", Target: "items[*].total", Logic: map[string]interface{}{"*": []interface{}{v("amount"), v("price")}}},
+		{Type: "compute", Target: "totals.total", Logic: map[string]interface{}{"sum": []interface{}{v("itemValues")}}},
+		{Type: "set", Target: "items[1].price", Value: 35.0},
+		{Type: "add", Target: "items[0].amount", Value: 2.0},
+		{Type: "validate", Logic: map[string]interface{}{">": []interface{}{v("totals.total"), 1000000}}},
+		{Type: "set", Target: "fields.discount", Value: 5.0},
+		{Type: "compute", Target: "fields.net", Logic: map[string]interface{}{"-": []interface{}{v("totals.total"), v("discount")}}},
+		{Type: "compute", Target: "items[*].negotiations[*].amount", Logic: map[string]interface{}{"*": []interface{}{v("price"), v("percent")}}},
+		{Type: "multiply", Target: "totals.total", Value: 2.0},
+		{Type: "compute", Target: "totals.total", Logic: map[string]interface{}{"sum": []interface{}{v("prices")}}},
+	}
+
+	for i, action := range steps {
+		if _, _, err := ExecuteAction(ctx, action); err != nil {
+			t.Fatalf("step %d (%s %s) failed: %v", i, action.Type, action.Target, err)
+		}
+		cached, err := pipeline.EvaluationData(ctx)
+		if err != nil {
+			t.Fatalf("step %d: EvaluationData failed: %v", i, err)
+		}
+		fresh, err := pipeline.BuildEvaluationDataWithError(ctx)
+		if err != nil {
+			t.Fatalf("step %d: BuildEvaluationDataWithError failed: %v", i, err)
+		}
+		got, _ := json.Marshal(cached)
+		want, _ := json.Marshal(fresh)
+		if string(got) != string(want) {
+			t.Fatalf("step %d (%s %s): cached evaluation data differs:\n%s\n%s", i, action.Type, action.Target, got, want)
+		}
+	}
+
+	// A última ação leu a projeção "prices" depois de items[1].price = 35
+	if got := ctx.State.Totals.Total; got != 45 {
+		t.Fatalf("totals.total = %v, want 45 (10 + 35)", got)
+	}
+	if got := ctx.State.Fields["net"]; got != 45.0 {
+		t.Fatalf("fields.net = %v, want 45 (50 - 5)", got)
+	}
+}
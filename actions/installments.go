@@ -0,0 +1,71 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/operators"
+	"github.com/dolphin-sistemas/computations-engine/payments"
+)
+
+// ExecuteInstallmentsAction executa ação "installments": calcula o plano de parcelamento
+// (payments.Schedule) e grava o array de parcelas em target (ex: "fields.installments").
+//
+// Params (literais ou JsonLogic): total, count, rate, interest ("none", "simple", "compound",
+// "price"), firstDue, intervalDays, minInstallment, remainder ("last", "first", "spread")
+// e precision. As parcelas somam exatamente o total financiado.
+func ExecuteInstallmentsAction(ctx *core.EngineContext, action core.Action, evalData map[string]interface{}) (*core.Reason, *core.Violation, error) {
+	if action.Target == "" {
+		return nil, nil, fmt.Errorf("installments action requires target")
+	}
+	if action.Params["total"] == nil || action.Params["count"] == nil {
+		return nil, nil, fmt.Errorf("installments action requires params.total and params.count")
+	}
+
+	steps, err := ParsePath(action.Target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !HasWildcard(steps) {
+		result, err := installmentSchedule(action.Params, evalData, evalData)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := SetValue(ctx.State, action.Target, result); err != nil {
+			return nil, nil, err
+		}
+		return &core.Reason{Message: fmt.Sprintf("installments %s: %d installments", action.Target, len(result))}, nil, nil
+	}
+
+	count := 0
+	_, err = visitLeaves(ctx.State, steps, true, func(ref leafRef, selections []selectedValue) error {
+		result, err := installmentSchedule(action.Params, buildEvalDataForSelections(evalData, selections), evalData)
+		if err != nil {
+			return err
+		}
+		count++
+		return ref.Set(result)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &core.Reason{Message: fmt.Sprintf("installments %s calculated for %d elements", action.Target, count)}, nil, nil
+}
+
+// installmentSchedule avalia os params no escopo data e retorna as parcelas
+func installmentSchedule(params map[string]interface{}, data, root map[string]interface{}) ([]interface{}, error) {
+	values, err := evaluateParams(params, data, root)
+	if err != nil {
+		return nil, fmt.Errorf("installments: %w", err)
+	}
+	plan, err := operators.InstallmentPlan(values)
+	if err != nil {
+		return nil, fmt.Errorf("installments: %w", err)
+	}
+	schedule, err := payments.Schedule(plan)
+	if err != nil {
+		return nil, err
+	}
+	return operators.InstallmentValues(schedule), nil
+}
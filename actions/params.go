@@ -0,0 +1,44 @@
+package actions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/dolphin-sistemas/computations-engine/operators"
+)
+
+// evaluateParams avalia cada param (literal ou JsonLogic) no escopo data, com root como dados raiz
+func evaluateParams(params map[string]interface{}, data, root map[string]interface{}) (map[string]interface{}, error) {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make(map[string]interface{}, len(params))
+	for _, name := range names {
+		raw := params[name]
+		if logic, ok := raw.(map[string]interface{}); ok {
+			result, err := operators.EvaluateJsonLogicWithRoot(logic, data, root)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			raw = result
+		}
+		values[name] = raw
+	}
+	return values, nil
+}
+
+// decodeStrict converte v em out via JSON, rejeitando campos desconhecidos
+func decodeStrict(v interface{}, out interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(out)
+}
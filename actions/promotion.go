@@ -0,0 +1,299 @@
+package actions
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/operators"
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+// promotionItem é um item participante de uma promoção
+type promotionItem struct {
+	index int
+	value float64 // valor do item já descontado das promoções anteriores
+}
+
+// ExecutePromotionAction executa ação "promotion" (gerada por pipeline.CompilePromotions):
+// aplica a promoção params.id se o cupom, o limite de uso, o empilhamento e o valor mínimo
+// permitirem, grava os descontos nos itens e registra a promoção em ctx.AppliedPromotions.
+func ExecutePromotionAction(ctx *core.EngineContext, action core.Action, evalData map[string]interface{}) (*core.Reason, *core.Violation, error) {
+	id, _ := action.Params["id"].(string)
+	promo, ok := ctx.Promotions[id]
+	if !ok {
+		return nil, nil, fmt.Errorf("promotion action: unknown promotion %q", id)
+	}
+	if !promotionAllowed(ctx, promo) {
+		return nil, nil, nil
+	}
+
+	items, err := promotionItems(ctx, promo, evalData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("promotion %s: %w", promo.ID, err)
+	}
+	subtotal := 0.0
+	for _, item := range items {
+		subtotal += item.value
+	}
+	if promo.MinSubtotal > 0 && pkg.Round(subtotal, 2) < promo.MinSubtotal {
+		return nil, nil, nil
+	}
+
+	applied := core.AppliedPromotion{ID: promo.ID, Type: promo.Type, Coupon: promo.Coupon}
+	if promo.Type == core.PromotionFreeShipping {
+		if applied.Amount, err = freeShipping(ctx, promo); err != nil {
+			return nil, nil, fmt.Errorf("promotion %s: %w", promo.ID, err)
+		}
+	} else {
+		discounts := promotionDiscounts(ctx, promo, items, subtotal)
+		field := promo.DiscountField
+		if field == "" {
+			field = core.PromotionDiscountField
+		}
+		for i, item := range items {
+			if discounts[i] <= 0 {
+				continue
+			}
+			target := &ctx.State.Items[item.index]
+			if target.Fields == nil {
+				target.Fields = make(map[string]interface{})
+			}
+			target.Fields[field] = pkg.Round(pkg.ToFloat64(target.Fields[field])+discounts[i], 2)
+			applied.Items = append(applied.Items, core.PromotionItem{ItemID: target.ID, Amount: discounts[i]})
+			applied.Amount += discounts[i]
+		}
+		applied.Amount = pkg.Round(applied.Amount, 2)
+	}
+	if applied.Amount <= 0 {
+		return nil, nil, nil
+	}
+
+	ctx.AppliedPromotions = append(ctx.AppliedPromotions, applied)
+	return &core.Reason{Message: fmt.Sprintf("promotion %s applied: %.2f", promo.ID, applied.Amount)}, nil, nil
+}
+
+// promotionAllowed verifica empilhamento (exclusive), cupom e limite de uso
+func promotionAllowed(ctx *core.EngineContext, promo core.Promotion) bool {
+	for _, applied := range ctx.AppliedPromotions {
+		if promo.Exclusive || ctx.Promotions[applied.ID].Exclusive {
+			return false
+		}
+	}
+	if promo.Coupon != "" && !hasCoupon(ctx.State.Fields[core.CouponsField], promo.Coupon) {
+		return false
+	}
+	if promo.UsageLimit > 0 {
+		usage, _ := ctx.State.Fields[core.PromotionUsageField].(map[string]interface{})
+		if pkg.ToFloat64(usage[promo.ID]) >= float64(promo.UsageLimit) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasCoupon procura o código entre os cupons informados (string ou lista), sem diferenciar maiúsculas
+func hasCoupon(coupons interface{}, code string) bool {
+	switch v := coupons.(type) {
+	case string:
+		return strings.EqualFold(strings.TrimSpace(v), code)
+	case []interface{}:
+		for _, c := range v {
+			if s, ok := c.(string); ok && strings.EqualFold(strings.TrimSpace(s), code) {
+				return true
+			}
+		}
+	case []string:
+		for _, s := range v {
+			if strings.EqualFold(strings.TrimSpace(s), code) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// promotionItems seleciona os itens participantes (promo.Items) e calcula o valor de cada um:
+// promo.Base (ou itemValues) menos os descontos já gravados no campo de desconto
+func promotionItems(ctx *core.EngineContext, promo core.Promotion, evalData map[string]interface{}) ([]promotionItem, error) {
+	field := promo.DiscountField
+	if field == "" {
+		field = core.PromotionDiscountField
+	}
+	itemValues := numbersOf(evalData["itemValues"])
+
+	var items []promotionItem
+	for i := range ctx.State.Items {
+		item := &ctx.State.Items[i]
+		scope := buildEvalDataForSelections(evalData, []selectedValue{{Value: item}})
+		if len(promo.Items) > 0 {
+			selected, err := operators.EvaluateJsonLogicWithRoot(promo.Items, scope, evalData)
+			if err != nil {
+				return nil, fmt.Errorf("items: %w", err)
+			}
+			if ok, _ := selected.(bool); !ok {
+				continue
+			}
+		}
+
+		value := item.Amount
+		if i < len(itemValues) {
+			value = itemValues[i]
+		}
+		if len(promo.Base) > 0 {
+			base, err := operators.EvaluateJsonLogicWithRoot(promo.Base, scope, evalData)
+			if err != nil {
+				return nil, fmt.Errorf("base: %w", err)
+			}
+			value = pkg.ToFloat64(base)
+		}
+		value = math.Max(0, value-pkg.ToFloat64(item.Fields[field]))
+		items = append(items, promotionItem{index: i, value: value})
+	}
+	return items, nil
+}
+
+// promotionDiscounts calcula o desconto de cada item participante, em centavos exatos
+func promotionDiscounts(ctx *core.EngineContext, promo core.Promotion, items []promotionItem, subtotal float64) []float64 {
+	weights := make([]float64, len(items))
+	for i, item := range items {
+		weights[i] = item.value
+	}
+
+	switch promo.Type {
+	case core.PromotionPercentOff:
+		discounts := make([]float64, len(items))
+		total := 0.0
+		for i, item := range items {
+			discounts[i] = pkg.Round(item.value*promo.Percent/100, 2)
+			total += discounts[i]
+		}
+		if promo.MaxDiscount > 0 && total > promo.MaxDiscount {
+			return allocateCents(promo.MaxDiscount, discounts)
+		}
+		return discounts
+
+	case core.PromotionAmountOff:
+		return allocateCents(math.Min(promo.Amount, subtotal), weights)
+
+	case core.PromotionBuyXGetY:
+		return buyXGetY(ctx, promo, items)
+	}
+	return make([]float64, len(items))
+}
+
+// buyXGetY concede o desconto nas unidades mais baratas: get a cada buy + get unidades
+func buyXGetY(ctx *core.EngineContext, promo core.Promotion, items []promotionItem) []float64 {
+	quantities := make([]int, len(items))
+	prices := make([]float64, len(items))
+	order := make([]int, len(items))
+	units := 0
+	for i, item := range items {
+		order[i] = i
+		quantities[i] = int(math.Floor(ctx.State.Items[item.index].Amount))
+		if quantities[i] > 0 {
+			prices[i] = item.value / float64(quantities[i])
+			units += quantities[i]
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool { return prices[order[a]] < prices[order[b]] })
+
+	sets := units / (promo.Buy + promo.Get)
+	if promo.MaxApplications > 0 && sets > promo.MaxApplications {
+		sets = promo.MaxApplications
+	}
+	percent := promo.Percent
+	if percent == 0 {
+		percent = 100
+	}
+
+	discounts := make([]float64, len(items))
+	free := sets * promo.Get
+	for _, i := range order {
+		if free == 0 {
+			break
+		}
+		n := quantities[i]
+		if n > free {
+			n = free
+		}
+		if n > 0 {
+			discounts[i] = pkg.Round(float64(n)*prices[i]*percent/100, 2)
+			free -= n
+		}
+	}
+	return discounts
+}
+
+// freeShipping zera o frete (promo.ShippingTarget) e retorna o valor descontado
+func freeShipping(ctx *core.EngineContext, promo core.Promotion) (float64, error) {
+	target := promo.ShippingTarget
+	if target == "" {
+		target = core.PromotionShippingPath
+	}
+	current, err := GetValue(ctx.State, target)
+	if err != nil {
+		return 0, nil // sem frete no estado
+	}
+	amount := pkg.Round(pkg.ToFloat64(current), 2)
+	if amount <= 0 {
+		return 0, nil
+	}
+	if err := SetValue(ctx.State, target, 0.0); err != nil {
+		return 0, err
+	}
+	return amount, nil
+}
+
+// allocateCents rateia total (em centavos) proporcionalmente aos pesos pelo maior resto:
+// as partes somam exatamente o total e nenhuma fica negativa
+func allocateCents(total float64, weights []float64) []float64 {
+	out := make([]float64, len(weights))
+	sum := 0.0
+	for _, w := range weights {
+		sum += w
+	}
+	if len(weights) == 0 || sum <= 0 {
+		return out
+	}
+
+	cents := int64(math.Round(total * 100))
+	remainders := make([]float64, len(weights))
+	allocated := int64(0)
+	for i, w := range weights {
+		exact := float64(cents) * w / sum
+		share := int64(math.Floor(exact + 1e-9))
+		out[i] = float64(share)
+		remainders[i] = exact - float64(share)
+		allocated += share
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for i := int64(0); i < cents-allocated; i++ {
+		out[order[i]]++
+	}
+	for i := range out {
+		out[i] /= 100
+	}
+	return out
+}
+
+// numbersOf converte a projeção itemValues ([]float64 ou []interface{}) em números
+func numbersOf(v interface{}) []float64 {
+	switch values := v.(type) {
+	case []float64:
+		return values
+	case []interface{}:
+		out := make([]float64, len(values))
+		for i, value := range values {
+			out[i] = pkg.ToFloat64(value)
+		}
+		return out
+	}
+	return nil
+}
@@ -116,20 +116,18 @@ func SetValueAtIndices(state *core.State, target string, indices []int, value in
 	current := interface{}(state)
 	for stepIdx := 0; stepIdx < len(steps)-1; stepIdx++ {
 		step := steps[stepIdx]
-		var next *PathStep
-		if stepIdx+1 < len(steps) {
-			next = &steps[stepIdx+1]
-		}
 
 		child, childSetter, err := getChild(current, step.Key, true)
 		if err != nil {
 			return err
 		}
 		if child == nil {
-			// Missing path: create a container if possible, otherwise fail (this is a targeted set).
-			child = makeContainerForNext(next)
-			if child == nil {
-				return fmt.Errorf("path %q does not exist at %q", target, step.Key)
+			// Missing path: create the step's own container (an array when indexed; selectIndex
+			// expands it), otherwise an object for the next key.
+			if step.HasIndex || step.Wildcard {
+				child = []interface{}{}
+			} else {
+				child = make(map[string]interface{})
 			}
 			if err := childSetter(child); err != nil {
 				return err
@@ -138,7 +136,7 @@ func SetValueAtIndices(state *core.State, target string, indices []int, value in
 
 		switch {
 		case step.HasIndex:
-			elem, err := selectIndex(child, childSetter, step.Index, true, next)
+			elem, err := selectIndex(child, childSetter, step.Index, true)
 			if err != nil {
 				return err
 			}
@@ -147,7 +145,7 @@ func SetValueAtIndices(state *core.State, target string, indices []int, value in
 			if wildIdx >= len(indices) {
 				return fmt.Errorf("missing wildcard index %d for target %q", wildIdx, target)
 			}
-			elem, err := selectIndex(child, childSetter, indices[wildIdx], true, next)
+			elem, err := selectIndex(child, childSetter, indices[wildIdx], true)
 			if err != nil {
 				return err
 			}
@@ -219,8 +217,9 @@ func visitAt(
 		if !createMissing {
 			return 0, nil
 		}
-		// Don't create containers for wildcard steps (no implicit element creation).
-		if step.Wildcard {
+		// Don't create containers for wildcard steps (no implicit element creation), nor on
+		// the way to one: nothing would be written below it.
+		if step.Wildcard || HasWildcard(steps[stepIdx+1:]) {
 			return 0, nil
 		}
 		// For explicit indexes (e.g. foo[0]) we can create an array.
@@ -231,10 +230,8 @@ func visitAt(
 			}
 			child = created
 		} else {
-			created := makeContainerForNext(next)
-			if created == nil {
-				return 0, nil
-			}
+			// The next step is always a key (its index, if any, applies to its own child).
+			created := make(map[string]interface{})
 			if err := childSetter(created); err != nil {
 				return 0, err
 			}
@@ -244,7 +241,7 @@ func visitAt(
 
 	switch {
 	case step.HasIndex:
-		elem, err := selectIndex(child, childSetter, step.Index, createMissing, next)
+		elem, err := selectIndex(child, childSetter, step.Index, createMissing && !HasWildcard(steps[stepIdx+1:]))
 		if err != nil {
 			return 0, err
 		}
@@ -267,7 +264,7 @@ func visitAt(
 			return total, nil
 		case []interface{}:
 			for i := 0; i < len(arr); i++ {
-				elem, err := selectIndex(child, childSetter, i, createMissing, next)
+				elem, err := selectIndex(child, childSetter, i, createMissing && !HasWildcard(steps[stepIdx+1:]))
 				if err != nil {
 					return 0, err
 				}
@@ -500,46 +497,20 @@ func getChild(current interface{}, key string, createMissing bool) (interface{},
 		}
 
 	case *core.Totals:
-		switch key {
-		case "subtotal":
-			return c.Subtotal, func(v interface{}) error {
-				f, err := toFloat64(v)
-				if err != nil {
-					return err
-				}
-				c.Subtotal = f
-				return nil
-			}, nil
-		case "discount":
-			return c.Discount, func(v interface{}) error {
-				f, err := toFloat64(v)
-				if err != nil {
-					return err
-				}
-				c.Discount = f
-				return nil
-			}, nil
-		case "tax":
-			return c.Tax, func(v interface{}) error {
-				f, err := toFloat64(v)
-				if err != nil {
-					return err
-				}
-				c.Tax = f
-				return nil
-			}, nil
-		case "total":
-			return c.Total, func(v interface{}) error {
-				f, err := toFloat64(v)
-				if err != nil {
-					return err
-				}
-				c.Total = f
-				return nil
-			}, nil
-		default:
-			return nil, func(interface{}) error { return nil }, nil
+		// Legacy aggregates (subtotal, discount, tax, total) and named ones (freight, icmsSt, ...)
+		// share the same accessors; unknown names are created on write.
+		var value interface{}
+		if f, ok := c.Get(key); ok {
+			value = f
 		}
+		return value, func(v interface{}) error {
+			f, err := toFloat64(v)
+			if err != nil {
+				return fmt.Errorf("totals.%s: %w", key, err)
+			}
+			c.Set(key, f)
+			return nil
+		}, nil
 
 	case map[string]interface{}:
 		return c[key], func(v interface{}) error {
@@ -551,7 +522,7 @@ func getChild(current interface{}, key string, createMissing bool) (interface{},
 	}
 }
 
-func selectIndex(child interface{}, childSetter func(interface{}) error, idx int, createMissing bool, next *PathStep) (interface{}, error) {
+func selectIndex(child interface{}, childSetter func(interface{}) error, idx int, createMissing bool) (interface{}, error) {
 	switch arr := child.(type) {
 	case []core.Item:
 		if idx < 0 || idx >= len(arr) {
@@ -577,11 +548,10 @@ func selectIndex(child interface{}, childSetter func(interface{}) error, idx int
 
 		elem := arr[idx]
 		if elem == nil && createMissing {
-			created := makeContainerForNext(next)
-			if created != nil {
-				arr[idx] = created
-				elem = created
-			}
+			// Elements are always followed by a key step.
+			created := make(map[string]interface{})
+			arr[idx] = created
+			elem = created
 		}
 		return elem, nil
 
@@ -0,0 +1,127 @@
+package actions
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+func fuzzState() *core.State {
+	return &core.State{
+		Items: []core.Item{
+			{ID: "a", Amount: 1, Fields: map[string]interface{}{"price": 10.0, "negotiations": []interface{}{map[string]interface{}{"percent": 5.0}}}},
+			{ID: "b", Amount: 2, Fields: map[string]interface{}{"price": 20.0}},
+		},
+		Fields: map[string]interface{}{"customer": map[string]interface{}{"uf": "SP"}},
+	}
+}
+
+func FuzzSetGetValue(f *testing.F) {
+	for _, seed := range []string{
+		"totals.total", "totals.freight", "fields.discount", "discount", "fields.customer.uf", "customer.limits[2].value",
+		"items[0].fields.price", "items[1].price", "items[*].fields.total", "items[*].negotiations[*].percent",
+		"items[3].fields.x", "items[0].amount", "a.b.c", "items[*]", "totals", "fields", "items[0].negotiations[*]",
+	} {
+		f.Add(seed, 12.5)
+	}
+
+	f.Fuzz(func(t *testing.T, target string, value float64) {
+		if math.IsNaN(value) {
+			return
+		}
+		steps, err := ParsePath(target)
+		if err != nil || len(steps) == 0 {
+			return
+		}
+
+		state := fuzzState()
+		before, _ := json.Marshal(state)
+		if err := SetValue(state, target, value); err != nil {
+			return
+		}
+		got, err := GetValue(state, target)
+		if err != nil {
+			t.Fatalf("SetValue(%q) succeeded but GetValue failed: %v", target, err)
+		}
+		if readBack(got, value, HasWildcard(steps)) {
+			return
+		}
+
+		// Alvos que SetValue ignora (ex: wildcard sem elementos, campo não gravável) não alteram o estado
+		after, _ := json.Marshal(state)
+		if string(after) != string(before) {
+			t.Fatalf("SetValue(%q, %v) changed the state but GetValue returns %#v:\n%s\n%s", target, value, got, before, after)
+		}
+	})
+}
+
+// readBack indica se GetValue devolveu o valor gravado. Em wildcards basta um match: elementos
+// com formatos diferentes (ex: array onde se espera objeto) são ignorados por SetValue.
+func readBack(got interface{}, value float64, wildcard bool) bool {
+	if !wildcard {
+		return reflect.DeepEqual(got, value)
+	}
+	values, ok := got.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, v := range values {
+		// Um wildcard no último passo devolve o array inteiro como um único match
+		if reflect.DeepEqual(v, value) || readBack(v, value, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestSetValue_PathCreation: chaves ausentes viram objetos, índices explícitos criam o array
+// (elementos nil viram objetos) e nada é criado no caminho até um wildcard
+func TestSetValue_PathCreation(t *testing.T) {
+	tests := []struct {
+		target   string
+		expected string
+	}{
+		{"fields.a.b", `{"a":{"b":1},"nils":[null]}`},
+		{"fields.list[1].x", `{"list":[null,{"x":1}],"nils":[null]}`},
+		{"fields.nils[0].x", `{"nils":[{"x":1}]}`},
+		{"fields.m[0]", `{"m":[1],"nils":[null]}`},
+		// Wildcard sem elementos: o estado não muda
+		{"fields.list[*].x", `{"nils":[null]}`},
+		{"fields.a.list[*].x", `{"nils":[null]}`},
+	}
+	for _, tt := range tests {
+		state := &core.State{Fields: map[string]interface{}{"nils": []interface{}{nil}}}
+		if err := SetValue(state, tt.target, 1.0); err != nil {
+			t.Fatalf("SetValue(%q): %v", tt.target, err)
+		}
+		if got, _ := json.Marshal(state.Fields); string(got) != tt.expected {
+			t.Fatalf("SetValue(%q): fields = %s, want %s", tt.target, got, tt.expected)
+		}
+	}
+}
+
+// TestSetValueAtIndices_PathCreation: com os índices informados, o passo com wildcard (ou
+// índice) ausente vira array, expandido até o índice; os demais passos viram objetos
+func TestSetValueAtIndices_PathCreation(t *testing.T) {
+	tests := []struct {
+		target   string
+		expected string
+	}{
+		{"fields.rows[*].x", `{"nils":[null],"rows":[null,{"x":1}]}`},
+		{"fields.a.b", `{"a":{"b":1},"nils":[null]}`},
+		{"fields.g[0].cells[*].v", `{"g":[{"cells":[null,{"v":1}]}],"nils":[null]}`},
+		{"fields.nils[*].x", `{"nils":[null,{"x":1}]}`},
+	}
+	for _, tt := range tests {
+		state := &core.State{Fields: map[string]interface{}{"nils": []interface{}{nil}}}
+		if err := SetValueAtIndices(state, tt.target, []int{1}, 1.0); err != nil {
+			t.Fatalf("SetValueAtIndices(%q): %v", tt.target, err)
+		}
+		if got, _ := json.Marshal(state.Fields); string(got) != tt.expected {
+			t.Fatalf("SetValueAtIndices(%q): fields = %s, want %s", tt.target, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/taxes"
+)
+
+// ExecuteTaxesAction executa ação "taxes": calcula ICMS, ICMS-ST, IPI, PIS/COFINS e DIFAL
+// (taxes.Calculate) e grava o detalhamento em target, por elemento quando o target tem wildcards.
+//
+// Cada params é um campo de taxes.Input (value, cst, icmsRate, mva, ...), literal ou JsonLogic
+// avaliado no escopo do elemento. O detalhamento gravado tem a forma:
+//
+//	{"icms": {"base", "rate", "value"}, "icmsSt": {...}, "ipi": {...}, "pis": {...},
+//	 "cofins": {...}, "difal": {"base", "value", "fcp"}, "added": IPI + ICMS-ST}
+func ExecuteTaxesAction(ctx *core.EngineContext, action core.Action, evalData map[string]interface{}) (*core.Reason, *core.Violation, error) {
+	if action.Target == "" {
+		return nil, nil, fmt.Errorf("taxes action requires target")
+	}
+	if len(action.Params) == 0 {
+		return nil, nil, fmt.Errorf("taxes action requires params")
+	}
+
+	steps, err := ParsePath(action.Target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !HasWildcard(steps) {
+		breakdown, err := calculateTaxes(action.Params, evalData, evalData)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := SetValue(ctx.State, action.Target, breakdown); err != nil {
+			return nil, nil, err
+		}
+		return &core.Reason{Message: fmt.Sprintf("taxes %s calculated", action.Target)}, nil, nil
+	}
+
+	count := 0
+	_, err = visitLeaves(ctx.State, steps, true, func(ref leafRef, selections []selectedValue) error {
+		breakdown, err := calculateTaxes(action.Params, buildEvalDataForSelections(evalData, selections), evalData)
+		if err != nil {
+			return err
+		}
+		count++
+		return ref.Set(breakdown)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &core.Reason{Message: fmt.Sprintf("taxes %s calculated for %d elements", action.Target, count)}, nil, nil
+}
+
+// calculateTaxes avalia os params no escopo data e retorna o detalhamento como mapa
+func calculateTaxes(params map[string]interface{}, data, root map[string]interface{}) (map[string]interface{}, error) {
+	values, err := evaluateParams(params, data, root)
+	if err != nil {
+		return nil, fmt.Errorf("taxes: %w", err)
+	}
+	var input taxes.Input
+	if err := decodeStrict(values, &input); err != nil {
+		return nil, fmt.Errorf("taxes: invalid params: %w", err)
+	}
+	breakdown, err := taxes.Calculate(input)
+	if err != nil {
+		return nil, fmt.Errorf("taxes: %w", err)
+	}
+
+	var out map[string]interface{}
+	if err := decodeStrict(breakdown, &out); err != nil {
+		return nil, fmt.Errorf("taxes: %w", err)
+	}
+	return out, nil
+}
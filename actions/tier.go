@@ -0,0 +1,116 @@
+package actions
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/operators"
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+// ExecuteTieredDiscountAction executa ação "tieredDiscount": calcula o desconto por faixas
+// (operators.ApplyTiers) e grava em target, por elemento quando o target tem wildcards.
+//
+// Params:
+//   - tiers:     faixas (literal ou JsonLogic), ex: [{"upTo": 10, "rate": 0}, {"rate": 5}]
+//   - quantity:  valor que seleciona a faixa (JsonLogic por elemento; padrão: amount)
+//   - base:      valor sobre o qual o percentual incide (padrão: quantity)
+//   - mode:      "allUnits" (padrão) ou "incremental"
+//   - output:    "amount" (padrão, base * percentual) ou "rate"
+//   - precision: casas decimais do resultado (opcional)
+func ExecuteTieredDiscountAction(ctx *core.EngineContext, action core.Action, evalData map[string]interface{}) (*core.Reason, *core.Violation, error) {
+	if action.Target == "" {
+		return nil, nil, fmt.Errorf("tieredDiscount action requires target")
+	}
+	if action.Params["tiers"] == nil {
+		return nil, nil, fmt.Errorf("tieredDiscount action requires params.tiers")
+	}
+	mode, _ := action.Params["mode"].(string)
+	output, _ := action.Params["output"].(string)
+	if output == "" {
+		output = "amount"
+	}
+	if output != "amount" && output != "rate" {
+		return nil, nil, fmt.Errorf("tieredDiscount: unknown output %q (use amount or rate)", output)
+	}
+
+	steps, err := ParsePath(action.Target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !HasWildcard(steps) {
+		result, err := tieredDiscount(action, mode, output, evalData, evalData)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := SetValue(ctx.State, action.Target, result); err != nil {
+			return nil, nil, err
+		}
+		return &core.Reason{Message: fmt.Sprintf("tiered discount %s = %v", action.Target, result)}, nil, nil
+	}
+
+	count := 0
+	_, err = visitLeaves(ctx.State, steps, true, func(ref leafRef, selections []selectedValue) error {
+		result, err := tieredDiscount(action, mode, output, buildEvalDataForSelections(evalData, selections), evalData)
+		if err != nil {
+			return err
+		}
+		count++
+		return ref.Set(result)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &core.Reason{Message: fmt.Sprintf("tiered discount %s for %d elements", action.Target, count)}, nil, nil
+}
+
+// tieredDiscount calcula o resultado para um elemento (data) com root como dados raiz
+func tieredDiscount(action core.Action, mode, output string, data, root map[string]interface{}) (float64, error) {
+	tiers, err := operators.EvaluateTiers(action.Params["tiers"], data, root)
+	if err != nil {
+		return 0, fmt.Errorf("tieredDiscount: tiers: %w", err)
+	}
+
+	quantity := pkg.ToFloat64(data["amount"])
+	if raw, ok := action.Params["quantity"]; ok {
+		if quantity, err = evaluateNumberParam(raw, data, root); err != nil {
+			return 0, fmt.Errorf("tieredDiscount: quantity: %w", err)
+		}
+	}
+	base := quantity
+	if raw, ok := action.Params["base"]; ok {
+		if base, err = evaluateNumberParam(raw, data, root); err != nil {
+			return 0, fmt.Errorf("tieredDiscount: base: %w", err)
+		}
+	}
+
+	rate, _, err := operators.ApplyTiers(quantity, tiers, mode)
+	if err != nil {
+		return 0, fmt.Errorf("tieredDiscount: %w", err)
+	}
+	result := rate
+	if output == "amount" {
+		result = base * rate / 100
+	}
+
+	if p, ok := action.Params["precision"]; ok {
+		factor := math.Pow(10, pkg.ToFloat64(p))
+		result = math.Round(result*factor) / factor
+	}
+	return result, nil
+}
+
+// evaluateNumberParam avalia um parâmetro numérico literal ou JsonLogic
+func evaluateNumberParam(raw interface{}, data, root map[string]interface{}) (float64, error) {
+	logic, ok := raw.(map[string]interface{})
+	if !ok {
+		return pkg.ToFloat64(raw), nil
+	}
+	result, err := operators.EvaluateJsonLogicWithRoot(logic, data, root)
+	if err != nil {
+		return 0, err
+	}
+	return pkg.ToFloat64(result), nil
+}
@@ -0,0 +1,209 @@
+package engine
+
+import (
+	"context"
+	"iter"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+// MaxBatchErrors é quantos erros BatchStats.Errors guarda (os demais só entram em Failed)
+const MaxBatchErrors = 100
+
+// BatchOptions configura RunBatch
+type BatchOptions struct {
+	Context core.ContextMeta // metadados de todas as execuções (Now vazio = horário do início do lote)
+	Workers int              // execuções simultâneas (padrão: runtime.GOMAXPROCS(0))
+	Ordered bool             // entregar os resultados na ordem da entrada (padrão: à medida que terminam)
+
+	// OnResult recebe cada resultado, inclusive os com erro, na goroutine de RunBatch (um por vez).
+	// Um erro retornado interrompe o lote e é devolvido por RunBatch.
+	OnResult func(BatchResult) error
+}
+
+// BatchResult é o resultado de um State do lote
+type BatchResult struct {
+	Index  int                   // posição do State na entrada
+	Result *core.RunEngineResult // nil quando Err != nil
+	Err    error
+
+	fired map[string]bool // regras disparadas na execução (para BatchStats.RuleHits)
+}
+
+// BatchStats agrega as execuções de um lote (contadores; os resultados não são guardados)
+type BatchStats struct {
+	States     int            `json:"states"`     // execuções concluídas
+	Failed     int            `json:"failed"`     // execuções com erro
+	Violations map[string]int `json:"violations"` // violações por código
+	RuleHits   map[string]int `json:"ruleHits"`   // execuções em que a regra disparou (condition verdadeira ou ausente)
+	Errors     []BatchError   `json:"errors,omitempty"`
+	Duration   time.Duration  `json:"duration"`
+}
+
+// BatchError é um erro de execução do lote (os primeiros MaxBatchErrors)
+type BatchError struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+func (s *BatchStats) add(r BatchResult) {
+	s.States++
+	if r.Err != nil {
+		s.Failed++
+		if len(s.Errors) < MaxBatchErrors {
+			s.Errors = append(s.Errors, BatchError{Index: r.Index, Error: r.Err.Error()})
+		}
+		return
+	}
+	for _, v := range r.Result.Violations {
+		s.Violations[v.Code]++
+	}
+	for ruleID := range r.fired {
+		s.RuleHits[ruleID]++
+	}
+}
+
+// ruleHits implementa core.CoverageRecorder para uma execução do lote: guarda as regras
+// disparadas e repassa os eventos ao coletor de cobertura, quando houver
+type ruleHits struct {
+	fired map[string]bool
+	next  core.CoverageRecorder
+}
+
+func (h *ruleHits) RecordRule(ruleID string, fired bool) {
+	if fired {
+		h.fired[ruleID] = true
+	}
+	if h.next != nil {
+		h.next.RecordRule(ruleID, fired)
+	}
+}
+
+func (h *ruleHits) RecordAction(ruleID string, index int, violated bool) {
+	if h.next != nil {
+		h.next.RecordAction(ruleID, index, violated)
+	}
+}
+
+func (h *ruleHits) RecordBranch(id int, taken bool) {
+	if h.next != nil {
+		h.next.RecordBranch(id, taken)
+	}
+}
+
+// RunBatch avalia states contra o mesmo RulePack, compilado uma única vez, com até opts.Workers
+// execuções simultâneas. Cada resultado é entregue a opts.OnResult assim que possível (ou na ordem
+// da entrada, com opts.Ordered) e descartado em seguida; a memória usada não depende do tamanho do lote.
+//
+// Um RulePack inválido retorna erro antes de consumir states. Erros de um State não interrompem o
+// lote: vão para o BatchResult e para as estatísticas. O cancelamento de ctx interrompe o lote
+// (as estatísticas parciais são retornadas com o erro do contexto).
+func RunBatch(ctx context.Context, states iter.Seq[core.State], rules core.RulePack, opts BatchOptions) (*BatchStats, error) {
+	started := time.Now()
+	contextMeta := opts.Context
+	if contextMeta.Now == "" {
+		contextMeta.Now = started.UTC().Format(time.RFC3339)
+	}
+	compiled, err := compileRulePack(rules, contextMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type job struct {
+		index int
+		state core.State
+	}
+	jobs := make(chan job)
+	results := make(chan BatchResult, workers)
+	// Execuções despachadas e ainda não entregues: limita o que fica em memória (inclusive os
+	// resultados que aguardam os anteriores, com Ordered)
+	window := make(chan struct{}, 2*workers)
+
+	go func() {
+		defer close(jobs)
+		index := 0
+		for state := range states {
+			select {
+			case window <- struct{}{}:
+			case <-runCtx.Done():
+				return
+			}
+			select {
+			case jobs <- job{index: index, state: state}:
+			case <-runCtx.Done():
+				return
+			}
+			index++
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				hits := &ruleHits{fired: make(map[string]bool)}
+				result, err := compiled.run(runCtx, j.state, contextMeta, hits)
+				results <- BatchResult{Index: j.index, Result: result, Err: err, fired: hits.fired}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	stats := &BatchStats{Violations: make(map[string]int), RuleHits: make(map[string]int)}
+	var handlerErr error
+	deliver := func(r BatchResult) {
+		<-window
+		if runCtx.Err() != nil {
+			// Interrompido: descartar as execuções em andamento
+			return
+		}
+		stats.add(r)
+		if opts.OnResult != nil {
+			if err := opts.OnResult(r); err != nil {
+				handlerErr = err
+				cancel()
+			}
+		}
+	}
+
+	pending := make(map[int]BatchResult)
+	next := 0
+	for r := range results {
+		if !opts.Ordered {
+			deliver(r)
+			continue
+		}
+		pending[r.Index] = r
+		for {
+			p, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			deliver(p)
+			next++
+		}
+	}
+
+	stats.Duration = time.Since(started)
+	if handlerErr != nil {
+		return stats, handlerErr
+	}
+	return stats, ctx.Err()
+}
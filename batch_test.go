@@ -0,0 +1,171 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+
+	engine "github.com/dolphin-sistemas/computations-engine"
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/internal/benchdata"
+)
+
+// batchStates gera n pedidos: os de índice par com limite de crédito baixo (violação CREDIT_LIMIT)
+// e, a cada 5, um com moeda inválida (erro de execução)
+func batchStates(n int) iter.Seq[core.State] {
+	return func(yield func(core.State) bool) {
+		for i := 0; i < n; i++ {
+			state := benchdata.Order(1 + i%7)
+			if i%2 == 0 {
+				state.Fields["customer"].(map[string]interface{})["limit"] = 1.0
+			}
+			if i%5 == 4 {
+				state.Currency = "XXX"
+			}
+			if !yield(state) {
+				return
+			}
+		}
+	}
+}
+
+func TestRunBatch_OrderedMatchesRunEngine(t *testing.T) {
+	pack := benchdata.Pack(8)
+	var want []string
+	for state := range batchStates(40) {
+		result, err := engine.RunEngine(context.Background(), state, pack, benchdata.Context)
+		if err != nil {
+			want = append(want, "error: "+err.Error())
+			continue
+		}
+		want = append(want, marshal(t, result))
+	}
+
+	var got []string
+	stats, err := engine.RunBatch(context.Background(), batchStates(40), pack, engine.BatchOptions{
+		Context: benchdata.Context,
+		Workers: 4,
+		Ordered: true,
+		OnResult: func(r engine.BatchResult) error {
+			if r.Index != len(got) {
+				t.Fatalf("result %d delivered at position %d", r.Index, len(got))
+			}
+			if r.Err != nil {
+				got = append(got, "error: "+r.Err.Error())
+			} else {
+				got = append(got, marshal(t, r.Result))
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("RunBatch failed: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d results, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("result %d differs from RunEngine:\n%s\n%s", i, got[i], want[i])
+		}
+	}
+
+	// 40 pedidos, 8 com moeda inválida; das 32 execuções, 16 (índices pares) violam o limite
+	if stats.States != 40 || stats.Failed != 8 || len(stats.Errors) != 8 {
+		t.Fatalf("stats = %d states, %d failed, %d errors; want 40, 8, 8", stats.States, stats.Failed, len(stats.Errors))
+	}
+	if !strings.Contains(stats.Errors[0].Error, "unknown currency") || stats.Errors[0].Index != 4 {
+		t.Fatalf("first error = %+v", stats.Errors[0])
+	}
+	if stats.Violations["CREDIT_LIMIT"] != 16 {
+		t.Fatalf("violations = %v, want CREDIT_LIMIT: 16", stats.Violations)
+	}
+	// credit-limit não tem condition: dispara em todas as execuções, mesmo sem violação
+	if stats.RuleHits["item-total"] != 32 || stats.RuleHits["credit-limit"] != 32 {
+		t.Fatalf("rule hits = %v, want item-total: 32, credit-limit: 32", stats.RuleHits)
+	}
+}
+
+func TestRunBatch_AsCompleted(t *testing.T) {
+	seen := make(map[int]bool)
+	stats, err := engine.RunBatch(context.Background(), batchStates(100), benchdata.Pack(32), engine.BatchOptions{
+		Context: benchdata.Context,
+		Workers: 8,
+		OnResult: func(r engine.BatchResult) error {
+			if seen[r.Index] {
+				t.Fatalf("result %d delivered twice", r.Index)
+			}
+			seen[r.Index] = true
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("RunBatch failed: %v", err)
+	}
+	if len(seen) != 100 || stats.States != 100 {
+		t.Fatalf("delivered %d results, stats.States = %d; want 100", len(seen), stats.States)
+	}
+}
+
+func TestRunBatch_InvalidPack(t *testing.T) {
+	consumed := false
+	states := func(yield func(core.State) bool) {
+		consumed = true
+	}
+	_, err := engine.RunBatch(context.Background(), states, core.RulePack{}, engine.BatchOptions{})
+	if err == nil || !strings.Contains(err.Error(), "rulePack.id is required") {
+		t.Fatalf("expected rulePack.id error, got %v", err)
+	}
+	if consumed {
+		t.Fatal("states were consumed for an invalid pack")
+	}
+}
+
+func TestRunBatch_StopsOnHandlerError(t *testing.T) {
+	stop := errors.New("stop")
+	delivered := 0
+	stats, err := engine.RunBatch(context.Background(), batchStates(1000), benchdata.Pack(8), engine.BatchOptions{
+		Context: benchdata.Context,
+		Workers: 2,
+		Ordered: true,
+		OnResult: func(r engine.BatchResult) error {
+			delivered++
+			if delivered == 3 {
+				return stop
+			}
+			return nil
+		},
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+	if delivered != 3 || stats.States != 3 {
+		t.Fatalf("delivered %d results, stats.States = %d; want 3", delivered, stats.States)
+	}
+}
+
+func TestRunBatch_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	delivered := 0
+	stats, err := engine.RunBatch(ctx, batchStates(1000), benchdata.Pack(8), engine.BatchOptions{
+		Context: benchdata.Context,
+		Workers: 2,
+		OnResult: func(r engine.BatchResult) error {
+			delivered++
+			if delivered == 5 {
+				cancel()
+			}
+			return nil
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if delivered != 5 || stats.States != 5 {
+		t.Fatalf("delivered %d results, stats.States = %d after cancel; want 5", delivered, stats.States)
+	}
+}
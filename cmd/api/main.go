@@ -0,0 +1,81 @@
+// Command api expõe o motor de regras via HTTP.
+//
+//	go run ./cmd/api -addr :8080 -packs ./packs
+//
+// Endpoints:
+//
+//	POST /v1/run               {state, rulePack, context} -> RunEngineResult
+//	POST /v1/packs/{id}/run    {state, context}, RulePack do diretório -packs (?version=, padrão: a mais recente)
+//	GET  /v1/packs             packs carregados
+//	POST /v1/lint              valida um RulePack (JSON ou YAML) sem executar
+//	GET  /healthz, /readyz     saúde e prontidão
+//
+// SIGHUP relê o diretório de packs; SIGINT/SIGTERM encerram aguardando as requisições em andamento.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "endereço HTTP")
+	packsDir := flag.String("packs", "", "diretório de RulePacks (.json/.yaml) para /v1/packs")
+	maxBody := flag.Int64("max-body", 4<<20, "tamanho máximo do corpo das requisições (bytes)")
+	timeout := flag.Duration("timeout", 10*time.Second, "prazo de cada execução do motor")
+	flag.Parse()
+
+	store := newPackStore(*packsDir)
+	if err := store.Load(); err != nil {
+		log.Fatal(err)
+	}
+	srv := newServer(config{MaxBodyBytes: *maxBody, Timeout: *timeout}, store)
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           srv.routes(),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      *timeout + 30*time.Second,
+		IdleTimeout:       2 * time.Minute,
+	}
+
+	// SIGHUP: recarregar packs (mantém os anteriores se algum arquivo for inválido)
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if err := store.Load(); err != nil {
+				log.Printf("reload failed (keeping %d packs): %v", store.Len(), err)
+				continue
+			}
+			log.Printf("reloaded %d packs", store.Len())
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		srv.ready.Store(false)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *timeout+5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("shutdown: %v", err)
+		}
+	}()
+
+	srv.ready.Store(true)
+	log.Printf("listening on %s (%d packs)", *addr, store.Len())
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dolphin-sistemas/computations-engine"
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/loader"
+)
+
+// Códigos de erro das respostas ({"error": {"code": ..., "message": ...}})
+const (
+	codeInvalidRequest   = "invalid_request"
+	codePayloadTooLarge  = "payload_too_large"
+	codeNotFound         = "not_found"
+	codeMethodNotAllowed = "method_not_allowed"
+	codeInvalidPack      = "invalid_rule_pack"
+	codeEngineError      = "engine_error"
+	codeTimeout          = "timeout"
+	codeCanceled         = "canceled"
+	codeInternal         = "internal_error"
+)
+
+// config são os limites do servidor
+type config struct {
+	MaxBodyBytes int64         // tamanho máximo do corpo das requisições
+	Timeout      time.Duration // prazo de cada execução do motor
+}
+
+// server expõe o motor via HTTP
+type server struct {
+	cfg   config
+	store *packStore
+	ready atomic.Bool
+}
+
+// runRequest é o envelope de POST /v1/run (o mesmo do cmd/wasm)
+type runRequest struct {
+	State    core.State       `json:"state"`
+	RulePack core.RulePack    `json:"rulePack"`
+	Context  core.ContextMeta `json:"context"`
+}
+
+// packRunRequest é o corpo de POST /v1/packs/{id}/run (o RulePack vem do store)
+type packRunRequest struct {
+	State   core.State       `json:"state"`
+	Context core.ContextMeta `json:"context"`
+}
+
+// apiError é o corpo das respostas de erro
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// lintResult é a resposta de POST /v1/lint
+type lintResult struct {
+	Valid   bool      `json:"valid"`
+	ID      string    `json:"id,omitempty"`
+	Version string    `json:"version,omitempty"`
+	Error   *apiError `json:"error,omitempty"`
+}
+
+func newServer(cfg config, store *packStore) *server {
+	return &server{cfg: cfg, store: store}
+}
+
+// routes registra os endpoints
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /readyz", s.handleReady)
+	mux.HandleFunc("POST /v1/run", s.handleRun)
+	mux.HandleFunc("GET /v1/packs", s.handleListPacks)
+	mux.HandleFunc("POST /v1/packs/{id}/run", s.handlePackRun)
+	mux.HandleFunc("POST /v1/lint", s.handleLint)
+
+	// Rotas inexistentes e métodos não permitidos também respondem com erro estruturado
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler, pattern := mux.Handler(r)
+		if pattern != "" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+		rec := &statusRecorder{header: http.Header{}}
+		handler.ServeHTTP(rec, r)
+		if allow := rec.header.Get("Allow"); allow != "" {
+			w.Header().Set("Allow", allow)
+		}
+		code := codeNotFound
+		if rec.code == http.StatusMethodNotAllowed {
+			code = codeMethodNotAllowed
+		}
+		writeError(w, rec.code, code, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+	})
+}
+
+// statusRecorder guarda o status e os cabeçalhos da resposta padrão do mux (404/405),
+// descartando o corpo em texto
+type statusRecorder struct {
+	header http.Header
+	code   int
+}
+
+func (r *statusRecorder) Header() http.Header { return r.header }
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.code == 0 {
+		r.code = code
+	}
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return len(b), nil
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if !s.ready.Load() {
+		writeError(w, http.StatusServiceUnavailable, "not_ready", "server is not ready")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ready", "packs": s.store.Len()})
+}
+
+func (s *server) handleRun(w http.ResponseWriter, r *http.Request) {
+	var req runRequest
+	if !s.decode(w, r, &req) {
+		return
+	}
+	s.run(w, r, req.State, req.RulePack, req.Context)
+}
+
+func (s *server) handlePackRun(w http.ResponseWriter, r *http.Request) {
+	id, version := r.PathValue("id"), r.URL.Query().Get("version")
+	pack, ok := s.store.Get(id, version)
+	if !ok {
+		ref := id
+		if version != "" {
+			ref += "@" + version
+		}
+		writeError(w, http.StatusNotFound, codeNotFound, fmt.Sprintf("rule pack %s not found", ref))
+		return
+	}
+
+	var req packRunRequest
+	if !s.decode(w, r, &req) {
+		return
+	}
+	s.run(w, r, req.State, pack.Pack, req.Context)
+}
+
+func (s *server) handleListPacks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"packs": s.store.List()})
+}
+
+// handleLint valida um RulePack (JSON ou, com Content-Type YAML, YAML) sem executá-lo.
+// O pack vem da requisição: tabelas com source são rejeitadas, sem ler arquivos do servidor.
+func (s *server) handleLint(w http.ResponseWriter, r *http.Request) {
+	body, ok := s.readBody(w, r)
+	if !ok {
+		return
+	}
+
+	var pack core.RulePack
+	var err error
+	if isYAML(r.Header.Get("Content-Type")) {
+		pack, err = loader.LoadRulePackFromYAML(body)
+	} else {
+		pack, err = loader.LoadRulePackFromJSON(body)
+	}
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, lintResult{Error: &apiError{Code: codeInvalidPack, Message: err.Error()}})
+		return
+	}
+	writeJSON(w, http.StatusOK, lintResult{Valid: true, ID: pack.ID, Version: pack.Version})
+}
+
+// run executa o motor com o prazo configurado e escreve o resultado
+func (s *server) run(w http.ResponseWriter, r *http.Request, state core.State, pack core.RulePack, meta core.ContextMeta) {
+	ctx := r.Context()
+	if s.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+		defer cancel()
+	}
+
+	result, err := engine.RunEngine(ctx, state, pack, meta)
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, result)
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, codeTimeout, err.Error())
+	case errors.Is(err, context.Canceled):
+		// O cliente desistiu; a resposta provavelmente não será lida
+		writeError(w, http.StatusServiceUnavailable, codeCanceled, err.Error())
+	default:
+		writeError(w, http.StatusUnprocessableEntity, codeEngineError, err.Error())
+	}
+}
+
+// decode lê o corpo JSON em out; em erro escreve a resposta e retorna false
+func (s *server) decode(w http.ResponseWriter, r *http.Request, out interface{}) bool {
+	body, ok := s.readBody(w, r)
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidRequest, "failed to parse input: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// readBody lê o corpo respeitando MaxBodyBytes
+func (s *server) readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	reader := io.Reader(r.Body)
+	if s.cfg.MaxBodyBytes > 0 {
+		reader = http.MaxBytesReader(w, r.Body, s.cfg.MaxBodyBytes)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, codePayloadTooLarge, fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit))
+			return nil, false
+		}
+		writeError(w, http.StatusBadRequest, codeInvalidRequest, "failed to read body: "+err.Error())
+		return nil, false
+	}
+	if len(body) == 0 {
+		writeError(w, http.StatusBadRequest, codeInvalidRequest, "request body is empty")
+		return nil, false
+	}
+	return body, true
+}
+
+func isYAML(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return strings.Contains(contentType, "yaml")
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]apiError{"error": {Code: code, Message: message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("failed to marshal response: %v", err)
+		status = http.StatusInternalServerError
+		data, _ = json.Marshal(map[string]apiError{"error": {Code: codeInternal, Message: "failed to marshal response"}})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(data, '\n'))
+}
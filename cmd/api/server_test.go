@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testPack = `{
+  "id": "pricing",
+  "version": "%s",
+  "phases": [{
+    "name": "baseline",
+    "rules": [{
+      "id": "total", "phase": "baseline", "priority": 1, "enabled": true,
+      "actions": [{"type": "compute", "target": "totals.total", "logic": {"*": [{"var": "price"}, %s]}}]
+    }]
+  }]
+}`
+
+func newTestServer(t *testing.T, cfg config) *httptest.Server {
+	t.Helper()
+	dir := t.TempDir()
+	for file, content := range map[string]string{
+		"pricing-v1.json":  strings.Replace(strings.Replace(testPack, "%s", "v1.2.0", 1), "%s", "1", 1),
+		"pricing-v10.json": strings.Replace(strings.Replace(testPack, "%s", "v1.10.0", 1), "%s", "2", 1),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store := newPackStore(dir)
+	if err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	srv := newServer(cfg, store)
+	srv.ready.Store(true)
+	ts := httptest.NewServer(srv.routes())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestServer(t *testing.T) {
+	ts := newTestServer(t, config{MaxBodyBytes: 2048, Timeout: 5 * time.Second})
+	state := `{"fields": {"price": 50}}`
+	inline := strings.Replace(strings.Replace(testPack, "%s", "v9", 1), "%s", "3", 1)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		status      int
+		contains    string
+	}{
+		{"health", "GET", "/healthz", "", "", http.StatusOK, `"ok"`},
+		{"ready", "GET", "/readyz", "", "", http.StatusOK, `"packs":2`},
+		{"run inline pack", "POST", "/v1/run", "", `{"state": ` + state + `, "rulePack": ` + inline + `}`, http.StatusOK, `"total":150`},
+		{"run latest pack", "POST", "/v1/packs/pricing/run", "", `{"state": ` + state + `}`, http.StatusOK, `"total":100`},
+		{"run pack version", "POST", "/v1/packs/pricing/run?version=v1.2.0", "", `{"state": ` + state + `}`, http.StatusOK, `"total":50`},
+		{"unknown pack", "POST", "/v1/packs/missing/run", "", `{}`, http.StatusNotFound, `"code":"not_found"`},
+		{"list packs", "GET", "/v1/packs", "", "", http.StatusOK, `"version":"v1.10.0"`},
+		{"invalid json", "POST", "/v1/run", "", `{"state":`, http.StatusBadRequest, `"code":"invalid_request"`},
+		{"empty body", "POST", "/v1/run", "", ``, http.StatusBadRequest, `request body is empty`},
+		{"body too large", "POST", "/v1/run", "", `{"state": {"fields": {"x": "` + strings.Repeat("a", 4096) + `"}}}`, http.StatusRequestEntityTooLarge, `"code":"payload_too_large"`},
+		{"engine error", "POST", "/v1/run", "", `{"state": {}, "rulePack": {"phases": []}}`, http.StatusUnprocessableEntity, `rulePack.id is required`},
+		{"lint valid", "POST", "/v1/lint", "", inline, http.StatusOK, `"valid":true`},
+		{"lint yaml", "POST", "/v1/lint", "application/yaml", "id: p\nversion: v1\nphases: []\n", http.StatusOK, `"id":"p"`},
+		{"lint invalid", "POST", "/v1/lint", "", `{"id": "p"}`, http.StatusUnprocessableEntity, `rulePack.version is required`},
+		// O pack da requisição não lê arquivos do servidor (server.go existe no diretório de trabalho)
+		{"lint table source", "POST", "/v1/lint", "", `{"id": "p", "version": "v1", "tables": {"t": {"source": "server.go", "columns": [{"name": "k"}]}}, "phases": []}`, http.StatusUnprocessableEntity, `source is only supported when loading the rule pack from a file`},
+		{"method not allowed", "GET", "/v1/run", "", "", http.StatusMethodNotAllowed, `"code":"method_not_allowed"`},
+		{"unknown route", "GET", "/v2/run", "", "", http.StatusNotFound, `"code":"not_found"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, ts.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			var body json.RawMessage
+			json.NewDecoder(resp.Body).Decode(&body)
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d (body %s)", resp.StatusCode, tt.status, body)
+			}
+			if !strings.Contains(string(body), tt.contains) {
+				t.Errorf("body %s does not contain %s", body, tt.contains)
+			}
+		})
+	}
+}
+
+// TestPackStore_SkipsTableSources: arquivos usados como source de tabelas não são carregados como packs
+func TestPackStore_SkipsTableSources(t *testing.T) {
+	dir := t.TempDir()
+	pack := `{
+	  "id": "prices", "version": "v1",
+	  "tables": {"prices": {"source": "tables/prices.json", "columns": [{"name": "sku", "match": "exact"}, {"name": "price", "type": "number"}]}},
+	  "phases": []
+	}`
+	if err := os.MkdirAll(filepath.Join(dir, "tables"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for file, content := range map[string]string{
+		"prices.json":        pack,
+		"tables/prices.json": `[{"sku": "A", "price": 10}]`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := newPackStore(dir)
+	if err := store.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	loaded, ok := store.Get("prices", "")
+	if !ok || store.Len() != 1 {
+		t.Fatalf("expected only the prices pack, got %v", store.List())
+	}
+	if rows := len(loaded.Pack.Tables["prices"].Rows); rows != 1 {
+		t.Fatalf("expected 1 table row, got %d", rows)
+	}
+
+	// Um arquivo que não é pack nem source continua sendo erro
+	if err := os.WriteFile(filepath.Join(dir, "tables", "orphan.json"), []byte(`[1]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Load(); err == nil {
+		t.Fatal("expected error for a file that is neither a pack nor a table source")
+	}
+}
+
+func TestServer_Timeout(t *testing.T) {
+	ts := newTestServer(t, config{Timeout: time.Nanosecond})
+	resp, err := http.Post(ts.URL+"/v1/packs/pricing/run", "application/json", strings.NewReader(`{"state": {}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusGatewayTimeout)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"v1.10.0", "v1.9.2", 1},
+		{"v1.2", "v1.2.0", -1},
+		{"1.0.0", "v1.0.0", 0},
+		{"v2.0.0-beta", "v2.0.0-alpha", 1},
+	} {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/loader"
+)
+
+// packStore mantém os RulePacks de um diretório local, indexados por id e versão
+type packStore struct {
+	dir string
+
+	mu    sync.RWMutex
+	packs map[string]map[string]storedPack // id -> versão -> pack
+}
+
+// storedPack é um RulePack carregado e o arquivo de origem
+type storedPack struct {
+	Pack core.RulePack
+	File string
+}
+
+// packInfo descreve um pack do store na listagem
+type packInfo struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+	File    string `json:"file"`
+}
+
+func newPackStore(dir string) *packStore {
+	return &packStore{dir: dir, packs: map[string]map[string]storedPack{}}
+}
+
+// Load lê (ou relê) todos os .json/.yaml/.yml do diretório, exceto os usados como source de
+// tabelas pelos próprios packs; com erro o conteúdo anterior é mantido
+func (s *packStore) Load() error {
+	packs := map[string]map[string]storedPack{}
+	if s.dir != "" {
+		var files []string
+		err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".json", ".yaml", ".yml":
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("load packs: %w", err)
+		}
+
+		sources := map[string]bool{}
+		for _, path := range files {
+			for _, source := range tableSources(path) {
+				sources[source] = true
+			}
+		}
+
+		for _, path := range files {
+			if sources[filepath.Clean(path)] {
+				continue
+			}
+			pack, err := loader.LoadRulePackFromFile(path)
+			if err != nil {
+				return fmt.Errorf("load packs: %s: %w", path, err)
+			}
+			rel, _ := filepath.Rel(s.dir, path)
+			if previous, ok := packs[pack.ID][pack.Version]; ok {
+				return fmt.Errorf("load packs: %s: pack %s@%s already loaded from %s", rel, pack.ID, pack.Version, previous.File)
+			}
+			if packs[pack.ID] == nil {
+				packs[pack.ID] = map[string]storedPack{}
+			}
+			packs[pack.ID][pack.Version] = storedPack{Pack: pack, File: filepath.ToSlash(rel)}
+		}
+	}
+
+	s.mu.Lock()
+	s.packs = packs
+	s.mu.Unlock()
+	return nil
+}
+
+// tableSources retorna os arquivos usados como source de tabelas pelo pack em path
+// (vazio quando o arquivo não é um RulePack, ex: o próprio source de uma tabela)
+func tableSources(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var pack struct {
+		Tables map[string]struct {
+			Source string `json:"source" yaml:"source"`
+		} `json:"tables" yaml:"tables"`
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &pack)
+	} else {
+		err = yaml.Unmarshal(data, &pack)
+	}
+	if err != nil {
+		return nil
+	}
+	var sources []string
+	for _, table := range pack.Tables {
+		if table.Source != "" && filepath.IsLocal(table.Source) {
+			sources = append(sources, filepath.Join(filepath.Dir(path), table.Source))
+		}
+	}
+	return sources
+}
+
+// Get retorna o pack pelo id e versão (versão vazia = a mais recente)
+func (s *packStore) Get(id, version string) (storedPack, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions := s.packs[id]
+	if version != "" {
+		pack, ok := versions[version]
+		return pack, ok
+	}
+	var latest string
+	for v := range versions {
+		if latest == "" || compareVersions(v, latest) > 0 {
+			latest = v
+		}
+	}
+	pack, ok := versions[latest]
+	return pack, ok
+}
+
+// List retorna os packs ordenados por id e versão
+func (s *packStore) List() []packInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := []packInfo{}
+	for id, versions := range s.packs {
+		for version, pack := range versions {
+			list = append(list, packInfo{ID: id, Version: version, File: pack.File})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ID != list[j].ID {
+			return list[i].ID < list[j].ID
+		}
+		return compareVersions(list[i].Version, list[j].Version) < 0
+	})
+	return list
+}
+
+// Len retorna a quantidade de packs carregados
+func (s *packStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	n := 0
+	for _, versions := range s.packs {
+		n += len(versions)
+	}
+	return n
+}
+
+// compareVersions compara versões pelos segmentos numéricos ("v1.10.0" > "v1.9.2");
+// segmentos não numéricos são comparados como texto
+func compareVersions(a, b string) int {
+	split := func(v string) []string {
+		return strings.FieldsFunc(strings.TrimPrefix(v, "v"), func(r rune) bool { return r == '.' || r == '-' })
+	}
+	pa, pb := split(a), split(b)
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		na, errA := strconv.Atoi(pa[i])
+		nb, errB := strconv.Atoi(pb[i])
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+				return 1
+			}
+		case pa[i] != pb[i]:
+			return strings.Compare(pa[i], pb[i])
+		}
+	}
+	switch {
+	case len(pa) < len(pb):
+		return -1
+	case len(pa) > len(pb):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dolphin-sistemas/computations-engine/coverage"
+)
+
+// coverCommand combina perfis gravados por "engine test --coverprofile" (ou coverage.WriteJSON)
+// e gera o relatório
+func coverCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("cover", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	html := flags.String("html", "", "grava o relatório HTML no arquivo")
+	asJSON := flags.Bool("json", false, "imprime o perfil combinado em JSON")
+	minimum := flags.Float64("min", 0, "falha se a cobertura total ficar abaixo do percentual")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "cover: at least one coverage profile is required")
+		return 2
+	}
+
+	var profiles []*coverage.Profile
+	for _, path := range args {
+		profile, err := coverage.ReadProfileFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "cover: %v\n", err)
+			return 1
+		}
+		profiles = append(profiles, profile)
+	}
+	merged := coverage.Merge(profiles...)
+
+	switch {
+	case *html != "":
+		f, err := os.Create(*html)
+		if err != nil {
+			fmt.Fprintf(stderr, "cover: %v\n", err)
+			return 1
+		}
+		err = coverage.WriteHTML(f, merged)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "cover: %v\n", err)
+			return 1
+		}
+	case *asJSON:
+		err = coverage.WriteJSON(stdout, merged)
+	default:
+		err = coverage.WriteText(stdout, merged)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "cover: %v\n", err)
+		return 1
+	}
+
+	if total := merged.Summary().Percent(); total < *minimum {
+		fmt.Fprintf(stderr, "cover: total coverage %.1f%% is below %.1f%%\n", total, *minimum)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Ordem canônica das chaves: firstKeys nessa ordem, depois as demais em ordem alfabética e,
+// por último, as coleções grandes (lastKeys), para que cabeçalhos venham antes do conteúdo
+var (
+	firstKeys = []string{"id", "name", "version", "description", "type", "phase", "priority", "enabled", "disabled", "condition", "target", "value", "logic", "params"}
+	lastKeys  = []string{"rows", "actions", "rules", "phases"}
+)
+
+func fmtCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	write := flags.Bool("w", false, "grava o resultado no próprio arquivo")
+	list := flags.Bool("l", false, "lista os arquivos fora da forma canônica (sai com 1 se houver)")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "fmt: no pack files")
+		return 2
+	}
+
+	status := 0
+	for _, path := range args {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "fmt: %v\n", err)
+			status = 1
+			continue
+		}
+		format, err := formatOf(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "fmt: %v\n", err)
+			status = 1
+			continue
+		}
+		out, err := canonicalize(data, format)
+		if err != nil {
+			fmt.Fprintf(stderr, "fmt: %s: %v\n", path, err)
+			status = 1
+			continue
+		}
+
+		switch {
+		case *list:
+			if !bytes.Equal(data, out) {
+				fmt.Fprintln(stdout, path)
+				status = 1
+			}
+		case *write:
+			if !bytes.Equal(data, out) {
+				if err := os.WriteFile(path, out, 0o644); err != nil {
+					fmt.Fprintf(stderr, "fmt: %v\n", err)
+					status = 1
+				}
+			}
+		default:
+			stdout.Write(out)
+		}
+	}
+	return status
+}
+
+func convertCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("o", "", "arquivo de saída; a extensão define o formato (padrão: stdout)")
+	to := flags.String("to", "", "formato de saída: json ou yaml (padrão: o oposto da entrada)")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(args) != 1 {
+		fmt.Fprintln(stderr, "convert: expected exactly one pack file")
+		return 2
+	}
+	path := args[0]
+
+	from, err := formatOf(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "convert: %v\n", err)
+		return 1
+	}
+	target := *to
+	switch {
+	case target != "":
+	case *output != "":
+		if target, err = formatOf(*output); err != nil {
+			fmt.Fprintf(stderr, "convert: %v\n", err)
+			return 1
+		}
+	case from == "json":
+		target = "yaml"
+	default:
+		target = "json"
+	}
+	if target != "json" && target != "yaml" {
+		fmt.Fprintf(stderr, "convert: unknown format %q (use json or yaml)\n", target)
+		return 2
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "convert: %v\n", err)
+		return 1
+	}
+	out, err := canonicalize(data, target)
+	if err != nil {
+		fmt.Fprintf(stderr, "convert: %s: %v\n", path, err)
+		return 1
+	}
+	if err := writeOutput(*output, stdout, out); err != nil {
+		fmt.Fprintf(stderr, "convert: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// formatOf deduz o formato pela extensão
+func formatOf(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json", nil
+	case ".yaml", ".yml":
+		return "yaml", nil
+	}
+	return "", fmt.Errorf("%s: unsupported file format (use .json or .yaml)", path)
+}
+
+// canonicalize lê JSON ou YAML (JSON é YAML válido) e escreve no formato pedido com as chaves na
+// ordem canônica e indentação de 2 espaços. Os valores são preservados como escritos (1.0 continua 1.0);
+// comentários YAML são mantidos na saída YAML.
+func canonicalize(data []byte, format string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		return nil, fmt.Errorf("empty document")
+	}
+	sortKeys(&doc)
+
+	if format == "json" {
+		var buf bytes.Buffer
+		if err := writeJSONNode(&buf, &doc, ""); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+		return buf.Bytes(), nil
+	}
+
+	blockStyle(&doc)
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sortKeys reordena os mapeamentos (recursivamente) na ordem canônica
+func sortKeys(n *yaml.Node) {
+	for _, child := range n.Content {
+		sortKeys(child)
+	}
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	type pair struct{ key, value *yaml.Node }
+	pairs := make([]pair, 0, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		pairs = append(pairs, pair{n.Content[i], n.Content[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		ri, rj := keyRank(pairs[i].key.Value), keyRank(pairs[j].key.Value)
+		if ri != rj {
+			return ri < rj
+		}
+		return pairs[i].key.Value < pairs[j].key.Value
+	})
+	for i, p := range pairs {
+		n.Content[2*i], n.Content[2*i+1] = p.key, p.value
+	}
+}
+
+// keyRank: posição em firstKeys, depois as demais chaves (empatadas), depois lastKeys
+func keyRank(key string) int {
+	for i, k := range firstKeys {
+		if k == key {
+			return i
+		}
+	}
+	for i, k := range lastKeys {
+		if k == key {
+			return len(firstKeys) + 1 + i
+		}
+	}
+	return len(firstKeys)
+}
+
+// blockStyle remove os estilos herdados do JSON (fluxo e aspas) para a saída YAML em blocos
+func blockStyle(n *yaml.Node) {
+	n.Style &^= yaml.FlowStyle | yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle
+	for _, child := range n.Content {
+		blockStyle(child)
+	}
+}
+
+// writeJSONNode escreve um nó YAML como JSON indentado
+func writeJSONNode(buf *bytes.Buffer, n *yaml.Node, indent string) error {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		return writeJSONNode(buf, n.Content[0], indent)
+	case yaml.AliasNode:
+		return writeJSONNode(buf, n.Alias, indent)
+	case yaml.MappingNode:
+		if len(n.Content) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		buf.WriteString("{\n")
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			buf.WriteString(indent + "  ")
+			writeJSONString(buf, n.Content[i].Value)
+			buf.WriteString(": ")
+			if err := writeJSONNode(buf, n.Content[i+1], indent+"  "); err != nil {
+				return err
+			}
+			if i+2 < len(n.Content) {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent + "}")
+		return nil
+	case yaml.SequenceNode:
+		if len(n.Content) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteString("[\n")
+		for i, child := range n.Content {
+			buf.WriteString(indent + "  ")
+			if err := writeJSONNode(buf, child, indent+"  "); err != nil {
+				return err
+			}
+			if i+1 < len(n.Content) {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent + "]")
+		return nil
+	case yaml.ScalarNode:
+		return writeJSONScalar(buf, n)
+	}
+	return fmt.Errorf("line %d: unsupported YAML node", n.Line)
+}
+
+func writeJSONScalar(buf *bytes.Buffer, n *yaml.Node) error {
+	switch n.ShortTag() {
+	case "!!null":
+		buf.WriteString("null")
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return err
+		}
+		buf.WriteString(strconv.FormatBool(b))
+	case "!!int", "!!float":
+		if json.Valid([]byte(n.Value)) {
+			buf.WriteString(n.Value)
+			return nil
+		}
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return err
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return fmt.Errorf("line %d: %s has no JSON representation", n.Line, n.Value)
+		}
+		buf.WriteString(strconv.FormatFloat(f, 'f', -1, 64))
+	default:
+		writeJSONString(buf, n.Value)
+	}
+	return nil
+}
+
+// writeJSONString escreve s como string JSON sem escapar <, > e & (comuns em JsonLogic)
+func writeJSONString(buf *bytes.Buffer, s string) {
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	buf.Write(bytes.TrimSuffix(out.Bytes(), []byte("\n")))
+}
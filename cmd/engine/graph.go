@@ -0,0 +1,230 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/loader"
+	"github.com/dolphin-sistemas/computations-engine/pipeline"
+)
+
+// graphCommand gera um grafo DOT: uma caixa por regra (agrupadas por fase, na ordem do pipeline) e
+// uma aresta de A para B quando B lê algo que A grava. Arestas tracejadas indicam que B executa antes
+// de A (B lê o valor anterior). A análise é estática e aproximada: variáveis calculadas em tempo de
+// execução (ex: {"var": {"cat": ...}}) não são seguidas.
+func graphCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("graph", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("o", "", "arquivo de saída (padrão: stdout)")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(args) != 1 {
+		fmt.Fprintln(stderr, "graph: expected exactly one pack file")
+		return 2
+	}
+
+	pack, err := loader.LoadRulePackFromFile(args[0])
+	if err != nil {
+		fmt.Fprintf(stderr, "graph: %v\n", err)
+		return 1
+	}
+	dot, err := rulesGraph(pack)
+	if err != nil {
+		fmt.Fprintf(stderr, "graph: %v\n", err)
+		return 1
+	}
+	if err := writeOutput(*output, stdout, []byte(dot)); err != nil {
+		fmt.Fprintf(stderr, "graph: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// ruleNode é uma regra com o que ela lê e grava (chaves normalizadas por dataKey)
+type ruleNode struct {
+	rule   core.Rule
+	reads  map[string]bool
+	writes map[string]bool
+}
+
+func rulesGraph(pack core.RulePack) (string, error) {
+	compiled, err := pipeline.CompilePromotions(pack)
+	if err != nil {
+		return "", err
+	}
+	rules := pipeline.OrderedRules(compiled)
+	nodes := make([]ruleNode, len(rules))
+	for i, rule := range rules {
+		nodes[i] = analyzeRule(rule)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", pack.ID+"@"+pack.Version)
+	b.WriteString("  rankdir=LR;\n  node [shape=box, fontname=\"Helvetica\"];\n")
+
+	// Fases como clusters, na ordem de execução
+	var phases []string
+	byPhase := map[string][]int{}
+	for i, n := range nodes {
+		if _, ok := byPhase[n.rule.Phase]; !ok {
+			phases = append(phases, n.rule.Phase)
+		}
+		byPhase[n.rule.Phase] = append(byPhase[n.rule.Phase], i)
+	}
+	for c, phase := range phases {
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n    label=%q;\n", c, phase)
+		for _, i := range byPhase[phase] {
+			n := nodes[i]
+			attrs := ""
+			if !n.rule.Enabled {
+				attrs = ", style=dashed, fontcolor=gray"
+			}
+			fmt.Fprintf(&b, "    %q [label=%q%s];\n", nodeID(n.rule), fmt.Sprintf("%s\np=%d", n.rule.ID, n.rule.Priority), attrs)
+		}
+		b.WriteString("  }\n")
+	}
+
+	for i, writer := range nodes {
+		for j, reader := range nodes {
+			if i == j {
+				continue
+			}
+			var shared []string
+			for key := range writer.writes {
+				if reader.reads[key] {
+					shared = append(shared, key)
+				}
+			}
+			if len(shared) == 0 {
+				continue
+			}
+			sort.Strings(shared)
+			attrs := fmt.Sprintf("label=%q", strings.Join(shared, "\n"))
+			if j < i {
+				attrs += ", style=dashed, color=red"
+			}
+			fmt.Fprintf(&b, "  %q -> %q [%s];\n", nodeID(writer.rule), nodeID(reader.rule), attrs)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+func nodeID(rule core.Rule) string {
+	return rule.Phase + "/" + rule.ID
+}
+
+// analyzeRule coleta os caminhos gravados (targets) e lidos (var e operadores *Path) pela regra
+func analyzeRule(rule core.Rule) ruleNode {
+	n := ruleNode{rule: rule, reads: map[string]bool{}, writes: map[string]bool{}}
+	collectReads(rule.Condition, false, n.reads)
+	for _, action := range rule.Actions {
+		itemScope := strings.HasPrefix(action.Target, "items[")
+		if action.Target != "" && action.Type != "validate" {
+			n.writes[dataKey(action.Target)] = true
+		}
+		collectReads(action.Logic, itemScope, n.reads)
+		collectReads(action.Value, itemScope, n.reads)
+		collectReads(action.Params, itemScope, n.reads)
+
+		switch action.Type {
+		case "add", "multiply":
+			// Lêem o próprio alvo
+			n.reads[dataKey(action.Target)] = true
+		case "promotion":
+			n.writes["items.discount"] = true
+		}
+	}
+	return n
+}
+
+var pathIndex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// dataKey normaliza um caminho de escrita/leitura: "fields.x" -> "x" (campos do estado ficam na raiz
+// dos dados), "items[*].fields.x" e "items.0.x" -> "items.x", "totals.x" permanece
+func dataKey(path string) string {
+	path = pathIndex.ReplaceAllString(path, "")
+	parts := strings.Split(path, ".")
+	switch {
+	case len(parts) > 1 && parts[0] == "fields":
+		return strings.Join(parts[1:], ".")
+	case parts[0] == "items":
+		rest := parts[1:]
+		if len(rest) > 0 && isIndex(rest[0]) {
+			rest = rest[1:]
+		}
+		if len(rest) > 0 && rest[0] == "fields" {
+			rest = rest[1:]
+		}
+		return "items." + strings.Join(rest, ".")
+	}
+	return path
+}
+
+func isIndex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// collectReads percorre JsonLogic procurando {"var": ...} e os caminhos literais dos operadores *Path
+func collectReads(v interface{}, itemScope bool, reads map[string]bool) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for op, arg := range val {
+			switch {
+			case op == "var":
+				name := arg
+				if args, ok := arg.([]interface{}); ok && len(args) > 0 {
+					name = args[0]
+				}
+				if s, ok := name.(string); ok && s != "" {
+					addRead(s, itemScope, reads)
+				}
+			case strings.HasSuffix(op, "Path"):
+				if args, ok := arg.([]interface{}); ok && len(args) > 0 {
+					if s, ok := args[0].(string); ok {
+						reads[dataKey(s)] = true
+					}
+				}
+			}
+			collectReads(arg, itemScope, reads)
+		}
+	case []interface{}:
+		for _, item := range val {
+			collectReads(item, itemScope, reads)
+		}
+	}
+}
+
+// addRead registra uma variável; no escopo de item, "x" pode ser campo do item ou do estado
+func addRead(name string, itemScope bool, reads map[string]bool) {
+	switch {
+	case strings.HasPrefix(name, "totals.") || strings.HasPrefix(name, "items."):
+		reads[dataKey(name)] = true
+	case name == "baseAmount" || name == "amount":
+		reads["items."+name] = true
+	default:
+		reads[name] = true
+		if itemScope {
+			reads["items."+name] = true
+		}
+	}
+	// Projeções padrão derivam dos itens
+	if name == "itemValues" || name == "itemTotals" {
+		reads["items.value"], reads["items.total"] = true, true
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/dolphin-sistemas/computations-engine/actions"
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/loader"
+	"github.com/dolphin-sistemas/computations-engine/pipeline"
+)
+
+func lintCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	strict := flags.Bool("strict", false, "avisos também falham")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "lint: no pack files")
+		return 2
+	}
+
+	failed := false
+	for _, path := range args {
+		pack, err := loader.LoadRulePackFromFile(path)
+		if err != nil {
+			fmt.Fprintf(stdout, "%s: error: %v\n", path, err)
+			failed = true
+			continue
+		}
+		warnings := lintWarnings(pack)
+		for _, w := range warnings {
+			fmt.Fprintf(stdout, "%s: warning: %s\n", path, w)
+		}
+		if len(warnings) > 0 && *strict {
+			failed = true
+			continue
+		}
+		if len(warnings) == 0 {
+			fmt.Fprintf(stdout, "%s: ok (%s@%s)\n", path, pack.ID, pack.Version)
+		}
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// lintWarnings aponta o que é válido mas provavelmente não é o que o autor quis
+func lintWarnings(pack core.RulePack) []string {
+	var warnings []string
+	seenRules := map[string]string{}
+	seenPhases := map[string]bool{}
+
+	for _, phase := range pack.Phases {
+		standard := slices.Contains(pipeline.PhaseOrder, phase.Name)
+		switch {
+		case standard && seenPhases[phase.Name]:
+			warnings = append(warnings, fmt.Sprintf("phase %s declared more than once: only the last declaration runs", phase.Name))
+		case !standard:
+			warnings = append(warnings, fmt.Sprintf("phase %s is not a standard phase: it runs after %s", phase.Name, pipeline.PhaseOrder[len(pipeline.PhaseOrder)-1]))
+		}
+		seenPhases[phase.Name] = true
+
+		for _, rule := range phase.Rules {
+			if previous, ok := seenRules[rule.ID]; ok {
+				warnings = append(warnings, fmt.Sprintf("rule %s declared in phases %s and %s: reasons cannot tell them apart", rule.ID, previous, phase.Name))
+			}
+			seenRules[rule.ID] = phase.Name
+
+			if !rule.Enabled {
+				warnings = append(warnings, fmt.Sprintf("rule %s is disabled (enabled is false or missing)", rule.ID))
+			}
+			if rule.Phase != "" && rule.Phase != phase.Name {
+				warnings = append(warnings, fmt.Sprintf("rule %s says phase %s but is declared in phase %s", rule.ID, rule.Phase, phase.Name))
+			}
+			if len(rule.Actions) == 0 {
+				warnings = append(warnings, fmt.Sprintf("rule %s has no actions", rule.ID))
+			}
+			for i, action := range rule.Actions {
+				if !slices.Contains(actions.Types, action.Type) {
+					warnings = append(warnings, fmt.Sprintf("rule %s: actions[%d]: unknown action type %q", rule.ID, i, action.Type))
+				}
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,84 @@
+// Command engine executa, testa e inspeciona RulePacks pela linha de comando.
+//
+//	engine run --state state.json --pack pack.yaml [--context ctx.json] [--trace]
+//	engine test [dir|arquivo ...]          (padrão: testdata/vectors e testdata/errors)
+//	engine cover [--html out.html] cover.json ...
+//	engine lint [--strict] pack.json ...
+//	engine fmt [-w] pack.json ...
+//	engine convert pack.json [-o pack.yaml]
+//	engine graph pack.json > rules.dot
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// command é um subcomando; retorna o código de saída
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) int
+}
+
+var commands = []command{
+	{"run", "executa um RulePack sobre um State e imprime o resultado", runCommand},
+	{"test", "executa vectors (formato testdata/vectors) e mostra as diferenças", testCommand},
+	{"cover", "combina perfis de cobertura e gera o relatório (texto, HTML ou JSON)", coverCommand},
+	{"lint", "valida RulePacks e aponta problemas comuns", lintCommand},
+	{"fmt", "formata RulePacks JSON/YAML na forma canônica", fmtCommand},
+	{"convert", "converte RulePacks entre JSON e YAML", convertCommand},
+	{"graph", "gera o grafo de dependências entre regras (DOT)", graphCommand},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout, stderr)
+		}
+	}
+	fmt.Fprintf(stderr, "engine: unknown command %q\n\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: engine <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "engine <command> -h" for the command flags.`)
+}
+
+// parseFlags aceita flags antes e depois dos argumentos (engine convert pack.json -o pack.yaml)
+// e retorna os argumentos posicionais
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testPack = `{"version": "v1", "id": "cli",
+  "phases": [
+    {"name": "totals", "rules": [
+      {"actions": [{"type": "compute", "target": "totals.total", "logic": {"sumPath": ["items[*].fields.total"]}}], "id": "total", "phase": "totals", "priority": 1, "enabled": true}
+    ]},
+    {"name": "baseline", "rules": [
+      {"id": "item-total", "phase": "baseline", "priority": 1, "enabled": true,
+       "actions": [{"type": "compute", "target": "items[*].fields.total", "logic": {"*": [{"var": "price"}, {"var": "amount"}]}}]},
+      {"id": "unused", "phase": "baseline", "actions": [{"type": "discount", "target": "fields.x"}]}
+    ]}
+  ]
+}`
+
+func execute(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTestCommand_RepoVectors(t *testing.T) {
+	code, stdout, _ := execute(t, "test", "../../testdata/vectors", "../../testdata/errors")
+	if code != 0 {
+		t.Fatalf("exit %d:\n%s", code, stdout)
+	}
+}
+
+func TestTestCommand_ReportsDiffs(t *testing.T) {
+	vector := writeFile(t, "v.json", `{"input": {"order": {"items": [{"id": "a", "amount": 2, "fields": {"price": 5}}]}, "rulePack": `+testPack+`},
+	  "expected": {"rulesVersion": "v1", "stateFragment": {"totals": {"total": 11}, "items": [{"fields": {"total": 10, "extra": 1}}]}}}`)
+	code, stdout, _ := execute(t, "test", vector)
+	if code != 1 {
+		t.Fatalf("exit %d, want 1", code)
+	}
+	for _, want := range []string{"stateFragment.totals.total: got 10, expected 11", "stateFragment.items[0].fields.extra: missing", "1 failed"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("output does not contain %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestTestCommand_Update(t *testing.T) {
+	vector := writeFile(t, "v.json", `{
+  "input": {"order": {"items": [{"id": "a", "amount": 2, "fields": {"price": 5}}]}, "rulePack": `+testPack+`},
+  "expected": {
+    "rulesVersion": "v1",
+    "stateFragment": { "totals": { "total": 11 } }
+  }
+}
+`)
+	code, stdout, _ := execute(t, "test", "--update", vector)
+	if code != 0 {
+		t.Fatalf("exit %d:\n%s", code, stdout)
+	}
+	for _, want := range []string{"UPDATE " + vector + " (2 changes)", "stateFragment.totals.total: 11 -> 10", "violations: added []", "1 updated, 0 failed"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("output does not contain %q:\n%s", want, stdout)
+		}
+	}
+	data, _ := os.ReadFile(vector)
+	if !strings.Contains(string(data), `"stateFragment": { "totals": { "total": 10 } },`) {
+		t.Errorf("vector not rewritten in place:\n%s", data)
+	}
+
+	if code, stdout, _ := execute(t, "test", vector); code != 0 {
+		t.Errorf("vector still fails after update:\n%s", stdout)
+	}
+}
+
+func TestRunCommand_Trace(t *testing.T) {
+	state := writeFile(t, "state.json", `{"items": [{"id": "a", "amount": 2, "fields": {"price": 5}}]}`)
+	pack := writeFile(t, "pack.json", testPack)
+	code, stdout, stderr := execute(t, "run", "--state", state, "--pack", pack, "--trace")
+	if code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, `"total": 10`) {
+		t.Errorf("result does not contain the total:\n%s", stdout)
+	}
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], "disabled") || !strings.Contains(lines[1], "item-total") || !strings.Contains(lines[2], "totals.total = 10") {
+		t.Errorf("unexpected trace:\n%s", stderr)
+	}
+}
+
+func TestLintCommand(t *testing.T) {
+	pack := writeFile(t, "pack.json", testPack)
+	code, stdout, _ := execute(t, "lint", pack)
+	if code != 0 {
+		t.Fatalf("exit %d: %s", code, stdout)
+	}
+	for _, want := range []string{"rule unused is disabled", `unknown action type "discount"`} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("output does not contain %q:\n%s", want, stdout)
+		}
+	}
+	if code, _, _ := execute(t, "lint", "--strict", pack); code != 1 {
+		t.Errorf("--strict exit %d, want 1", code)
+	}
+	if code, stdout, _ := execute(t, "lint", writeFile(t, "bad.json", `{"id": "x"}`)); code != 1 || !strings.Contains(stdout, "rulePack.version is required") {
+		t.Errorf("invalid pack: exit %d: %s", code, stdout)
+	}
+}
+
+func TestFmtAndConvert(t *testing.T) {
+	pack := writeFile(t, "pack.json", testPack)
+	_, canonical, _ := execute(t, "fmt", pack)
+	if !strings.HasPrefix(canonical, "{\n  \"id\": \"cli\",\n  \"version\": \"v1\",\n  \"phases\": [") {
+		t.Errorf("keys not in canonical order:\n%s", canonical)
+	}
+	if !strings.Contains(canonical, `"sumPath": [`) || strings.Contains(canonical, `\u003c`) {
+		t.Errorf("unexpected formatting:\n%s", canonical)
+	}
+
+	yamlPath := filepath.Join(t.TempDir(), "pack.yaml")
+	if code, _, stderr := execute(t, "convert", pack, "-o", yamlPath); code != 0 {
+		t.Fatalf("convert to yaml: %s", stderr)
+	}
+	if code, stdout, _ := execute(t, "lint", yamlPath); code != 0 {
+		t.Fatalf("converted yaml does not load: %s", stdout)
+	}
+	_, back, _ := execute(t, "convert", yamlPath)
+	if back != canonical {
+		t.Errorf("json -> yaml -> json differs from fmt:\n%s\n---\n%s", back, canonical)
+	}
+
+	canonicalPath := writeFile(t, "canonical.json", canonical)
+	if code, stdout, _ := execute(t, "fmt", "-l", canonicalPath, pack); code != 1 || strings.TrimSpace(stdout) != pack {
+		t.Errorf("fmt -l: exit %d, listed %q", code, stdout)
+	}
+}
+
+func TestGraphCommand(t *testing.T) {
+	pack := writeFile(t, "pack.json", testPack)
+	code, stdout, stderr := execute(t, "graph", pack)
+	if code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr)
+	}
+	for _, want := range []string{
+		`digraph "cli@v1"`,
+		`label="baseline"`,
+		`"baseline/item-total" -> "totals/total" [label="items.total"];`,
+		`"baseline/unused" [label="unused\np=0", style=dashed`,
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("graph does not contain %s:\n%s", want, stdout)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dolphin-sistemas/computations-engine"
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/loader"
+)
+
+func runCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	statePath := flags.String("state", "", "arquivo JSON com o State (obrigatório)")
+	packPath := flags.String("pack", "", "arquivo do RulePack, .json ou .yaml (obrigatório)")
+	contextPath := flags.String("context", "", "arquivo JSON com o ContextMeta")
+	now := flags.String("now", "", "relógio da execução (RFC3339); sobrepõe context.now")
+	trace := flags.Bool("trace", false, "mostra em stderr as regras na ordem do pipeline e o que cada uma fez")
+	output := flags.String("o", "", "arquivo de saída (padrão: stdout)")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return 2
+	}
+	if *statePath == "" || *packPath == "" {
+		fmt.Fprintln(stderr, "run: --state and --pack are required")
+		flags.Usage()
+		return 2
+	}
+
+	var state core.State
+	if err := readJSONFile(*statePath, &state); err != nil {
+		fmt.Fprintf(stderr, "run: state: %v\n", err)
+		return 1
+	}
+	pack, err := loader.LoadRulePackFromFile(*packPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "run: pack: %v\n", err)
+		return 1
+	}
+	var meta core.ContextMeta
+	if *contextPath != "" {
+		if err := readJSONFile(*contextPath, &meta); err != nil {
+			fmt.Fprintf(stderr, "run: context: %v\n", err)
+			return 1
+		}
+	}
+	if *now != "" {
+		meta.Now = *now
+	}
+
+	result, err := engine.RunEngine(context.Background(), state, pack, meta)
+	if err != nil {
+		fmt.Fprintf(stderr, "run: %v\n", err)
+		return 1
+	}
+
+	if *trace {
+		rules, err := engine.TraceRules(pack, result)
+		if err != nil {
+			fmt.Fprintf(stderr, "run: trace: %v\n", err)
+			return 1
+		}
+		writeTrace(stderr, rules, result.Violations)
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		fmt.Fprintf(stderr, "run: %v\n", err)
+		return 1
+	}
+	if err := writeOutput(*output, stdout, append(data, '\n')); err != nil {
+		fmt.Fprintf(stderr, "run: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// writeTrace imprime uma linha por regra: fase, id, prioridade, situação e mensagens
+func writeTrace(w io.Writer, rules []engine.RuleTrace, violations []core.Violation) {
+	for _, rule := range rules {
+		status := "skipped"
+		switch {
+		case !rule.Enabled:
+			status = "disabled"
+		case rule.Fired:
+			status = "fired"
+		}
+		fmt.Fprintf(w, "%-10s %-30s p=%-3d %-8s %s\n", rule.Phase, rule.RuleID, rule.Priority, status, strings.Join(rule.Messages, "; "))
+	}
+	for _, v := range violations {
+		fmt.Fprintf(w, "violation  %s %s: %s\n", v.Field, v.Code, v.Message)
+	}
+}
+
+func readJSONFile(path string, out interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// writeOutput grava em path ou, vazio, em stdout
+func writeOutput(path string, stdout io.Writer, data []byte) error {
+	if path == "" {
+		_, err := stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
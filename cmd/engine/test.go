@@ -0,0 +1,177 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dolphin-sistemas/computations-engine/coverage"
+	"github.com/dolphin-sistemas/computations-engine/vectors"
+)
+
+func testCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	tolerance := flags.Float64("tolerance", vectors.DefaultTolerance, "diferença numérica aceita")
+	relTolerance := flags.Float64("rel-tolerance", 0, "diferença numérica aceita proporcional ao valor")
+	exact := flags.Bool("exact", false, "exige todas as chaves obtidas nos objetos esperados")
+	verbose := flags.Bool("v", false, "lista também os vectors que passaram")
+	update := flags.Bool("update", false, "reescreve expected a partir do resultado atual e lista as alterações")
+	cover := flags.Bool("cover", false, "mostra a cobertura das regras, ações e ramos ao final")
+	coverProfile := flags.String("coverprofile", "", "grava o perfil de cobertura (JSON) no arquivo")
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return 2
+	}
+	paths := args
+	if len(paths) == 0 {
+		paths = []string{"testdata/vectors", "testdata/errors"}
+	}
+
+	opts := []vectors.Option{vectors.WithTolerance(*tolerance), vectors.WithRelativeTolerance(*relTolerance)}
+	if *exact {
+		opts = append(opts, vectors.Exact())
+	}
+	var collector *coverage.Collector
+	if *cover || *coverProfile != "" {
+		collector = coverage.NewCollector()
+		opts = append(opts, vectors.WithCoverage(collector))
+	}
+
+	files, err := vectorFiles(paths)
+	if err != nil {
+		fmt.Fprintf(stderr, "test: %v\n", err)
+		return 1
+	}
+
+	code := runVectors(files, opts, *update, *verbose, stdout)
+	if collector != nil {
+		if err := writeCoverage(collector.Profile(), *cover, *coverProfile, stdout); err != nil {
+			fmt.Fprintf(stderr, "test: %v\n", err)
+			return 1
+		}
+	}
+	return code
+}
+
+// writeCoverage imprime o resumo da cobertura e/ou grava o perfil
+func writeCoverage(profile *coverage.Profile, report bool, path string, stdout io.Writer) error {
+	if report {
+		fmt.Fprintln(stdout)
+		if err := coverage.WriteText(stdout, profile); err != nil {
+			return err
+		}
+	}
+	if path == "" {
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := coverage.WriteJSON(f, profile); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func runVectors(files []string, opts []vectors.Option, update, verbose bool, stdout io.Writer) int {
+	if update {
+		return updateVectors(files, opts, stdout)
+	}
+
+	failed := 0
+	for _, file := range files {
+		diffs := runVectorFile(file, opts)
+		if len(diffs) == 0 {
+			if verbose {
+				fmt.Fprintf(stdout, "PASS %s\n", file)
+			}
+			continue
+		}
+		failed++
+		fmt.Fprintf(stdout, "FAIL %s\n", file)
+		for _, d := range diffs {
+			fmt.Fprintf(stdout, "     %s\n", d)
+		}
+	}
+
+	fmt.Fprintf(stdout, "%d vectors, %d passed, %d failed\n", len(files), len(files)-failed, failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// vectorFiles expande diretórios nos .json que contêm (sem recursão), em ordem alfabética
+func vectorFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		names, err := vectors.Files(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, names...)
+	}
+	return files, nil
+}
+
+// runVectorFile executa um vector e retorna as diferenças (vazio = passou)
+func runVectorFile(path string, opts []vectors.Option) []string {
+	vector, err := vectors.Load(path)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	diffs, err := vectors.Check(vector, opts...)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	lines := make([]string, len(diffs))
+	for i, d := range diffs {
+		lines[i] = d.String()
+	}
+	return lines
+}
+
+// updateVectors reescreve os vectors e imprime o relatório das alterações de cada um, para revisão
+func updateVectors(files []string, opts []vectors.Option, stdout io.Writer) int {
+	updated, failed := 0, 0
+	for _, file := range files {
+		report, err := vectors.UpdateFile(file, opts...)
+		if err != nil {
+			failed++
+			fmt.Fprintf(stdout, "FAIL %s\n     %v\n", file, err)
+			continue
+		}
+		if len(report.Changes) > 0 {
+			updated++
+			fmt.Fprintf(stdout, "UPDATE %s (%d changes)\n", file, len(report.Changes))
+			for _, c := range report.Changes {
+				fmt.Fprintf(stdout, "     %s\n", c)
+			}
+		}
+		if len(report.Remaining) > 0 {
+			failed++
+			fmt.Fprintf(stdout, "FAIL %s\n", file)
+			for _, d := range report.Remaining {
+				fmt.Fprintf(stdout, "     %s\n", d)
+			}
+		}
+	}
+
+	fmt.Fprintf(stdout, "%d vectors, %d updated, %d failed\n", len(files), updated, failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
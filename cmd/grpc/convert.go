@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	enginev1 "github.com/dolphin-sistemas/computations-engine/proto/engine/v1"
+)
+
+// As mensagens do .proto têm os mesmos nomes JSON dos tipos de core; a conversão passa pelo JSON
+// para reaproveitar os UnmarshalJSON de core (campos extras do item, totais nomeados, projeções).
+
+// fromProto converte uma mensagem no tipo de core equivalente (mensagem nula = valor zero)
+func fromProto(m proto.Message, out interface{}) error {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return nil
+	}
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// toProto converte um valor de core na mensagem equivalente (campos sem par no .proto são ignorados)
+func toProto(v interface{}, m proto.Message) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, m)
+}
+
+// runInput converte o trio state, rulePack e context das requisições
+func runInput(state *enginev1.State, pack *enginev1.RulePack, meta *enginev1.ContextMeta) (core.State, core.RulePack, core.ContextMeta, error) {
+	var (
+		s core.State
+		r core.RulePack
+		c core.ContextMeta
+	)
+	if err := fromProto(state, &s); err != nil {
+		return s, r, c, fmt.Errorf("state: %w", err)
+	}
+	if err := fromProto(pack, &r); err != nil {
+		return s, r, c, fmt.Errorf("rulePack: %w", err)
+	}
+	if err := fromProto(meta, &c); err != nil {
+		return s, r, c, fmt.Errorf("context: %w", err)
+	}
+	return s, r, c, nil
+}
+
+func resultToProto(result *core.RunEngineResult) (*enginev1.RunEngineResult, error) {
+	out := &enginev1.RunEngineResult{}
+	if err := toProto(result, out); err != nil {
+		return nil, fmt.Errorf("failed to convert result: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,62 @@
+// Command grpc expõe o motor de regras como serviço gRPC (proto/engine/v1/engine.proto).
+//
+//	go run ./cmd/grpc -addr :9090
+//
+// Registra também o serviço padrão de health check (grpc.health.v1).
+// SIGINT/SIGTERM encerram aguardando as chamadas em andamento.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	enginev1 "github.com/dolphin-sistemas/computations-engine/proto/engine/v1"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "endereço gRPC")
+	maxMsg := flag.Int("max-msg", 4<<20, "tamanho máximo das mensagens recebidas (bytes)")
+	timeout := flag.Duration("timeout", 10*time.Second, "prazo de cada execução do motor")
+	flag.Parse()
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srv, healthServer := newGRPCServer(*timeout, grpc.MaxRecvMsgSize(*maxMsg))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		healthServer.Shutdown()
+		srv.GracefulStop()
+	}()
+
+	log.Printf("listening on %s", lis.Addr())
+	if err := srv.Serve(lis); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// newGRPCServer cria o servidor com o serviço do motor e o health check
+func newGRPCServer(timeout time.Duration, opts ...grpc.ServerOption) (*grpc.Server, *health.Server) {
+	srv := grpc.NewServer(opts...)
+	enginev1.RegisterEngineServiceServer(srv, &engineService{timeout: timeout})
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(enginev1.EngineService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthServer)
+	return srv, healthServer
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dolphin-sistemas/computations-engine"
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/loader"
+	enginev1 "github.com/dolphin-sistemas/computations-engine/proto/engine/v1"
+)
+
+// engineService implementa enginev1.EngineServiceServer sobre engine.RunEngine
+type engineService struct {
+	enginev1.UnimplementedEngineServiceServer
+
+	timeout time.Duration // prazo de cada execução (0 = apenas o do cliente)
+}
+
+func (s *engineService) RunEngine(ctx context.Context, req *enginev1.RunEngineRequest) (*enginev1.RunEngineResponse, error) {
+	result, err := s.run(ctx, req.GetState(), req.GetRulePack(), req.GetContext())
+	if err != nil {
+		return nil, err
+	}
+	out, err := resultToProto(result)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &enginev1.RunEngineResponse{Result: out}, nil
+}
+
+func (s *engineService) Validate(ctx context.Context, req *enginev1.ValidateRequest) (*enginev1.ValidateResponse, error) {
+	var pack core.RulePack
+	if err := fromProto(req.GetRulePack(), &pack); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "rulePack: %v", err)
+	}
+	// O pack vem do cliente: validar sem ler arquivos do servidor (tables[*].source)
+	if err := loader.ValidateRulePack(pack); err != nil {
+		return &enginev1.ValidateResponse{Valid: false, Id: pack.ID, Version: pack.Version, Error: err.Error()}, nil
+	}
+	return &enginev1.ValidateResponse{Valid: true, Id: pack.ID, Version: pack.Version}, nil
+}
+
+func (s *engineService) Explain(ctx context.Context, req *enginev1.ExplainRequest) (*enginev1.ExplainResponse, error) {
+	state, pack, meta, err := runInput(req.GetState(), req.GetRulePack(), req.GetContext())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	result, err := s.runEngine(ctx, state, pack, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	trace, err := engine.TraceRules(pack, result)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	out := &enginev1.ExplainResponse{RulesVersion: result.RulesVersion}
+	for _, rule := range trace {
+		out.Rules = append(out.Rules, &enginev1.RuleExplanation{
+			RuleId:   rule.RuleID,
+			Phase:    rule.Phase,
+			Priority: int32(rule.Priority),
+			Enabled:  rule.Enabled,
+			Fired:    rule.Fired,
+			Messages: rule.Messages,
+		})
+	}
+	for _, v := range result.Violations {
+		out.Violations = append(out.Violations, &enginev1.Violation{Field: v.Field, Code: v.Code, Message: v.Message})
+	}
+	return out, nil
+}
+
+func (s *engineService) BatchRun(req *enginev1.BatchRunRequest, stream enginev1.EngineService_BatchRunServer) error {
+	ctx := stream.Context()
+	for i, state := range req.GetStates() {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		resp := &enginev1.BatchRunResponse{Index: int32(i)}
+		result, err := s.run(ctx, state, req.GetRulePack(), req.GetContext())
+		if err == nil {
+			resp.Result, err = resultToProto(result)
+		}
+		if err != nil {
+			st := status.Convert(err)
+			if st.Code() == codes.DeadlineExceeded || st.Code() == codes.Canceled {
+				return err
+			}
+			resp.Result = nil
+			resp.Error = &enginev1.Error{Code: st.Code().String(), Message: st.Message()}
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run converte a entrada e executa o motor
+func (s *engineService) run(ctx context.Context, state *enginev1.State, pack *enginev1.RulePack, meta *enginev1.ContextMeta) (*core.RunEngineResult, error) {
+	st, rp, cm, err := runInput(state, pack, meta)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return s.runEngine(ctx, st, rp, cm)
+}
+
+// runEngine executa com o prazo do servidor; erros do motor viram InvalidArgument
+// (a entrada não é executável) e os do contexto, DeadlineExceeded/Canceled
+func (s *engineService) runEngine(ctx context.Context, state core.State, pack core.RulePack, meta core.ContextMeta) (*core.RunEngineResult, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	result, err := engine.RunEngine(ctx, state, pack, meta)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.Error(status.FromContextError(ctxErr).Code(), err.Error())
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return result, nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	enginev1 "github.com/dolphin-sistemas/computations-engine/proto/engine/v1"
+)
+
+const testPack = `{
+  "id": "pricing",
+  "version": "v1.0.0",
+  "units": {"default": {"base": "un", "units": {"dz": {"factor": 12, "indivisible": true}}}},
+  "phases": [
+    {"name": "baseline", "rules": [
+      {"id": "item-total", "phase": "baseline", "priority": 1, "enabled": true,
+       "actions": [{"type": "compute", "target": "items[*].fields.total", "logic": {"*": [{"var": "baseAmount"}, {"var": "price"}]}}]},
+      {"id": "vip", "phase": "baseline", "priority": 2, "enabled": true,
+       "condition": {"==": [{"var": "segment"}, "vip"]},
+       "actions": [{"type": "set", "target": "fields.vip", "value": true}]}
+    ]},
+    {"name": "totals", "rules": [
+      {"id": "total", "phase": "totals", "priority": 1, "enabled": true,
+       "actions": [{"type": "compute", "target": "totals.total", "logic": {"sumPath": ["items[*].fields.total"]}}]}
+    ]},
+    {"name": "guards", "rules": [
+      {"id": "min-total", "phase": "guards", "priority": 1, "enabled": true,
+       "actions": [{"type": "validate", "logic": {"<": [{"var": "totals.total"}, 10]}, "params": {"field": "totals.total", "code": "MIN_TOTAL", "message": "minimum is 10"}}]}
+    ]}
+  ]
+}`
+
+func dial(t *testing.T, timeout time.Duration) enginev1.EngineServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv, _ := newGRPCServer(timeout)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	health, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: enginev1.EngineService_ServiceDesc.ServiceName})
+	if err != nil || health.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("health check: %v %v", health, err)
+	}
+	return enginev1.NewEngineServiceClient(conn)
+}
+
+func decode[T proto.Message](t *testing.T, data string, m T) T {
+	t.Helper()
+	if err := protojson.Unmarshal([]byte(data), m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestRunEngine(t *testing.T) {
+	client := dial(t, 5*time.Second)
+	resp, err := client.RunEngine(context.Background(), &enginev1.RunEngineRequest{
+		State:    decode(t, `{"items": [{"id": "a", "amount": 2, "unit": "dz", "fields": {"price": 0.5}}]}`, &enginev1.State{}),
+		RulePack: decode(t, testPack, &enginev1.RulePack{}),
+		Context:  &enginev1.ContextMeta{TenantId: "t1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := resp.GetResult()
+	if result.GetRulesVersion() != "v1.0.0" {
+		t.Errorf("rulesVersion = %q", result.GetRulesVersion())
+	}
+	totals := result.GetStateFragment().AsMap()["totals"].(map[string]interface{})
+	if totals["total"] != 12.0 {
+		t.Errorf("totals.total = %v, want 12", totals["total"])
+	}
+	if len(result.GetViolations()) != 0 {
+		t.Errorf("unexpected violations %v", result.GetViolations())
+	}
+}
+
+func TestRunEngine_InvalidPack(t *testing.T) {
+	client := dial(t, 5*time.Second)
+	_, err := client.RunEngine(context.Background(), &enginev1.RunEngineRequest{RulePack: &enginev1.RulePack{Version: "v1"}})
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "rulePack.id is required") {
+		t.Fatalf("err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestRunEngine_Deadline(t *testing.T) {
+	client := dial(t, time.Nanosecond)
+	_, err := client.RunEngine(context.Background(), &enginev1.RunEngineRequest{RulePack: decode(t, testPack, &enginev1.RulePack{})})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("err = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	client := dial(t, 5*time.Second)
+
+	resp, err := client.Validate(context.Background(), &enginev1.ValidateRequest{RulePack: decode(t, testPack, &enginev1.RulePack{})})
+	if err != nil || !resp.GetValid() || resp.GetId() != "pricing" {
+		t.Fatalf("Validate = %v, %v; want valid", resp, err)
+	}
+
+	invalid := decode(t, testPack, &enginev1.RulePack{})
+	invalid.Units["default"].Units["dz"].Factor = 0
+	resp, err = client.Validate(context.Background(), &enginev1.ValidateRequest{RulePack: invalid})
+	if err != nil || resp.GetValid() || !strings.Contains(resp.GetError(), "factor must be positive") {
+		t.Fatalf("Validate = %v, %v; want invalid units", resp, err)
+	}
+
+	// server.go existe no diretório de trabalho do servidor e não pode ser lido
+	withSource := decode(t, testPack, &enginev1.RulePack{})
+	withSource.Tables = map[string]*enginev1.Table{"t": {Source: "server.go"}}
+	resp, err = client.Validate(context.Background(), &enginev1.ValidateRequest{RulePack: withSource})
+	if err != nil || resp.GetValid() || !strings.Contains(resp.GetError(), "source is only supported") {
+		t.Fatalf("Validate = %v, %v; want table source rejected", resp, err)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	client := dial(t, 5*time.Second)
+	resp, err := client.Explain(context.Background(), &enginev1.ExplainRequest{
+		State:    decode(t, `{"items": [{"id": "a", "amount": 1, "unit": "dz", "fields": {"price": 0.5}}]}`, &enginev1.State{}),
+		RulePack: decode(t, testPack, &enginev1.RulePack{}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, rule := range resp.GetRules() {
+		got = append(got, rule.GetRuleId()+"="+map[bool]string{true: "fired", false: "skipped"}[rule.GetFired()])
+	}
+	want := "item-total=fired vip=skipped total=fired min-total=skipped"
+	if strings.Join(got, " ") != want {
+		t.Errorf("rules = %s, want %s", strings.Join(got, " "), want)
+	}
+	if len(resp.GetViolations()) != 1 || resp.GetViolations()[0].GetCode() != "MIN_TOTAL" {
+		t.Errorf("violations = %v, want MIN_TOTAL", resp.GetViolations())
+	}
+}
+
+func TestBatchRun(t *testing.T) {
+	client := dial(t, 5*time.Second)
+	stream, err := client.BatchRun(context.Background(), &enginev1.BatchRunRequest{
+		RulePack: decode(t, testPack, &enginev1.RulePack{}),
+		States: []*enginev1.State{
+			decode(t, `{"items": [{"id": "a", "amount": 1, "unit": "dz", "fields": {"price": 1}}]}`, &enginev1.State{}),
+			decode(t, `{"items": [{"id": "b", "amount": 1, "unit": "box", "fields": {"price": 1}}]}`, &enginev1.State{}),
+			decode(t, `{"items": [{"id": "c", "amount": 3, "fields": {"baseAmount": 3, "price": 2}}]}`, &enginev1.State{}),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetError() != nil {
+			got = append(got, resp.GetError().GetCode())
+			continue
+		}
+		totals := resp.GetResult().GetStateFragment().AsMap()["totals"].(map[string]interface{})
+		got = append(got, fmt.Sprint(totals["total"]))
+	}
+	if want := "12 InvalidArgument 6"; strings.Join(got, " ") != want {
+		t.Errorf("batch = %s, want %s", strings.Join(got, " "), want)
+	}
+}
@@ -0,0 +1,88 @@
+package core
+
+// Clone retorna uma cópia profunda do State (itens, campos, metadados e agregados),
+// sem compartilhar mapas ou slices com o original
+func (s State) Clone() State {
+	out := s
+	if s.Items != nil {
+		out.Items = make([]Item, len(s.Items))
+		for i, item := range s.Items {
+			out.Items[i] = item.Clone()
+		}
+	}
+	out.Totals = s.Totals.Clone()
+	out.Fields = cloneMap(s.Fields)
+	out.Meta = cloneMap(s.Meta)
+	return out
+}
+
+// Clone retorna uma cópia profunda do item
+func (i Item) Clone() Item {
+	out := i
+	out.Fields = cloneMap(i.Fields)
+	return out
+}
+
+// Clone retorna uma cópia dos agregados (valores, ordem e declarações)
+func (t Totals) Clone() Totals {
+	out := t
+	if t.extra != nil {
+		out.extra = make(map[string]float64, len(t.extra))
+		for k, v := range t.extra {
+			out.extra[k] = v
+		}
+	}
+	if t.order != nil {
+		out.order = append([]string(nil), t.order...)
+	}
+	if t.defs != nil {
+		out.defs = make(map[string]TotalDef, len(t.defs))
+		for k, v := range t.defs {
+			out.defs[k] = v
+		}
+	}
+	return out
+}
+
+func cloneMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = cloneValue(v)
+	}
+	return out
+}
+
+// cloneValue copia os contêineres usados nos campos (mapas e slices do JSON); demais valores são copiados como estão
+func cloneValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return cloneMap(val)
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, elem := range val {
+			out[i] = cloneValue(elem)
+		}
+		return out
+	case []map[string]interface{}:
+		out := make([]map[string]interface{}, len(val))
+		for i, elem := range val {
+			out[i] = cloneMap(elem)
+		}
+		return out
+	case []float64:
+		return append([]float64(nil), val...)
+	case []string:
+		return append([]string(nil), val...)
+	case map[string]float64:
+		out := make(map[string]float64, len(val))
+		for k, f := range val {
+			out[k] = f
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -1,7 +1,7 @@
 package core
 
 import (
-	"encoding/json"
+	"context"
 )
 
 // EngineContext mantém o estado interno do motor durante a execução
@@ -12,15 +12,34 @@ type EngineContext struct {
 	Reasons    []Reason
 	Violations []Violation
 	PhaseIndex int // Índice da fase atual
+
+	Projections map[string]Projection // Projeções declaradas no RulePack (sobrepõem itemValues/itemTotals)
+	Tables      *TableSet             // Tabelas de consulta compiladas do RulePack
+
+	ProjectionExprs map[string]interface{} // Expressões das projeções por path, compiladas uma vez por RulePack (pipeline.CompileProjections)
+
+	Promotions        map[string]Promotion // Promoções do RulePack por ID (executadas pela ação promotion)
+	AppliedPromotions []AppliedPromotion   // Promoções aplicadas até o momento
+
+	ExchangeRates ExchangeRates // Cotações do RulePack combinadas com as do ContextMeta
+	Units         *UnitSet      // Unidades de medida compiladas do RulePack
+
+	Ctx context.Context // Cancelamento/prazo da execução, conferido antes de cada regra (nil = sem prazo)
+
+	RuleID   string           // Regra em execução
+	Coverage CoverageRecorder // Coleta de cobertura (nil = desligada)
+
+	Eval EvalCache // Dados de avaliação montados pelo pipeline (invalidados pelas escritas das ações)
+
+	ItemProjectionRules map[string]bool // Regras que leem projeções de itens (exigem os itens na moeda do estado)
 }
 
 // NewEngineContext cria um novo contexto do motor
 func NewEngineContext(state State, context ContextMeta) (*EngineContext, error) {
-	// Clonar estado original
-	original, err := cloneState(state)
-	if err != nil {
-		return nil, err
-	}
+	// Clonar estado original (para os deltas) e trabalhar sobre outra cópia: mapas e slices do
+	// State recebido não são alterados
+	original := state.Clone()
+	state = state.Clone()
 
 	// Inicializar campos vazios
 	if state.Items == nil {
@@ -39,18 +58,3 @@ func NewEngineContext(state State, context ContextMeta) (*EngineContext, error)
 		PhaseIndex: 0,
 	}, nil
 }
-
-// cloneState faz uma cópia profunda do State
-func cloneState(s State) (State, error) {
-	data, err := json.Marshal(s)
-	if err != nil {
-		return State{}, err
-	}
-
-	var cloned State
-	if err := json.Unmarshal(data, &cloned); err != nil {
-		return State{}, err
-	}
-
-	return cloned, nil
-}
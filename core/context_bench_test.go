@@ -0,0 +1,24 @@
+package core_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/internal/benchdata"
+)
+
+// BenchmarkNewEngineContext mede as cópias do estado (Original e State via Clone)
+func BenchmarkNewEngineContext(b *testing.B) {
+	for _, items := range benchdata.ItemCounts {
+		state := benchdata.Order(items)
+		b.Run(fmt.Sprintf("items=%d", items), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := core.NewEngineContext(state, benchdata.Context); err != nil {
+					b.Fatalf("NewEngineContext failed: %v", err)
+				}
+			}
+		})
+	}
+}
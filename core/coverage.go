@@ -0,0 +1,8 @@
+package core
+
+// CoverageRecorder recebe os eventos de cobertura de uma execução (implementado pelo pacote coverage)
+type CoverageRecorder interface {
+	RecordRule(ruleID string, fired bool)                 // regra avaliada (condition verdadeira ou ausente = fired)
+	RecordAction(ruleID string, index int, violated bool) // ação executada (violated: validate gerou violação)
+	RecordBranch(id int, taken bool)                      // condição de um if instrumentado
+}
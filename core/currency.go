@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+// ExchangeRates são cotações por par "DE/PARA": {"USD/BRL": 5.12} significa 1 USD = 5,12 BRL.
+// O par inverso é derivado quando não declarado.
+type ExchangeRates map[string]float64
+
+// Validate confere os pares (moedas ISO-4217 distintas) e as cotações (positivas)
+func (r ExchangeRates) Validate() error {
+	for _, pair := range r.pairs() {
+		from, to, ok := strings.Cut(pair, "/")
+		if !ok || !pkg.IsCurrency(from) || !pkg.IsCurrency(to) || from == to {
+			return fmt.Errorf("exchange rate %q must be a pair of distinct ISO-4217 currencies (ex: USD/BRL)", pair)
+		}
+		if r[pair] <= 0 {
+			return fmt.Errorf("exchange rate %s must be positive", pair)
+		}
+	}
+	return nil
+}
+
+// Rate retorna a cotação de from para to (1 para a mesma moeda)
+func (r ExchangeRates) Rate(from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	if rate, ok := r[from+"/"+to]; ok {
+		return rate, nil
+	}
+	if rate, ok := r[to+"/"+from]; ok {
+		return 1 / rate, nil
+	}
+	return 0, fmt.Errorf("no exchange rate for %s/%s", from, to)
+}
+
+func (r ExchangeRates) pairs() []string {
+	pairs := make([]string, 0, len(r))
+	for pair := range r {
+		pairs = append(pairs, pair)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// MergeExchangeRates combina as cotações; as camadas seguintes sobrepõem as anteriores
+// (ex: cotações do RulePack e, por cima, as do ContextMeta da execução)
+func MergeExchangeRates(layers ...ExchangeRates) ExchangeRates {
+	var merged ExchangeRates
+	for _, layer := range layers {
+		for pair, rate := range layer {
+			if merged == nil {
+				merged = make(ExchangeRates)
+			}
+			merged[pair] = rate
+		}
+	}
+	return merged
+}
+
+// ValidateCurrencies confere as moedas do estado e dos itens (ISO-4217 ou vazias)
+func ValidateCurrencies(state State) error {
+	if state.Currency != "" && !pkg.IsCurrency(state.Currency) {
+		return fmt.Errorf("state.currency: unknown currency %q", state.Currency)
+	}
+	for i, item := range state.Items {
+		if item.Currency != "" && !pkg.IsCurrency(item.Currency) {
+			return fmt.Errorf("items[%d].currency: unknown currency %q", i, item.Currency)
+		}
+	}
+	return nil
+}
+
+// ItemCurrency é a moeda do item: a própria ou, se vazia, a do estado
+func (s *State) ItemCurrency(i int) string {
+	if s.Items[i].Currency != "" {
+		return s.Items[i].Currency
+	}
+	return s.Currency
+}
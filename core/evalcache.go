@@ -0,0 +1,44 @@
+package core
+
+// EvalCache guarda os dados de avaliação JsonLogic entre condições e ações de uma execução.
+// O pipeline monta os dados uma vez e, a cada leitura, refaz apenas o que foi invalidado pelas
+// escritas desde então: os itens alterados (um a um) e a parte do estado (campos, totais, projeções).
+type EvalCache struct {
+	Data       map[string]interface{} // dados montados (nil = montar do zero)
+	Items      []interface{}          // dados de cada item (data["items"], um map[string]interface{} por item)
+	ItemValues []interface{}          // projeção padrão itemValues (float64)
+	ItemTotals []interface{}          // projeção padrão itemTotals (float64)
+
+	Stale      bool         // algo mudou desde a última montagem (campos, totais ou itens)
+	StaleItems map[int]bool // itens alterados
+	AllItems   bool         // todos os itens alterados
+}
+
+// Invalidate descarta os dados montados (a próxima leitura monta tudo de novo)
+func (c *EvalCache) Invalidate() {
+	*c = EvalCache{}
+}
+
+// InvalidateState marca campos, totais e metadados do estado como alterados
+func (c *EvalCache) InvalidateState() {
+	c.Stale = true
+}
+
+// InvalidateItem marca o item i como alterado
+func (c *EvalCache) InvalidateItem(i int) {
+	c.Stale = true
+	if c.AllItems {
+		return
+	}
+	if c.StaleItems == nil {
+		c.StaleItems = make(map[int]bool)
+	}
+	c.StaleItems[i] = true
+}
+
+// InvalidateItems marca todos os itens como alterados
+func (c *EvalCache) InvalidateItems() {
+	c.Stale = true
+	c.AllItems = true
+	c.StaleItems = nil
+}
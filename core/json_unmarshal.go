@@ -4,7 +4,7 @@ import "encoding/json"
 
 // UnmarshalJSON preserves arbitrary nested state fields.
 //
-// Known top-level keys are decoded into strongly-typed fields (id, tenantId, items, totals, fields, meta).
+// Known top-level keys are decoded into strongly-typed fields (id, tenantId, currency, items, totals, fields, meta).
 // Any other top-level keys are preserved into State.Fields (without overriding an existing key).
 func (s *State) UnmarshalJSON(data []byte) error {
 	type alias State
@@ -21,6 +21,7 @@ func (s *State) UnmarshalJSON(data []byte) error {
 
 	delete(raw, "id")
 	delete(raw, "tenantId")
+	delete(raw, "currency")
 	delete(raw, "items")
 	delete(raw, "totals")
 	delete(raw, "fields")
@@ -49,6 +50,8 @@ func (s *State) UnmarshalJSON(data []byte) error {
 // Known keys:
 // - id (string)
 // - amount (number) [also accepts "quantity" as fallback]
+// - currency (string, ISO-4217)
+// - unit (string, unidade de amount)
 // - fields (object)
 //
 // Any other keys are preserved into Item.Fields.
@@ -71,6 +74,12 @@ func (it *Item) UnmarshalJSON(data []byte) error {
 	if id, ok := raw["id"].(string); ok {
 		it.ID = id
 	}
+	if currency, ok := raw["currency"].(string); ok {
+		it.Currency = currency
+	}
+	if unit, ok := raw["unit"].(string); ok {
+		it.Unit = unit
+	}
 
 	if v, ok := raw["amount"]; ok {
 		if f, ok2 := asFloat64(v); ok2 {
@@ -85,7 +94,7 @@ func (it *Item) UnmarshalJSON(data []byte) error {
 	// Preserve unknown keys into Fields.
 	for k, v := range raw {
 		switch k {
-		case "id", "amount", "quantity", "fields":
+		case "id", "amount", "quantity", "currency", "unit", "fields":
 			continue
 		default:
 			if k == "" {
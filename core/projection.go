@@ -0,0 +1,59 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Projection declara um array auxiliar materializado nos dados de avaliação JsonLogic.
+//
+// Formas aceitas no RulePack:
+//
+//	"projections": {
+//	  "unitPrices":  "items[*].unitPrice",
+//	  "grossValues": "items[*].unitPrice * items[*].amount",
+//	  "netValues":   {"logic": {"-": [{"var": "grossValue"}, {"var": "discount"}]}}
+//	}
+//
+// Path aceita um path de target (mesma gramática de actions.ParsePath) ou uma expressão
+// aritmética (+, -, *, /, parênteses) entre paths e números, avaliada elemento a elemento.
+// Logic é avaliada uma vez por item, com os campos do item no escopo.
+type Projection struct {
+	Path  string                 `json:"path,omitempty" yaml:"path,omitempty"`
+	Logic map[string]interface{} `json:"logic,omitempty" yaml:"logic,omitempty"`
+}
+
+// UnmarshalJSON aceita a forma curta (string com path/expressão) ou o objeto completo
+func (p *Projection) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		*p = Projection{Path: path}
+		return nil
+	}
+
+	type alias Projection
+	var decoded alias
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("projection must be a path string or an object with path/logic: %w", err)
+	}
+	*p = Projection(decoded)
+	return nil
+}
+
+// UnmarshalYAML aceita a forma curta (string com path/expressão) ou o objeto completo
+func (p *Projection) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*p = Projection{Path: node.Value}
+		return nil
+	}
+
+	type alias Projection
+	var decoded alias
+	if err := node.Decode(&decoded); err != nil {
+		return fmt.Errorf("projection must be a path string or an object with path/logic: %w", err)
+	}
+	*p = Projection(decoded)
+	return nil
+}
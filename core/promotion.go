@@ -0,0 +1,69 @@
+package core
+
+// Tipos de promoção
+const (
+	PromotionPercentOff   = "percentOff"   // percentual sobre os itens participantes
+	PromotionAmountOff    = "amountOff"    // valor fixo rateado entre os itens participantes
+	PromotionBuyXGetY     = "buyXGetY"     // a cada buy + get unidades, get unidades (as mais baratas) com desconto
+	PromotionFreeShipping = "freeShipping" // zera o frete (shippingTarget)
+)
+
+// Padrões das promoções
+const (
+	PromotionPhase         = "allocation"        // fase em que as promoções executam
+	CouponsField           = "coupons"           // State.Fields com os cupons informados (string ou lista)
+	PromotionUsageField    = "promotionUsage"    // State.Fields com os usos anteriores por promoção ({id: usos})
+	PromotionDiscountField = "promotionDiscount" // campo do item que acumula os descontos (separado de fields.discount)
+	PromotionShippingPath  = "totals.freight"    // target do frete zerado por freeShipping
+)
+
+// Promotion declara uma promoção no RulePack. Cada promoção é compilada em uma regra
+// (ver pipeline.CompilePromotions) e reportada em RunEngineResult.Promotions quando aplicada.
+//
+// Os descontos são gravados (somados) no campo discountField dos itens participantes e
+// incidem sobre o valor do item já descontado das promoções anteriores: a ordem de
+// empilhamento é a prioridade (menor primeiro).
+type Promotion struct {
+	ID          string `json:"id" yaml:"id"`
+	Type        string `json:"type" yaml:"type"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Phase       string `json:"phase,omitempty" yaml:"phase,omitempty"` // padrão: allocation
+	Priority    int    `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+
+	// Elegibilidade
+	Coupon      string                 `json:"coupon,omitempty" yaml:"coupon,omitempty"`           // código exigido em State.Fields.coupons (sem diferenciar maiúsculas)
+	Eligibility map[string]interface{} `json:"eligibility,omitempty" yaml:"eligibility,omitempty"` // JsonLogic sobre o pedido (condição da regra)
+	Items       map[string]interface{} `json:"items,omitempty" yaml:"items,omitempty"`             // JsonLogic por item: itens participantes (padrão: todos)
+	MinSubtotal float64                `json:"minSubtotal,omitempty" yaml:"minSubtotal,omitempty"` // valor mínimo dos itens participantes
+
+	// Limites e empilhamento
+	Exclusive       bool `json:"exclusive,omitempty" yaml:"exclusive,omitempty"`             // só aplica sozinha e bloqueia as seguintes
+	UsageLimit      int  `json:"usageLimit,omitempty" yaml:"usageLimit,omitempty"`           // usos totais (contados em State.Fields.promotionUsage)
+	MaxApplications int  `json:"maxApplications,omitempty" yaml:"maxApplications,omitempty"` // buyXGetY: conjuntos por pedido
+
+	// Parâmetros por tipo
+	Percent        float64                `json:"percent,omitempty" yaml:"percent,omitempty"`         // percentOff; buyXGetY (padrão 100)
+	Amount         float64                `json:"amount,omitempty" yaml:"amount,omitempty"`           // amountOff
+	MaxDiscount    float64                `json:"maxDiscount,omitempty" yaml:"maxDiscount,omitempty"` // teto do desconto de percentOff
+	Buy            int                    `json:"buy,omitempty" yaml:"buy,omitempty"`
+	Get            int                    `json:"get,omitempty" yaml:"get,omitempty"`
+	ShippingTarget string                 `json:"shippingTarget,omitempty" yaml:"shippingTarget,omitempty"` // padrão: totals.freight
+	Base           map[string]interface{} `json:"base,omitempty" yaml:"base,omitempty"`                     // JsonLogic por item: valor do item (padrão: itemValues)
+	DiscountField  string                 `json:"discountField,omitempty" yaml:"discountField,omitempty"`   // padrão: promotionDiscount
+}
+
+// AppliedPromotion é uma promoção aplicada na execução
+type AppliedPromotion struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	Coupon string          `json:"coupon,omitempty"`
+	Amount float64         `json:"amount"`          // desconto total
+	Items  []PromotionItem `json:"items,omitempty"` // desconto por item (vazio em freeShipping)
+}
+
+// PromotionItem é o desconto de uma promoção em um item
+type PromotionItem struct {
+	ItemID string  `json:"itemId"`
+	Amount float64 `json:"amount"`
+}
@@ -0,0 +1,504 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Tipos de coluna e modos de correspondência das tabelas de consulta
+const (
+	ColumnString = "string"
+	ColumnNumber = "number"
+	ColumnBool   = "bool"
+
+	MatchExact = "exact" // valor igual (ou "*" na linha = qualquer valor)
+	MatchRange = "range" // from <= valor < to (limites ausentes = abertos)
+
+	TableWildcard = "*"
+)
+
+// Table é uma tabela de consulta nomeada do RulePack (alíquotas por UF, frete por região, ...).
+//
+//	"tables": {
+//	  "icmsRates": {
+//	    "columns": [
+//	      {"name": "origin", "match": "exact"},
+//	      {"name": "dest", "match": "exact"},
+//	      {"name": "rate", "type": "number"}
+//	    ],
+//	    "rows": [
+//	      {"origin": "SP", "dest": "SP", "rate": 18},
+//	      {"origin": "SP", "dest": "*", "rate": 12}
+//	    ]
+//	  },
+//	  "freight": {"source": "tables/freight.csv", "columns": [...]}
+//	}
+//
+// Colunas com Match são chaves de busca; as demais são valores retornados. Em colunas
+// de faixa a célula é {"from": x, "to": y} (no CSV, as colunas "peso.from" e "peso.to").
+// Source aponta um arquivo CSV ou JSON carregado pelo loader (relativo ao RulePack).
+type Table struct {
+	Columns []TableColumn            `json:"columns,omitempty" yaml:"columns,omitempty"`
+	Rows    []map[string]interface{} `json:"rows,omitempty" yaml:"rows,omitempty"`
+	Source  string                   `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+// TableColumn declara o tipo de uma coluna e, para chaves, o modo de correspondência
+type TableColumn struct {
+	Name  string `json:"name" yaml:"name"`
+	Type  string `json:"type,omitempty" yaml:"type,omitempty"`   // string (padrão), number, bool
+	Match string `json:"match,omitempty" yaml:"match,omitempty"` // exact, range ou vazio (coluna de valor)
+}
+
+// TableSet reúne as tabelas compiladas (tipadas e indexadas) de um RulePack
+type TableSet struct {
+	tables map[string]*compiledTable
+}
+
+type compiledTable struct {
+	columns map[string]TableColumn
+	exact   []string // colunas de correspondência exata (ordem de declaração)
+	ranges  []string // colunas de faixa
+	rows    []compiledRow
+	index   []map[string][]int // por coluna exata: valor (ou "*") -> linhas, em ordem
+}
+
+type compiledRow struct {
+	values    map[string]interface{}
+	bounds    map[string]rangeBounds
+	wildcards int // colunas-chave com "*" (menos específica)
+}
+
+type rangeBounds struct {
+	from, to       float64
+	hasFrom, hasTo bool
+	wildcard       bool
+}
+
+// CompileTables converte as células para os tipos declarados e indexa as colunas exatas
+func CompileTables(tables map[string]Table) (*TableSet, error) {
+	set := &TableSet{tables: make(map[string]*compiledTable, len(tables))}
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		table := tables[name]
+		if table.Source != "" && len(table.Rows) == 0 {
+			return nil, fmt.Errorf("table %s: source %q was not loaded (use the loader)", name, table.Source)
+		}
+		compiled, err := compileTable(table)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", name, err)
+		}
+		set.tables[name] = compiled
+	}
+	return set, nil
+}
+
+func compileTable(table Table) (*compiledTable, error) {
+	t := &compiledTable{
+		columns: make(map[string]TableColumn, len(table.Columns)),
+	}
+	for i, col := range table.Columns {
+		if col.Name == "" {
+			return nil, fmt.Errorf("columns[%d].name is required", i)
+		}
+		if _, dup := t.columns[col.Name]; dup {
+			return nil, fmt.Errorf("duplicate column %q", col.Name)
+		}
+		switch col.Type {
+		case "", ColumnString, ColumnNumber, ColumnBool:
+		default:
+			return nil, fmt.Errorf("column %s: unknown type %q", col.Name, col.Type)
+		}
+		switch col.Match {
+		case "":
+		case MatchExact:
+			t.exact = append(t.exact, col.Name)
+		case MatchRange:
+			if col.Type != "" && col.Type != ColumnNumber {
+				return nil, fmt.Errorf("column %s: range columns must be numbers", col.Name)
+			}
+			col.Type = ColumnNumber
+			t.ranges = append(t.ranges, col.Name)
+		default:
+			return nil, fmt.Errorf("column %s: unknown match %q", col.Name, col.Match)
+		}
+		t.columns[col.Name] = col
+	}
+	if len(t.exact)+len(t.ranges) == 0 {
+		return nil, fmt.Errorf("at least one column must declare match (exact or range)")
+	}
+	t.index = make([]map[string][]int, len(t.exact))
+	for j := range t.index {
+		t.index[j] = make(map[string][]int)
+	}
+
+	for i, raw := range table.Rows {
+		row, err := t.compileRow(raw)
+		if err != nil {
+			return nil, fmt.Errorf("rows[%d]: %w", i, err)
+		}
+		for j, col := range t.exact {
+			k := indexKey(row.values[col])
+			t.index[j][k] = append(t.index[j][k], len(t.rows))
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t, nil
+}
+
+func (t *compiledTable) compileRow(raw map[string]interface{}) (compiledRow, error) {
+	row := compiledRow{values: make(map[string]interface{}, len(raw)), bounds: make(map[string]rangeBounds)}
+	for k, v := range raw {
+		// Faixas em forma plana (CSV): "peso.from" / "peso.to"
+		if col, bound, ok := strings.Cut(k, "."); ok && (bound == "from" || bound == "to") && t.columns[col].Match == MatchRange {
+			obj, _ := row.values[col].(map[string]interface{})
+			if obj == nil {
+				obj = map[string]interface{}{}
+				row.values[col] = obj
+			}
+			obj[bound] = v
+			continue
+		}
+		row.values[k] = v
+	}
+
+	for _, col := range t.exact {
+		v, err := convertCell(row.values[col], t.columns[col].Type)
+		if err != nil {
+			return row, fmt.Errorf("column %s: %w", col, err)
+		}
+		if v == nil {
+			return row, fmt.Errorf("column %s: value is required (use %q to match any value)", col, TableWildcard)
+		}
+		if v == TableWildcard {
+			row.wildcards++
+		}
+		row.values[col] = v
+	}
+	for _, col := range t.ranges {
+		b, err := parseBounds(row.values[col])
+		if err != nil {
+			return row, fmt.Errorf("column %s: %w", col, err)
+		}
+		if b.wildcard {
+			row.wildcards++
+		}
+		row.bounds[col] = b
+	}
+	for name, col := range t.columns {
+		if col.Match != "" {
+			continue
+		}
+		v, err := convertCell(row.values[name], col.Type)
+		if err != nil {
+			return row, fmt.Errorf("column %s: %w", name, err)
+		}
+		row.values[name] = v
+	}
+	return row, nil
+}
+
+// parseBounds lê uma célula de faixa: {"from": x, "to": y}, "*" ou vazia (qualquer valor)
+func parseBounds(v interface{}) (rangeBounds, error) {
+	switch cell := v.(type) {
+	case nil:
+		return rangeBounds{wildcard: true}, nil
+	case string:
+		if cell == "" || cell == TableWildcard {
+			return rangeBounds{wildcard: true}, nil
+		}
+		return rangeBounds{}, fmt.Errorf("range must be an object with from/to, got %q", cell)
+	case map[string]interface{}:
+		var b rangeBounds
+		for key, raw := range cell {
+			n, err := convertCell(raw, ColumnNumber)
+			if err != nil {
+				return b, fmt.Errorf("%s: %w", key, err)
+			}
+			f, bounded := n.(float64) // nil ou "*" = limite aberto
+			switch key {
+			case "from":
+				b.from, b.hasFrom = f, bounded
+			case "to":
+				b.to, b.hasTo = f, bounded
+			default:
+				return b, fmt.Errorf("unknown range bound %q (use from/to)", key)
+			}
+		}
+		if b.hasFrom && b.hasTo && b.from > b.to {
+			return b, fmt.Errorf("from (%v) is greater than to (%v)", b.from, b.to)
+		}
+		b.wildcard = !b.hasFrom && !b.hasTo
+		return b, nil
+	default:
+		return rangeBounds{}, fmt.Errorf("range must be an object with from/to, got %T", v)
+	}
+}
+
+// convertCell converte uma célula (ou valor de busca) para o tipo da coluna; "*" é preservado
+func convertCell(v interface{}, typ string) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if s, ok := v.(string); ok {
+		if s == TableWildcard {
+			return s, nil
+		}
+		if s == "" && typ != "" && typ != ColumnString {
+			return nil, nil
+		}
+	}
+	switch typ {
+	case ColumnNumber:
+		switch n := v.(type) {
+		case float64:
+			return n, nil
+		case float32:
+			return float64(n), nil
+		case int:
+			return float64(n), nil
+		case int64:
+			return float64(n), nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q", n)
+			}
+			return f, nil
+		}
+		return nil, fmt.Errorf("expected number, got %T", v)
+	case ColumnBool:
+		switch b := v.(type) {
+		case bool:
+			return b, nil
+		case string:
+			parsed, err := strconv.ParseBool(strings.TrimSpace(b))
+			if err != nil {
+				return nil, fmt.Errorf("invalid bool %q", b)
+			}
+			return parsed, nil
+		}
+		return nil, fmt.Errorf("expected bool, got %T", v)
+	case ColumnString:
+		switch s := v.(type) {
+		case string:
+			return s, nil
+		case float64:
+			return strconv.FormatFloat(s, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(s), nil
+		}
+		return fmt.Sprint(v), nil
+	default:
+		return v, nil
+	}
+}
+
+// indexKey representa um valor de coluna exata no índice
+func indexKey(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Has indica se a tabela existe
+func (s *TableSet) Has(name string) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.tables[name]
+	return ok
+}
+
+// Names retorna os nomes das tabelas em ordem alfabética
+func (s *TableSet) Names() []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.tables))
+	for name := range s.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasColumn indica se a coluna foi declarada na tabela
+func (s *TableSet) HasColumn(table, column string) bool {
+	if s == nil {
+		return false
+	}
+	if t, ok := s.tables[table]; ok {
+		_, ok := t.columns[column]
+		return ok
+	}
+	return false
+}
+
+// Lookup retorna a linha que corresponde aos critérios (colunas-chave -> valor).
+//
+// Colunas-chave ausentes nos critérios só correspondem a linhas com "*". Entre várias
+// linhas válidas vence a mais específica (menos "*"); no empate, a primeira declarada.
+func (s *TableSet) Lookup(table string, criteria map[string]interface{}) (map[string]interface{}, bool, error) {
+	if s == nil {
+		return nil, false, fmt.Errorf("unknown table %q", table)
+	}
+	t, ok := s.tables[table]
+	if !ok {
+		return nil, false, fmt.Errorf("unknown table %q", table)
+	}
+
+	for col := range criteria {
+		if c, ok := t.columns[col]; !ok || c.Match == "" {
+			return nil, false, fmt.Errorf("table %s: %q is not a key column", table, col)
+		}
+	}
+
+	exact := make([]string, len(t.exact))
+	for i, col := range t.exact {
+		exact[i] = TableWildcard
+		if v, ok := criteria[col]; ok && v != nil {
+			converted, err := convertCell(v, t.columns[col].Type)
+			if err != nil {
+				// Valor de tipo incompatível não corresponde a nenhuma linha específica
+				continue
+			}
+			exact[i] = indexKey(converted)
+		}
+	}
+	numbers := make(map[string]*float64, len(t.ranges))
+	for _, col := range t.ranges {
+		if v, ok := criteria[col]; ok && v != nil {
+			if n, err := convertCell(v, ColumnNumber); err == nil && n != nil {
+				f, isNum := n.(float64)
+				if isNum {
+					numbers[col] = &f
+				}
+			}
+		}
+	}
+	best := -1
+	for _, idx := range t.candidates(exact) {
+		if !t.rows[idx].inRanges(t.ranges, numbers) {
+			continue
+		}
+		if best < 0 || t.rows[idx].wildcards < t.rows[best].wildcards ||
+			(t.rows[idx].wildcards == t.rows[best].wildcards && idx < best) {
+			best = idx
+		}
+	}
+	if best < 0 {
+		return nil, false, nil
+	}
+	return t.rows[best].output(), true, nil
+}
+
+// candidates retorna, em ordem, as linhas cujas colunas exatas correspondem à chave (valor ou "*"
+// em cada coluna): a interseção das listas de cada coluna, sem sondar combinações de "*"
+func (t *compiledTable) candidates(key []string) []int {
+	if len(key) == 0 {
+		all := make([]int, len(t.rows))
+		for i := range all {
+			all[i] = i
+		}
+		return all
+	}
+	var result []int
+	for j, k := range key {
+		list := t.index[j][TableWildcard]
+		if k != TableWildcard {
+			list = mergeSorted(t.index[j][k], list)
+		}
+		if j == 0 {
+			result = list
+		} else {
+			result = intersectSorted(result, list)
+		}
+		if len(result) == 0 {
+			return nil
+		}
+	}
+	return result
+}
+
+func mergeSorted(a, b []int) []int {
+	out := make([]int, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] < b[j] {
+			out = append(out, a[i])
+			i++
+		} else {
+			out = append(out, b[j])
+			j++
+		}
+	}
+	out = append(out, a[i:]...)
+	return append(out, b[j:]...)
+}
+
+func intersectSorted(a, b []int) []int {
+	out := make([]int, 0, min(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+func (r compiledRow) inRanges(ranges []string, numbers map[string]*float64) bool {
+	for _, col := range ranges {
+		b := r.bounds[col]
+		if b.wildcard {
+			continue
+		}
+		n := numbers[col]
+		if n == nil {
+			return false
+		}
+		if (b.hasFrom && *n < b.from) || (b.hasTo && *n >= b.to) {
+			return false
+		}
+	}
+	return true
+}
+
+// output monta a linha retornada (faixas como {"from", "to"})
+func (r compiledRow) output() map[string]interface{} {
+	out := make(map[string]interface{}, len(r.values))
+	for k, v := range r.values {
+		out[k] = v
+	}
+	for col, b := range r.bounds {
+		bounds := map[string]interface{}{}
+		if b.hasFrom {
+			bounds["from"] = b.from
+		}
+		if b.hasTo {
+			bounds["to"] = b.to
+		}
+		out[col] = bounds
+	}
+	return out
+}
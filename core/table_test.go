@@ -0,0 +1,88 @@
+package core_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+func TestTableSet_LookupSpecificity(t *testing.T) {
+	set, err := core.CompileTables(map[string]core.Table{
+		"icmsRates": {
+			Columns: []core.TableColumn{
+				{Name: "origin", Match: core.MatchExact},
+				{Name: "dest", Match: core.MatchExact},
+				{Name: "rate", Type: core.ColumnNumber},
+			},
+			Rows: []map[string]interface{}{
+				{"origin": "*", "dest": "*", "rate": 4},
+				{"origin": "SP", "dest": "*", "rate": 12},
+				{"origin": "SP", "dest": "SP", "rate": 18},
+				{"origin": "*", "dest": "RJ", "rate": 7},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CompileTables: %v", err)
+	}
+
+	tests := []struct {
+		criteria map[string]interface{}
+		want     float64
+	}{
+		{map[string]interface{}{"origin": "SP", "dest": "SP"}, 18},
+		{map[string]interface{}{"origin": "SP", "dest": "MG"}, 12},
+		{map[string]interface{}{"origin": "SP", "dest": "RJ"}, 12}, // empate: vence a primeira declarada
+		{map[string]interface{}{"origin": "MG", "dest": "RJ"}, 7},
+		{map[string]interface{}{"origin": "MG"}, 4},
+	}
+	for _, tt := range tests {
+		row, ok, err := set.Lookup("icmsRates", tt.criteria)
+		if err != nil || !ok {
+			t.Fatalf("%v: ok=%v err=%v", tt.criteria, ok, err)
+		}
+		if row["rate"] != tt.want {
+			t.Errorf("%v: rate = %v, want %v", tt.criteria, row["rate"], tt.want)
+		}
+	}
+}
+
+func TestTableSet_LookupManyExactColumns(t *testing.T) {
+	// 70 colunas exatas: a busca não pode depender de 2^n combinações de "*"
+	const n = 70
+	columns := make([]core.TableColumn, 0, n+1)
+	specific := map[string]interface{}{"value": "specific"}
+	fallback := map[string]interface{}{"value": "fallback"}
+	criteria := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("k%d", i)
+		columns = append(columns, core.TableColumn{Name: name, Match: core.MatchExact})
+		specific[name] = fmt.Sprintf("v%d", i)
+		fallback[name] = core.TableWildcard
+		criteria[name] = fmt.Sprintf("v%d", i)
+	}
+	fallback["k0"] = "v0"
+	columns = append(columns, core.TableColumn{Name: "value"})
+
+	set, err := core.CompileTables(map[string]core.Table{
+		"wide": {Columns: columns, Rows: []map[string]interface{}{fallback, specific}},
+	})
+	if err != nil {
+		t.Fatalf("CompileTables: %v", err)
+	}
+
+	row, ok, err := set.Lookup("wide", criteria)
+	if err != nil || !ok || row["value"] != "specific" {
+		t.Fatalf("full key: row=%v ok=%v err=%v", row, ok, err)
+	}
+	criteria["k69"] = "other"
+	row, ok, err = set.Lookup("wide", criteria)
+	if err != nil || !ok || row["value"] != "fallback" {
+		t.Fatalf("partial key: row=%v ok=%v err=%v", row, ok, err)
+	}
+	criteria["k0"] = "other"
+	if _, ok, err = set.Lookup("wide", criteria); err != nil || ok {
+		t.Fatalf("no match: ok=%v err=%v", ok, err)
+	}
+}
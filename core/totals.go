@@ -0,0 +1,298 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Nomes dos agregados legados (mantidos como campos de Totals por compatibilidade)
+const (
+	TotalSubtotal = "subtotal"
+	TotalDiscount = "discount"
+	TotalTax      = "tax"
+	TotalTotal    = "total"
+)
+
+// Tipos de agregado aceitos em TotalDef.Type
+const (
+	TotalTypeNumber   = "number"
+	TotalTypeCurrency = "currency"
+	TotalTypePercent  = "percent"
+	TotalTypeInteger  = "integer"
+)
+
+// legacyTotals define a ordem fixa dos agregados legados
+var legacyTotals = []string{TotalSubtotal, TotalDiscount, TotalTax, TotalTotal}
+
+// TotalDef declara um agregado nomeado no RulePack (ex: freight, insurance, icmsSt)
+type TotalDef struct {
+	Name      string `json:"name"`
+	Type      string `json:"type,omitempty"`      // "number" (padrão), "currency", "percent", "integer"
+	Precision *int   `json:"precision,omitempty"` // Casas decimais aplicadas ao gravar (padrão: currency=2, integer=0)
+}
+
+// EffectivePrecision retorna a precisão aplicada ao agregado (-1 = sem arredondamento)
+func (d TotalDef) EffectivePrecision() int {
+	if d.Precision != nil {
+		return *d.Precision
+	}
+	switch d.Type {
+	case TotalTypeCurrency:
+		return 2
+	case TotalTypeInteger:
+		return 0
+	default:
+		return -1
+	}
+}
+
+// ValidateTotalDefs valida os agregados declarados em rulePack.totals: nomes únicos, que não
+// redefinem os legados, tipo conhecido e precisão entre 0 e 10
+func ValidateTotalDefs(defs []TotalDef) error {
+	seen := make(map[string]bool, len(defs))
+	for i, def := range defs {
+		if def.Name == "" {
+			return fmt.Errorf("rulePack.totals[%d].name is required", i)
+		}
+		if seen[def.Name] {
+			return fmt.Errorf("rulePack.totals[%d]: duplicate total %q", i, def.Name)
+		}
+		seen[def.Name] = true
+		if isLegacyTotal(def.Name) {
+			return fmt.Errorf("rulePack.totals[%d]: %q is a legacy total and cannot be declared", i, def.Name)
+		}
+
+		switch def.Type {
+		case "", TotalTypeNumber, TotalTypeCurrency, TotalTypePercent, TotalTypeInteger:
+		default:
+			return fmt.Errorf("rulePack.totals[%d]: unknown type %q", i, def.Type)
+		}
+		if def.Precision != nil && (*def.Precision < 0 || *def.Precision > 10) {
+			return fmt.Errorf("rulePack.totals[%d]: precision must be between 0 and 10", i)
+		}
+	}
+	return nil
+}
+
+func isLegacyTotal(name string) bool {
+	for _, legacy := range legacyTotals {
+		if name == legacy {
+			return true
+		}
+	}
+	return false
+}
+
+// Totals representa totais/sumário calculados.
+//
+// Os quatro agregados legados (subtotal, discount, tax, total) continuam como campos.
+// Qualquer outro agregado (freight, insurance, icmsSt, ipi, ...) é mantido em um mapa
+// ordenado por ordem de declaração/inserção, acessível via Get/Set/Names.
+type Totals struct {
+	Subtotal float64 `json:"subtotal,omitempty"`
+	Discount float64 `json:"discount,omitempty"`
+	Tax      float64 `json:"tax,omitempty"`
+	Total    float64 `json:"total,omitempty"`
+
+	extra map[string]float64
+	order []string
+	defs  map[string]TotalDef
+}
+
+// Declare registra os agregados declarados no RulePack.
+// Agregados declarados passam a existir (valor 0) e respeitam a precisão do tipo ao serem gravados.
+func (t *Totals) Declare(defs []TotalDef) {
+	for _, def := range defs {
+		if def.Name == "" {
+			continue
+		}
+		if t.defs == nil {
+			t.defs = make(map[string]TotalDef, len(defs))
+		}
+		t.defs[def.Name] = def
+
+		if current, ok := t.Get(def.Name); ok {
+			t.Set(def.Name, current)
+		} else {
+			t.Set(def.Name, 0)
+		}
+	}
+}
+
+// Def retorna a declaração de um agregado, se existir
+func (t *Totals) Def(name string) (TotalDef, bool) {
+	def, ok := t.defs[name]
+	return def, ok
+}
+
+// Get retorna o valor de um agregado e se ele existe
+func (t *Totals) Get(name string) (float64, bool) {
+	switch name {
+	case TotalSubtotal:
+		return t.Subtotal, true
+	case TotalDiscount:
+		return t.Discount, true
+	case TotalTax:
+		return t.Tax, true
+	case TotalTotal:
+		return t.Total, true
+	}
+	v, ok := t.extra[name]
+	return v, ok
+}
+
+// Set define o valor de um agregado, aplicando a precisão declarada
+func (t *Totals) Set(name string, value float64) {
+	if def, ok := t.defs[name]; ok {
+		if p := def.EffectivePrecision(); p >= 0 {
+			m := math.Pow(10, float64(p))
+			value = math.Round(value*m) / m
+		}
+	}
+
+	switch name {
+	case TotalSubtotal:
+		t.Subtotal = value
+	case TotalDiscount:
+		t.Discount = value
+	case TotalTax:
+		t.Tax = value
+	case TotalTotal:
+		t.Total = value
+	default:
+		if t.extra == nil {
+			t.extra = make(map[string]float64)
+		}
+		if _, exists := t.extra[name]; !exists {
+			t.order = append(t.order, name)
+		}
+		t.extra[name] = value
+	}
+}
+
+// Names retorna os nomes dos agregados em ordem (legados primeiro, depois os demais)
+func (t *Totals) Names() []string {
+	names := make([]string, 0, len(legacyTotals)+len(t.order))
+	names = append(names, legacyTotals...)
+	names = append(names, t.order...)
+	return names
+}
+
+// Map retorna todos os agregados como mapa (formato usado na avaliação JsonLogic)
+func (t *Totals) Map() map[string]interface{} {
+	out := make(map[string]interface{}, len(legacyTotals)+len(t.order))
+	for _, name := range t.Names() {
+		v, _ := t.Get(name)
+		out[name] = v
+	}
+	return out
+}
+
+// IsZero indica se não há nenhum agregado com valor ou declarado
+func (t Totals) IsZero() bool {
+	return t.Subtotal == 0 && t.Discount == 0 && t.Tax == 0 && t.Total == 0 && len(t.order) == 0 && len(t.defs) == 0
+}
+
+// Equal compara os valores de dois Totals (ordem e declarações são ignoradas)
+func (t Totals) Equal(other Totals) bool {
+	if t.Subtotal != other.Subtotal || t.Discount != other.Discount || t.Tax != other.Tax || t.Total != other.Total {
+		return false
+	}
+	if len(t.extra) != len(other.extra) {
+		return false
+	}
+	for k, v := range t.extra {
+		ov, ok := other.extra[k]
+		if !ok || ov != v {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON serializa os agregados preservando a ordem (legados omitidos quando zero)
+func (t Totals) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	write := func(name string, value float64) error {
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		val, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("totals.%s: %w", name, err)
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+		return nil
+	}
+
+	for _, name := range legacyTotals {
+		v, _ := t.Get(name)
+		if v == 0 {
+			if _, declared := t.defs[name]; !declared {
+				continue
+			}
+		}
+		if err := write(name, v); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range t.order {
+		if err := write(name, t.extra[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON aceita os agregados legados e quaisquer outros agregados numéricos.
+// A ordem das chaves no JSON é preservada para os agregados não legados.
+func (t *Totals) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("totals must be an object")
+	}
+
+	*t = Totals{defs: t.defs}
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := keyTok.(string)
+
+		var raw interface{}
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		if key == "" || raw == nil {
+			continue
+		}
+		f, ok := asFloat64(raw)
+		if !ok {
+			return fmt.Errorf("totals.%s must be numeric, got %T", key, raw)
+		}
+		t.Set(key, f)
+	}
+	_, err = dec.Token()
+	return err
+}
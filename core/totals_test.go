@@ -0,0 +1,62 @@
+package core_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+func TestTotals_NamedAggregatesJSON(t *testing.T) {
+	var totals core.Totals
+	if err := json.Unmarshal([]byte(`{"total": 10, "freight": 1.5, "insurance": 2, "ipi": 0.75}`), &totals); err != nil {
+		t.Fatalf("unmarshal totals: %v", err)
+	}
+
+	if v, ok := totals.Get("insurance"); !ok || v != 2 {
+		t.Errorf("Expected insurance 2, got %v (exists=%v)", v, ok)
+	}
+
+	totals.Declare([]core.TotalDef{{Name: "surcharge", Type: core.TotalTypeCurrency}})
+	totals.Set("surcharge", 3.14159)
+
+	out, err := json.Marshal(totals)
+	if err != nil {
+		t.Fatalf("marshal totals: %v", err)
+	}
+	expected := `{"total":10,"freight":1.5,"insurance":2,"ipi":0.75,"surcharge":3.14}`
+	if string(out) != expected {
+		t.Errorf("Expected %s, got %s", expected, out)
+	}
+}
+
+func TestValidateTotalDefs(t *testing.T) {
+	precision := func(p int) *int { return &p }
+	tests := []struct {
+		name string
+		defs []core.TotalDef
+		err  string
+	}{
+		{"valid", []core.TotalDef{{Name: "freight", Type: core.TotalTypeCurrency}, {Name: "commission", Precision: precision(3)}}, ""},
+		{"missing name", []core.TotalDef{{Type: core.TotalTypeCurrency}}, "name is required"},
+		{"duplicate", []core.TotalDef{{Name: "freight"}, {Name: "freight"}}, `duplicate total "freight"`},
+		{"legacy", []core.TotalDef{{Name: "total", Type: core.TotalTypeCurrency}}, `"total" is a legacy total`},
+		{"unknown type", []core.TotalDef{{Name: "freight", Type: "money"}}, `unknown type "money"`},
+		{"precision", []core.TotalDef{{Name: "freight", Precision: precision(11)}}, "precision must be between 0 and 10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := core.ValidateTotalDefs(tt.defs)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
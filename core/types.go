@@ -4,6 +4,7 @@ package core
 type State struct {
 	ID       string                 `json:"id,omitempty"`
 	TenantID string                 `json:"tenantId,omitempty"`
+	Currency string                 `json:"currency,omitempty"` // Moeda ISO-4217 dos valores (opcional)
 	Items    []Item                 `json:"items,omitempty"`  // Coleção de itens
 	Totals   Totals                 `json:"totals,omitempty"`  // Totais/sumário
 	Fields   map[string]interface{} `json:"fields,omitempty"`  // Campos customizáveis
@@ -14,22 +15,24 @@ type State struct {
 type Item struct {
 	ID     string                 `json:"id,omitempty"`
 	Amount float64                `json:"amount,omitempty"`  // Quantidade/valor base
+	Currency string               `json:"currency,omitempty"` // Moeda ISO-4217 dos valores do item (padrão: a do State)
+	Unit     string               `json:"unit,omitempty"`     // Unidade de Amount (ex: "case"; ver RulePack.Units)
 	Fields map[string]interface{} `json:"fields,omitempty"`  // Campos customizáveis
 }
 
-// Totals representa totais/sumário calculados
-type Totals struct {
-	Subtotal float64 `json:"subtotal,omitempty"`
-	Discount float64 `json:"discount,omitempty"`
-	Tax      float64 `json:"tax,omitempty"`
-	Total    float64 `json:"total,omitempty"`
-}
-
 // RulePack representa um pacote de regras versionado
 type RulePack struct {
 	ID      string     `json:"id"`
 	Version string     `json:"version"`
 	Phases  []RulePhase `json:"phases"`
+	Totals  []TotalDef  `json:"totals,omitempty"` // Agregados nomeados declarados (além dos legados)
+
+	Projections map[string]Projection `json:"projections,omitempty"` // Arrays auxiliares expostos ao JsonLogic (ex: itemValues)
+	Tables      map[string]Table      `json:"tables,omitempty"`      // Tabelas de consulta (operador lookup)
+	Promotions  []Promotion           `json:"promotions,omitempty"`  // Promoções e cupons (compiladas em regras)
+
+	ExchangeRates ExchangeRates `json:"exchangeRates,omitempty" yaml:"exchangeRates,omitempty"` // Cotações padrão (ex: {"USD/BRL": 5.12})
+	Units         map[string]UnitTable `json:"units,omitempty" yaml:"units,omitempty"`         // Unidades de medida por produto (operadores toUnit/fromUnit)
 }
 
 // RulePhase representa uma fase de processamento (baseline, allocation, taxes, totals, validations, guards, etc.)
@@ -62,6 +65,12 @@ type ContextMeta struct {
 	TenantID string `json:"tenantId"`
 	UserID   string `json:"userId,omitempty"`
 	Locale   string `json:"locale,omitempty"`
+
+	Now      string   `json:"now,omitempty"`      // Relógio da execução (RFC3339); vazio = horário atual
+	Timezone string   `json:"timezone,omitempty"` // Fuso IANA padrão dos operadores de data (ex: America/Sao_Paulo)
+	Holidays []string `json:"holidays,omitempty"` // Feriados (YYYY-MM-DD) desconsiderados em dias úteis
+
+	ExchangeRates ExchangeRates `json:"exchangeRates,omitempty"` // Cotações da execução (sobrepõem as do RulePack)
 }
 
 // Reason rastreia qual regra executou e por quê
@@ -85,4 +94,5 @@ type RunEngineResult struct {
 	Reasons       []Reason               `json:"reasons"`         // Regras que executaram
 	Violations    []Violation            `json:"violations"`      // Violações de validação
 	RulesVersion  string                 `json:"rulesVersion"`    // Versão das regras usadas
+	Promotions    []AppliedPromotion     `json:"promotions,omitempty"` // Promoções aplicadas, na ordem de aplicação
 }
@@ -0,0 +1,236 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+// Tabela de unidades usada pelos itens sem ItemUnitTableField
+const (
+	DefaultUnitTable   = "default"
+	ItemUnitTableField = "unitTable" // campo do item com o nome da tabela de unidades (ex: o produto)
+)
+
+// Arredondamento de quantidades convertidas para unidades indivisíveis
+const (
+	UnitRoundUp      = "up"      // para cima (padrão: atende a quantidade pedida)
+	UnitRoundDown    = "down"    // para baixo (só embalagens completas)
+	UnitRoundNearest = "nearest" // mais próximo
+)
+
+// UnitTable declara as unidades de um produto (ou família) e o fator de cada uma para a unidade base.
+//
+//	"units": {
+//	  "beer":  {"base": "un", "units": {"pack6": {"factor": 6, "indivisible": true}, "case": {"factor": 24, "indivisible": true}}},
+//	  "cable": {"base": "m",  "units": {"cm": {"factor": 0.01}, "roll": {"factor": 100, "indivisible": true}}}
+//	}
+//
+// A unidade base sempre existe (fator 1) mesmo sem ser declarada em Units.
+type UnitTable struct {
+	Base  string             `json:"base" yaml:"base"`
+	Units map[string]UnitDef `json:"units,omitempty" yaml:"units,omitempty"`
+}
+
+// UnitDef é uma unidade: Factor unidades base por unidade (caixa com 24 = 24)
+type UnitDef struct {
+	Factor      float64 `json:"factor" yaml:"factor"`
+	Indivisible bool    `json:"indivisible,omitempty" yaml:"indivisible,omitempty"` // quantidades inteiras nesta unidade
+	Decimals    *int    `json:"decimals,omitempty" yaml:"decimals,omitempty"`       // casas das quantidades nesta unidade
+	Rounding    string  `json:"rounding,omitempty" yaml:"rounding,omitempty"`       // up (padrão), down ou nearest
+}
+
+// UnitSet são as tabelas de unidades validadas de um RulePack
+type UnitSet struct {
+	tables map[string]UnitTable
+}
+
+// CompileUnits valida as tabelas de unidades (fatores positivos, arredondamento conhecido)
+func CompileUnits(tables map[string]UnitTable) (*UnitSet, error) {
+	set := &UnitSet{tables: make(map[string]UnitTable, len(tables))}
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		table := tables[name]
+		if table.Base == "" {
+			return nil, fmt.Errorf("units %s: base is required", name)
+		}
+		units := make(map[string]UnitDef, len(table.Units)+1)
+		for unit, def := range table.Units {
+			if unit == "" {
+				return nil, fmt.Errorf("units %s: unit name is required", name)
+			}
+			if def.Factor <= 0 {
+				return nil, fmt.Errorf("units %s.%s: factor must be positive", name, unit)
+			}
+			if unit == table.Base && def.Factor != 1 {
+				return nil, fmt.Errorf("units %s.%s: base unit factor must be 1", name, unit)
+			}
+			switch def.Rounding {
+			case "", UnitRoundUp, UnitRoundDown, UnitRoundNearest:
+			default:
+				return nil, fmt.Errorf("units %s.%s: unknown rounding %q (use %s, %s or %s)", name, unit, def.Rounding, UnitRoundUp, UnitRoundDown, UnitRoundNearest)
+			}
+			if def.Decimals != nil && (*def.Decimals < 0 || *def.Decimals > 9) {
+				return nil, fmt.Errorf("units %s.%s: decimals must be between 0 and 9", name, unit)
+			}
+			units[unit] = def
+		}
+		if _, ok := units[table.Base]; !ok {
+			units[table.Base] = UnitDef{Factor: 1}
+		}
+		set.tables[name] = UnitTable{Base: table.Base, Units: units}
+	}
+	return set, nil
+}
+
+// Has indica se a tabela existe
+func (s *UnitSet) Has(table string) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.tables[table]
+	return ok
+}
+
+// Base retorna a unidade base da tabela
+func (s *UnitSet) Base(table string) (string, error) {
+	t, err := s.table(table)
+	if err != nil {
+		return "", err
+	}
+	return t.Base, nil
+}
+
+// ToBase converte quantity na unidade para a unidade base
+func (s *UnitSet) ToBase(table, unit string, quantity float64) (float64, error) {
+	def, err := s.unit(table, unit)
+	if err != nil {
+		return 0, err
+	}
+	base, _ := s.unit(table, s.tables[table].Base)
+	return base.round(quantity*def.Factor, ""), nil
+}
+
+// FromBase converte a quantidade base para a unidade, arredondando conforme a unidade
+// (rounding vazio usa o da unidade)
+func (s *UnitSet) FromBase(table, unit string, quantity float64, rounding string) (float64, error) {
+	def, err := s.unit(table, unit)
+	if err != nil {
+		return 0, err
+	}
+	switch rounding {
+	case "", UnitRoundUp, UnitRoundDown, UnitRoundNearest:
+	default:
+		return 0, fmt.Errorf("unknown rounding %q (use %s, %s or %s)", rounding, UnitRoundUp, UnitRoundDown, UnitRoundNearest)
+	}
+	return def.round(quantity/def.Factor, rounding), nil
+}
+
+// ItemTable retorna a tabela de unidades do item (ItemUnitTableField ou DefaultUnitTable)
+func ItemTable(item Item) string {
+	if name, ok := item.Fields[ItemUnitTableField].(string); ok && name != "" {
+		return name
+	}
+	return DefaultUnitTable
+}
+
+// ValidateItem confere se a unidade do item existe na tabela dele (itens sem unidade são aceitos)
+func (s *UnitSet) ValidateItem(item Item) error {
+	if item.Unit == "" {
+		return nil
+	}
+	_, err := s.unit(ItemTable(item), item.Unit)
+	return err
+}
+
+// ItemBaseAmount converte Amount do item (na unidade dele) para a unidade base da tabela do item;
+// ok é falso para itens sem unidade ou sem tabela
+func (s *UnitSet) ItemBaseAmount(item Item) (float64, bool) {
+	if item.Unit == "" {
+		return 0, false
+	}
+	base, err := s.ToBase(ItemTable(item), item.Unit, item.Amount)
+	if err != nil {
+		return 0, false
+	}
+	return base, true
+}
+
+// ValidateItemUnits confere as unidades dos itens do estado contra as tabelas
+func ValidateItemUnits(state State, units *UnitSet) error {
+	for i, item := range state.Items {
+		if err := units.ValidateItem(item); err != nil {
+			return fmt.Errorf("items[%d].unit: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// IsIndivisible indica se a unidade só aceita quantidades inteiras
+func (s *UnitSet) IsIndivisible(table, unit string) (bool, error) {
+	def, err := s.unit(table, unit)
+	if err != nil {
+		return false, err
+	}
+	return def.Indivisible, nil
+}
+
+func (s *UnitSet) table(table string) (UnitTable, error) {
+	if s == nil {
+		return UnitTable{}, fmt.Errorf("unknown unit table %q", table)
+	}
+	t, ok := s.tables[table]
+	if !ok {
+		return UnitTable{}, fmt.Errorf("unknown unit table %q", table)
+	}
+	return t, nil
+}
+
+func (s *UnitSet) unit(table, unit string) (UnitDef, error) {
+	t, err := s.table(table)
+	if err != nil {
+		return UnitDef{}, err
+	}
+	def, ok := t.Units[unit]
+	if !ok {
+		return UnitDef{}, fmt.Errorf("unknown unit %q in table %s", unit, table)
+	}
+	return def, nil
+}
+
+// round arredonda uma quantidade na unidade: inteira se indivisível, nas casas declaradas
+// ou, sem elas, apenas removendo o ruído de ponto flutuante
+func (d UnitDef) round(quantity float64, rounding string) float64 {
+	if rounding == "" {
+		rounding = d.Rounding
+	}
+	decimals := 9
+	switch {
+	case d.Indivisible:
+		decimals = 0
+	case d.Decimals != nil:
+		decimals = *d.Decimals
+	}
+	if !d.Indivisible && d.Decimals == nil {
+		return pkg.Round(quantity, decimals)
+	}
+
+	scale := math.Pow(10, float64(decimals))
+	scaled := pkg.Round(quantity*scale, 6)
+	switch rounding {
+	case UnitRoundDown:
+		scaled = math.Floor(scaled)
+	case UnitRoundNearest:
+		scaled = math.Round(scaled)
+	default:
+		scaled = math.Ceil(scaled)
+	}
+	return scaled / scale
+}
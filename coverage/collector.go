@@ -0,0 +1,244 @@
+package coverage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/operators"
+	"github.com/dolphin-sistemas/computations-engine/pipeline"
+)
+
+// Collector acumula a cobertura de várias execuções (seguro para uso concorrente)
+type Collector struct {
+	mu    sync.Mutex
+	packs map[string]*packState
+	order []string
+}
+
+type packState struct {
+	pack     *Pack
+	rules    map[string]*Rule // por id (com ids repetidos, vale a primeira regra)
+	branches []*Branch        // pelo id usado em operators.BranchOperator
+}
+
+// NewCollector cria um coletor vazio
+func NewCollector() *Collector {
+	return &Collector{packs: make(map[string]*packState)}
+}
+
+type contextKey struct{}
+
+// NewContext retorna um contexto que liga a coleta de cobertura em engine.RunEngine
+func NewContext(ctx context.Context, c *Collector) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext retorna o coletor do contexto (nil quando a coleta está desligada)
+func FromContext(ctx context.Context) *Collector {
+	if ctx == nil {
+		return nil
+	}
+	c, _ := ctx.Value(contextKey{}).(*Collector)
+	return c
+}
+
+// Instrument registra uma execução do RulePack (já com as promoções compiladas) e retorna uma cópia
+// em que cada if das conditions e das logics das ações registra o ramo, e o recorder da execução.
+// O RulePack recebido não é alterado.
+func (c *Collector) Instrument(rules core.RulePack) (core.RulePack, core.CoverageRecorder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := rules.ID + "@" + rules.Version
+	state, ok := c.packs[key]
+	if !ok {
+		state = &packState{pack: &Pack{ID: rules.ID, Version: rules.Version}, rules: make(map[string]*Rule)}
+		c.packs[key] = state
+		c.order = append(c.order, key)
+	}
+	state.pack.Runs++
+
+	// Numeração dos ifs: regras na ordem do pipeline, condition e depois as ações
+	ids := make(map[string][]int) // por regra: ids dos ifs na ordem da instrumentação
+	next := 0
+	for _, rule := range pipeline.OrderedRules(rules) {
+		r := state.rules[rule.ID]
+		if r == nil {
+			r = &Rule{ID: rule.ID, Phase: rule.Phase, Enabled: rule.Enabled, HasCondition: len(rule.Condition) > 0}
+			for _, action := range rule.Actions {
+				r.Actions = append(r.Actions, &Action{Type: action.Type})
+			}
+			state.rules[rule.ID] = r
+			state.pack.Rules = append(state.pack.Rules, r)
+		}
+		if _, seen := ids[rule.ID]; seen {
+			continue
+		}
+		var locations []string
+		collectBranches(rule.Condition, "condition", &locations)
+		for i, action := range rule.Actions {
+			collectBranches(action.Logic, fmt.Sprintf("actions[%d].logic", i), &locations)
+		}
+		for _, location := range locations {
+			b := r.branch(location)
+			if b == nil {
+				b = &Branch{Location: location}
+				r.Branches = append(r.Branches, b)
+			}
+			for len(state.branches) <= next {
+				state.branches = append(state.branches, nil)
+			}
+			state.branches[next] = b
+			ids[rule.ID] = append(ids[rule.ID], next)
+			next++
+		}
+		if ids[rule.ID] == nil {
+			ids[rule.ID] = []int{}
+		}
+	}
+
+	// Cópia instrumentada
+	out := rules
+	out.Phases = make([]core.RulePhase, len(rules.Phases))
+	for p, phase := range rules.Phases {
+		out.Phases[p] = phase
+		out.Phases[p].Rules = make([]core.Rule, len(phase.Rules))
+		for i, rule := range phase.Rules {
+			next := ids[rule.ID]
+			instrumented := rule
+			if rule.Condition != nil {
+				instrumented.Condition = instrument(rule.Condition, &next).(map[string]interface{})
+			}
+			instrumented.Actions = make([]core.Action, len(rule.Actions))
+			for j, action := range rule.Actions {
+				instrumented.Actions[j] = action
+				if action.Logic != nil {
+					instrumented.Actions[j].Logic = instrument(action.Logic, &next).(map[string]interface{})
+				}
+			}
+			out.Phases[p].Rules[i] = instrumented
+		}
+	}
+	return out, &recorder{collector: c, state: state}
+}
+
+// collectBranches lista as localizações dos ifs na ordem em que instrument os numera
+func collectBranches(logic interface{}, path string, out *[]string) {
+	switch v := logic.(type) {
+	case map[string]interface{}:
+		for _, k := range sortedKeys(v) {
+			if isBranch(k, v[k]) {
+				*out = append(*out, path+".if")
+			}
+			collectBranches(v[k], path+"."+k, out)
+		}
+	case []interface{}:
+		for i, child := range v {
+			collectBranches(child, fmt.Sprintf("%s[%d]", path, i), out)
+		}
+	}
+}
+
+// instrument copia a lógica trocando cada if por operators.BranchOperator com o próximo id
+func instrument(logic interface{}, ids *[]int) interface{} {
+	switch v := logic.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for _, k := range sortedKeys(v) {
+			if isBranch(k, v[k]) && len(*ids) > 0 {
+				id := (*ids)[0]
+				*ids = (*ids)[1:]
+				args := v[k].([]interface{})
+				branch := []interface{}{float64(id)}
+				for _, arg := range args {
+					branch = append(branch, instrument(arg, ids))
+				}
+				out[operators.BranchOperator] = branch
+				continue
+			}
+			out[k] = instrument(v[k], ids)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			out[i] = instrument(child, ids)
+		}
+		return out
+	default:
+		return logic
+	}
+}
+
+// isBranch indica um if instrumentável: {"if": [condição, então]} ou {"if": [condição, então, senão]}
+func isBranch(key string, args interface{}) bool {
+	if key != "if" {
+		return false
+	}
+	list, ok := args.([]interface{})
+	return ok && (len(list) == 2 || len(list) == 3)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Profile retorna uma cópia da cobertura acumulada
+func (c *Collector) Profile() *Profile {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p := &Profile{}
+	for _, key := range c.order {
+		p.Packs = append(p.Packs, c.packs[key].pack)
+	}
+	return Merge(p)
+}
+
+// recorder implementa core.CoverageRecorder para uma execução
+type recorder struct {
+	collector *Collector
+	state     *packState
+}
+
+func (r *recorder) RecordRule(ruleID string, fired bool) {
+	r.collector.mu.Lock()
+	defer r.collector.mu.Unlock()
+	if rule := r.state.rules[ruleID]; rule != nil {
+		rule.Evaluated++
+		if fired {
+			rule.Fired++
+		}
+	}
+}
+
+func (r *recorder) RecordAction(ruleID string, index int, violated bool) {
+	r.collector.mu.Lock()
+	defer r.collector.mu.Unlock()
+	if rule := r.state.rules[ruleID]; rule != nil && index < len(rule.Actions) {
+		rule.Actions[index].Executed++
+		if violated {
+			rule.Actions[index].Violations++
+		}
+	}
+}
+
+func (r *recorder) RecordBranch(id int, taken bool) {
+	r.collector.mu.Lock()
+	defer r.collector.mu.Unlock()
+	if id < 0 || id >= len(r.state.branches) || r.state.branches[id] == nil {
+		return
+	}
+	if taken {
+		r.state.branches[id].True++
+	} else {
+		r.state.branches[id].False++
+	}
+}
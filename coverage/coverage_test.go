@@ -0,0 +1,162 @@
+package coverage_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine"
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/coverage"
+	"github.com/dolphin-sistemas/computations-engine/vectors"
+)
+
+const pack = `{"id": "cov", "version": "v1", "phases": [
+  {"name": "baseline", "rules": [
+    {"id": "price", "phase": "baseline", "priority": 1, "enabled": true, "actions": [
+      {"type": "compute", "target": "items[*].fields.total",
+       "logic": {"if": [{">": [{"var": "amount"}, 10]}, {"*": [{"var": "price"}, {"var": "amount"}, 0.9]}, {"*": [{"var": "price"}, {"var": "amount"}]}]}}
+    ]},
+    {"id": "big-order", "phase": "baseline", "priority": 2, "enabled": true,
+     "condition": {">": [{"sumPath": ["items[*].amount"]}, 100]},
+     "actions": [{"type": "set", "target": "fields.big", "value": true}]},
+    {"id": "off", "phase": "baseline", "priority": 3, "actions": [{"type": "set", "target": "fields.off", "value": true}]}
+  ]},
+  {"name": "guards", "rules": [
+    {"id": "min-total", "phase": "guards", "priority": 1, "enabled": true, "actions": [
+      {"type": "validate", "logic": {"<": [{"sumPath": ["items[*].fields.total"]}, 50]}, "params": {"field": "totals.total", "code": "MIN", "message": "minimum"}}
+    ]}
+  ]}
+]}`
+
+func loadPack(t *testing.T) core.RulePack {
+	t.Helper()
+	var rules core.RulePack
+	if err := json.Unmarshal([]byte(pack), &rules); err != nil {
+		t.Fatal(err)
+	}
+	return rules
+}
+
+func state(amounts ...float64) core.State {
+	s := core.State{}
+	for i, amount := range amounts {
+		s.Items = append(s.Items, core.Item{ID: string(rune('a' + i)), Amount: amount, Fields: map[string]interface{}{"price": 5.0}})
+	}
+	return s
+}
+
+func TestCollector_CountsRulesActionsAndBranches(t *testing.T) {
+	rules := loadPack(t)
+	original, _ := json.Marshal(rules)
+	collector := coverage.NewCollector()
+	ctx := coverage.NewContext(context.Background(), collector)
+
+	for _, s := range []core.State{state(2, 20), state(1)} {
+		withCoverage, err := engine.RunEngine(ctx, s, rules, core.ContextMeta{Now: "2026-01-01T00:00:00Z"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// A instrumentação não muda o resultado
+		without, err := engine.RunEngine(context.Background(), s, rules, core.ContextMeta{Now: "2026-01-01T00:00:00Z"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(withCoverage, without) {
+			t.Errorf("result with coverage differs:\n%+v\n%+v", withCoverage, without)
+		}
+	}
+	if after, _ := json.Marshal(rules); string(after) != string(original) {
+		t.Error("Instrument modified the rule pack")
+	}
+
+	profile := collector.Profile()
+	if len(profile.Packs) != 1 || profile.Packs[0].Runs != 2 {
+		t.Fatalf("packs = %+v", profile.Packs)
+	}
+	byID := map[string]*coverage.Rule{}
+	for _, r := range profile.Packs[0].Rules {
+		byID[r.ID] = r
+	}
+
+	price := byID["price"]
+	if price.Fired != 2 || len(price.Branches) != 1 || price.Branches[0].Location != "actions[0].logic.if" ||
+		price.Branches[0].True != 1 || price.Branches[0].False != 2 {
+		t.Errorf("price = %+v, branches %+v", price, price.Branches[0])
+	}
+	if big := byID["big-order"]; big.Evaluated != 2 || big.Fired != 0 || big.Actions[0].Executed != 0 {
+		t.Errorf("big-order = %+v", big)
+	}
+	if off := byID["off"]; off.Enabled || off.Evaluated != 0 {
+		t.Errorf("off = %+v", off)
+	}
+	if min := byID["min-total"]; min.Actions[0].Executed != 2 || min.Actions[0].Violations != 1 {
+		t.Errorf("min-total actions = %+v", min.Actions[0])
+	}
+
+	never := profile.Packs[0].NeverFired()
+	if len(never) != 1 || never[0].ID != "big-order" {
+		t.Errorf("never fired = %+v", never)
+	}
+	s := profile.Summary()
+	// regras 2/3, ações 2/3, ramos: condition de big-order 1/2 + if 2/2
+	if s.RulesCovered != 2 || s.Rules != 3 || s.ActionsCovered != 2 || s.Actions != 3 || s.BranchesCovered != 3 || s.Branches != 4 {
+		t.Errorf("summary = %+v", s)
+	}
+}
+
+func TestMergeAndReports(t *testing.T) {
+	rules := loadPack(t)
+	run := func(s core.State) *coverage.Profile {
+		collector := coverage.NewCollector()
+		if _, err := engine.RunEngine(coverage.NewContext(context.Background(), collector), s, rules, core.ContextMeta{}); err != nil {
+			t.Fatal(err)
+		}
+		return collector.Profile()
+	}
+
+	// Perfil gravado e lido de volta, combinado com outro
+	var buf bytes.Buffer
+	if err := coverage.WriteJSON(&buf, run(state(90, 20))); err != nil {
+		t.Fatal(err)
+	}
+	read, err := coverage.ReadProfile(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := coverage.Merge(read, run(state(1)))
+	if merged.Packs[0].Runs != 2 || len(merged.Packs[0].NeverFired()) != 0 {
+		t.Errorf("merged = %+v", merged.Packs[0])
+	}
+
+	var text bytes.Buffer
+	if err := coverage.WriteText(&text, coverage.Merge(run(state(1)))); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"pack cov v1 (1 runs)", "big-order", "NEVER FIRED", "actions[0].logic.if", "true 0, false 1", "disabled", "total: "} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text report does not contain %q:\n%s", want, text.String())
+		}
+	}
+
+	var html bytes.Buffer
+	if err := coverage.WriteHTML(&html, merged); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html.String(), "<td>big-order</td>") || !strings.Contains(html.String(), `class="covered"`) {
+		t.Errorf("unexpected HTML report:\n%s", html.String())
+	}
+}
+
+func TestVectorsCoverage(t *testing.T) {
+	collector := coverage.NewCollector()
+	vectors.Run(t, "../testdata/vectors", vectors.WithCoverage(collector))
+
+	profile := collector.Profile()
+	if len(profile.Packs) == 0 || profile.Summary().Rules == 0 {
+		t.Fatalf("no coverage collected: %+v", profile)
+	}
+}
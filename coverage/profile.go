@@ -0,0 +1,257 @@
+// Package coverage coleta a cobertura de RulePacks: quantas vezes cada regra disparou, cada ação
+// executou e cada ramo JsonLogic (condition da regra e ifs) foi verdadeiro ou falso, somando
+// várias execuções do motor, e gera relatórios em texto, HTML e JSON.
+//
+//	collector := coverage.NewCollector()
+//	ctx := coverage.NewContext(context.Background(), collector)
+//	engine.RunEngine(ctx, state, pack, meta) // várias vezes
+//	coverage.WriteText(os.Stdout, collector.Profile())
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Profile é a cobertura acumulada, por RulePack (id e versão)
+type Profile struct {
+	Packs []*Pack `json:"packs"`
+}
+
+// Pack é a cobertura de um RulePack
+type Pack struct {
+	ID      string  `json:"id"`
+	Version string  `json:"version"`
+	Runs    int     `json:"runs"`  // execuções do motor com o pack
+	Rules   []*Rule `json:"rules"` // na ordem do pipeline
+}
+
+// Rule é a cobertura de uma regra
+type Rule struct {
+	ID           string    `json:"id"`
+	Phase        string    `json:"phase"`
+	Enabled      bool      `json:"enabled"`
+	HasCondition bool      `json:"hasCondition"`
+	Evaluated    int       `json:"evaluated"` // vezes que a condition foi avaliada (ou a regra alcançada, sem condition)
+	Fired        int       `json:"fired"`     // vezes que as ações executaram
+	Actions      []*Action `json:"actions"`
+	Branches     []*Branch `json:"branches,omitempty"`
+}
+
+// Action é a cobertura de uma ação da regra
+type Action struct {
+	Type       string `json:"type"`
+	Executed   int    `json:"executed"`
+	Violations int    `json:"violations,omitempty"` // validate: vezes que gerou violação
+}
+
+// Branch é a cobertura de um if: quantas vezes a condição foi verdadeira e falsa
+type Branch struct {
+	Location string `json:"location"` // ex: condition.and[1].if, actions[0].logic.if
+	True     int    `json:"true"`
+	False    int    `json:"false"`
+}
+
+// Summary conta os itens cobertos: regras que dispararam, ações executadas e resultados de ramos
+// (verdadeiro e falso de cada condition e de cada if) observados. Regras desabilitadas ficam fora.
+type Summary struct {
+	Rules, RulesCovered       int
+	Actions, ActionsCovered   int
+	Branches, BranchesCovered int
+}
+
+// Percent é a cobertura total (itens cobertos / itens), 100 quando não há itens
+func (s Summary) Percent() float64 {
+	return percent(s.RulesCovered+s.ActionsCovered+s.BranchesCovered, s.Rules+s.Actions+s.Branches)
+}
+
+func (s *Summary) add(o Summary) {
+	s.Rules += o.Rules
+	s.RulesCovered += o.RulesCovered
+	s.Actions += o.Actions
+	s.ActionsCovered += o.ActionsCovered
+	s.Branches += o.Branches
+	s.BranchesCovered += o.BranchesCovered
+}
+
+func percent(covered, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(covered) * 100 / float64(total)
+}
+
+// Summary da regra (zerado para regras desabilitadas)
+func (r *Rule) Summary() Summary {
+	var s Summary
+	if !r.Enabled {
+		return s
+	}
+	s.Rules = 1
+	if r.Fired > 0 {
+		s.RulesCovered = 1
+	}
+	for _, a := range r.Actions {
+		s.Actions++
+		if a.Executed > 0 {
+			s.ActionsCovered++
+		}
+	}
+	if r.HasCondition {
+		s.Branches += 2
+		s.BranchesCovered += covered(r.Fired) + covered(r.Evaluated-r.Fired)
+	}
+	for _, b := range r.Branches {
+		s.Branches += 2
+		s.BranchesCovered += covered(b.True) + covered(b.False)
+	}
+	return s
+}
+
+// Summary do pack
+func (p *Pack) Summary() Summary {
+	var s Summary
+	for _, r := range p.Rules {
+		s.add(r.Summary())
+	}
+	return s
+}
+
+// Summary de todos os packs
+func (p *Profile) Summary() Summary {
+	var s Summary
+	for _, pack := range p.Packs {
+		s.add(pack.Summary())
+	}
+	return s
+}
+
+// NeverFired retorna as regras habilitadas que não dispararam em nenhuma execução
+func (p *Pack) NeverFired() []*Rule {
+	var rules []*Rule
+	for _, r := range p.Rules {
+		if r.Enabled && r.Fired == 0 {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+func covered(n int) int {
+	if n > 0 {
+		return 1
+	}
+	return 0
+}
+
+// Merge soma perfis (ex: de várias suítes ou processos). Packs se correspondem por id e versão,
+// regras por id, ações pela posição e ramos pela localização; itens de um só perfil são mantidos.
+func Merge(profiles ...*Profile) *Profile {
+	out := &Profile{}
+	for _, p := range profiles {
+		if p == nil {
+			continue
+		}
+		for _, pack := range p.Packs {
+			target := out.pack(pack.ID, pack.Version)
+			if target == nil {
+				target = &Pack{ID: pack.ID, Version: pack.Version}
+				out.Packs = append(out.Packs, target)
+			}
+			target.merge(pack)
+		}
+	}
+	out.sort()
+	return out
+}
+
+func (p *Profile) pack(id, version string) *Pack {
+	for _, pack := range p.Packs {
+		if pack.ID == id && pack.Version == version {
+			return pack
+		}
+	}
+	return nil
+}
+
+func (p *Profile) sort() {
+	sort.SliceStable(p.Packs, func(i, j int) bool {
+		if p.Packs[i].ID != p.Packs[j].ID {
+			return p.Packs[i].ID < p.Packs[j].ID
+		}
+		return p.Packs[i].Version < p.Packs[j].Version
+	})
+}
+
+func (p *Pack) merge(o *Pack) {
+	p.Runs += o.Runs
+	for _, rule := range o.Rules {
+		target := p.rule(rule.ID)
+		if target == nil {
+			target = &Rule{ID: rule.ID, Phase: rule.Phase, Enabled: rule.Enabled, HasCondition: rule.HasCondition}
+			p.Rules = append(p.Rules, target)
+		}
+		target.Evaluated += rule.Evaluated
+		target.Fired += rule.Fired
+		for i, a := range rule.Actions {
+			if i == len(target.Actions) {
+				target.Actions = append(target.Actions, &Action{Type: a.Type})
+			}
+			target.Actions[i].Executed += a.Executed
+			target.Actions[i].Violations += a.Violations
+		}
+		for _, b := range rule.Branches {
+			tb := target.branch(b.Location)
+			if tb == nil {
+				tb = &Branch{Location: b.Location}
+				target.Branches = append(target.Branches, tb)
+			}
+			tb.True += b.True
+			tb.False += b.False
+		}
+	}
+}
+
+func (p *Pack) rule(id string) *Rule {
+	for _, r := range p.Rules {
+		if r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+func (r *Rule) branch(location string) *Branch {
+	for _, b := range r.Branches {
+		if b.Location == location {
+			return b
+		}
+	}
+	return nil
+}
+
+// ReadProfile lê um perfil JSON (gravado por WriteJSON)
+func ReadProfile(r io.Reader) (*Profile, error) {
+	var p Profile
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, fmt.Errorf("invalid coverage profile: %w", err)
+	}
+	return &p, nil
+}
+
+// ReadProfileFile lê um perfil JSON de um arquivo
+func ReadProfileFile(path string) (*Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := ReadProfile(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
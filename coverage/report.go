@@ -0,0 +1,113 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"text/tabwriter"
+)
+
+// WriteJSON grava o perfil em JSON (lido de volta por ReadProfile e combinável com Merge)
+func WriteJSON(w io.Writer, p *Profile) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// WriteText grava o relatório em texto: resumo por pack, uma linha por regra (NEVER FIRED para as
+// habilitadas que não dispararam) e, abaixo da regra, os resultados de ramos e as ações não cobertos
+func WriteText(w io.Writer, p *Profile) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, pack := range p.Packs {
+		fmt.Fprintf(tw, "pack %s %s (%d runs): %s\n", pack.ID, pack.Version, pack.Runs, summaryText(pack.Summary()))
+		for _, r := range pack.Rules {
+			status := fmt.Sprintf("fired %d/%d", r.Fired, r.Evaluated)
+			switch {
+			case !r.Enabled:
+				status = "disabled"
+			case r.Fired == 0:
+				status += "\tNEVER FIRED"
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", r.Phase, r.ID, status)
+			if !r.Enabled {
+				continue
+			}
+			for _, gap := range gaps(r) {
+				fmt.Fprintf(tw, "  \t  %s\t%s\n", gap[0], gap[1])
+			}
+		}
+	}
+	fmt.Fprintf(tw, "total: %s\n", summaryText(p.Summary()))
+	return tw.Flush()
+}
+
+func summaryText(s Summary) string {
+	return fmt.Sprintf("%.1f%% covered (rules %d/%d, actions %d/%d, branches %d/%d)",
+		s.Percent(), s.RulesCovered, s.Rules, s.ActionsCovered, s.Actions, s.BranchesCovered, s.Branches)
+}
+
+// gaps lista o que falta cobrir na regra: [localização, contagens]
+func gaps(r *Rule) [][2]string {
+	var out [][2]string
+	if r.HasCondition && (r.Fired == 0 || r.Evaluated == r.Fired) {
+		out = append(out, [2]string{"condition", fmt.Sprintf("true %d, false %d", r.Fired, r.Evaluated-r.Fired)})
+	}
+	for i, a := range r.Actions {
+		if a.Executed == 0 {
+			out = append(out, [2]string{fmt.Sprintf("actions[%d] %s", i, a.Type), "not executed"})
+		}
+	}
+	for _, b := range r.Branches {
+		if b.True == 0 || b.False == 0 {
+			out = append(out, [2]string{b.Location, fmt.Sprintf("true %d, false %d", b.True, b.False)})
+		}
+	}
+	return out
+}
+
+// WriteHTML grava o relatório em uma página HTML, com as regras que nunca dispararam em destaque
+func WriteHTML(w io.Writer, p *Profile) error {
+	return htmlReport.Execute(w, p)
+}
+
+var htmlReport = template.Must(template.New("coverage").Funcs(template.FuncMap{
+	"summary": summaryText,
+	"gaps":    gaps,
+	"percent": func(s Summary) string { return fmt.Sprintf("%.1f%%", s.Percent()) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>RulePack coverage</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { text-align: left; padding: 4px 12px; border-bottom: 1px solid #ddd; vertical-align: top; }
+tr.covered td.status { color: #1a7f37; }
+tr.partial td.status { color: #9a6700; }
+tr.never td { background: #ffebe9; }
+tr.never td.status { color: #cf222e; font-weight: bold; }
+tr.disabled td { color: #888; }
+ul { margin: 0; padding-left: 1.2em; }
+</style>
+</head>
+<body>
+<h1>RulePack coverage: {{percent .Summary}}</h1>
+<p>{{summary .Summary}}</p>
+{{range .Packs}}
+<h2>{{.ID}} {{.Version}} <small>({{.Runs}} runs, {{percent .Summary}})</small></h2>
+<p>{{summary .Summary}}</p>
+<table>
+<tr><th>Phase</th><th>Rule</th><th>Fired</th><th>Status</th><th>Not covered</th></tr>
+{{range .Rules}}{{$gaps := gaps .}}
+<tr class="{{if not .Enabled}}disabled{{else if eq .Fired 0}}never{{else if $gaps}}partial{{else}}covered{{end}}">
+<td>{{.Phase}}</td><td>{{.ID}}</td><td>{{.Fired}}/{{.Evaluated}}</td>
+<td class="status">{{if not .Enabled}}disabled{{else if eq .Fired 0}}never fired{{else if $gaps}}partial{{else}}covered{{end}}</td>
+<td>{{if .Enabled}}{{if $gaps}}<ul>{{range $gaps}}<li>{{index . 0}}: {{index . 1}}</li>{{end}}</ul>{{end}}{{end}}</td>
+</tr>{{end}}
+</table>
+{{end}}
+</body>
+</html>
+`))
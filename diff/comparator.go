@@ -20,7 +20,7 @@ func GetChangedFields(original, current core.State) []string {
 	var changed []string
 
 	// Comparar totals
-	if original.Totals != current.Totals {
+	if !original.Totals.Equal(current.Totals) {
 		changed = append(changed, "totals")
 	}
 
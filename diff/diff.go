@@ -10,7 +10,7 @@ func BuildStateFragment(ctx *core.EngineContext) map[string]interface{} {
 	state := ctx.State
 
 	// Totais sempre expor
-	if state.Totals != (core.Totals{}) {
+	if !state.Totals.IsZero() {
 		fragment["totals"] = state.Totals
 	}
 
@@ -26,6 +26,12 @@ func BuildStateFragment(ctx *core.EngineContext) map[string]interface{} {
 			"id":     item.ID,
 			"fields": item.Fields,
 		}
+		if item.Currency != "" {
+			itemsFragment[i]["currency"] = item.Currency
+		}
+		if item.Unit != "" {
+			itemsFragment[i]["unit"] = item.Unit
+		}
 	}
 	if len(itemsFragment) > 0 {
 		fragment["items"] = itemsFragment
@@ -41,7 +47,7 @@ func BuildServerDelta(ctx *core.EngineContext) map[string]interface{} {
 	current := ctx.State
 
 	// Comparar totais
-	if original.Totals != current.Totals {
+	if !original.Totals.Equal(current.Totals) {
 		delta["totals"] = current.Totals
 	}
 
@@ -3,30 +3,154 @@ package engine
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/dolphin-sistemas/computations-engine/actions"
 	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/coverage"
 	"github.com/dolphin-sistemas/computations-engine/diff"
+	"github.com/dolphin-sistemas/computations-engine/guards"
+	"github.com/dolphin-sistemas/computations-engine/operators"
 	"github.com/dolphin-sistemas/computations-engine/pipeline"
 )
 
+func init() {
+	// Inicializar referência de actions no pipeline (evitar import circular). Feito uma única vez:
+	// RunEngine e RunBatch executam em várias goroutines
+	pipeline.ExecuteActions = actions.ExecuteActions
+	pipeline.GetValue = actions.GetValue
+	pipeline.ValidateSingleCurrency = guards.ValidateSingleCurrency
+}
+
 // RunEngine é a função principal pública do motor de regras
 // Executa o pipeline completo e retorna os resultados
 func RunEngine(ctx context.Context, state core.State, rules core.RulePack, contextMeta core.ContextMeta) (*core.RunEngineResult, error) {
-	// Inicializar referência de actions no pipeline (evitar import circular)
-	pipeline.ExecuteActions = actions.ExecuteActions
+	// Fixar o relógio da execução (operadores de data usam context.now)
+	if contextMeta.Now == "" {
+		contextMeta.Now = time.Now().UTC().Format(time.RFC3339)
+	}
 
-	// Criar contexto do motor
-	engineCtx, err := core.NewEngineContext(state, contextMeta)
+	compiled, err := compileRulePack(rules, contextMeta)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create engine context: %w", err)
+		return nil, err
 	}
+	return compiled.run(ctx, state, contextMeta, nil)
+}
+
+// compiledPack é um RulePack validado e compilado, reaproveitado por todas as execuções de um
+// lote (RunBatch). É somente leitura durante as execuções.
+type compiledPack struct {
+	rules         core.RulePack // com as promoções compiladas em regras
+	promotions    map[string]core.Promotion
+	tables        *core.TableSet
+	units         *core.UnitSet
+	exchangeRates core.ExchangeRates
+	itemRules     map[string]bool // regras que leem projeções de itens (pipeline.ItemProjectionRules)
 
+	projectionExprs map[string]interface{} // expressões das projeções por path (pipeline.CompileProjections)
+}
+
+// compileRulePack valida e compila o RulePack (projeções, promoções, padrões, tabelas, cotações e unidades)
+func compileRulePack(rules core.RulePack, contextMeta core.ContextMeta) (*compiledPack, error) {
 	// Validar RulePack
 	if rules.ID == "" {
 		return nil, fmt.Errorf("rulePack.id is required")
 	}
 
+	// Relógio e fuso da execução (operadores de data)
+	if err := operators.ValidateClock(contextMeta.Now, contextMeta.Timezone); err != nil {
+		return nil, fmt.Errorf("invalid context: %w", err)
+	}
+
+	// Validar agregados declarados (os mesmos critérios do loader)
+	if err := core.ValidateTotalDefs(rules.Totals); err != nil {
+		return nil, fmt.Errorf("invalid rulePack totals: %w", err)
+	}
+
+	// Validar projeções declaradas
+	projectionExprs, err := pipeline.CompileProjections(rules.Projections)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rulePack projections: %w", err)
+	}
+
+	// Compilar promoções em regras (uma por promoção, na fase da promoção)
+	rules, err = pipeline.CompilePromotions(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rulePack promotions: %w", err)
+	}
+	compiled := &compiledPack{
+		rules:      rules,
+		promotions: pipeline.PromotionIndex(rules.Promotions),
+		itemRules:  pipeline.ItemProjectionRules(rules),
+
+		projectionExprs: projectionExprs,
+	}
+
+	// Compilar padrões (regex) usados nas regras
+	if err := pipeline.PrecompileRulePack(rules); err != nil {
+		return nil, fmt.Errorf("invalid rulePack patterns: %w", err)
+	}
+
+	// Compilar (tipar e indexar) as tabelas de consulta
+	compiled.tables, err = core.CompileTables(rules.Tables)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rulePack tables: %w", err)
+	}
+
+	// Cotações (RulePack + ContextMeta)
+	compiled.exchangeRates = core.MergeExchangeRates(rules.ExchangeRates, contextMeta.ExchangeRates)
+	if err := compiled.exchangeRates.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid exchange rates: %w", err)
+	}
+
+	// Unidades de medida do RulePack
+	compiled.units, err = core.CompileUnits(rules.Units)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rulePack units: %w", err)
+	}
+
+	return compiled, nil
+}
+
+// run executa o pipeline para um State; hits (opcional) registra as regras disparadas
+func (p *compiledPack) run(ctx context.Context, state core.State, contextMeta core.ContextMeta, hits *ruleHits) (*core.RunEngineResult, error) {
+	// Criar contexto do motor
+	engineCtx, err := core.NewEngineContext(state, contextMeta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create engine context: %w", err)
+	}
+	engineCtx.Ctx = ctx
+	engineCtx.Projections = p.rules.Projections
+	engineCtx.ProjectionExprs = p.projectionExprs
+	engineCtx.Promotions = p.promotions
+	engineCtx.Tables = p.tables
+	engineCtx.ExchangeRates = p.exchangeRates
+	engineCtx.Units = p.units
+	engineCtx.ItemProjectionRules = p.itemRules
+
+	// Cobertura (quando o contexto tem um coletor): instrumentar uma cópia do RulePack
+	rules := p.rules
+	if collector := coverage.FromContext(ctx); collector != nil {
+		rules, engineCtx.Coverage = collector.Instrument(rules)
+	}
+	if hits != nil {
+		hits.next = engineCtx.Coverage
+		engineCtx.Coverage = hits
+	}
+
+	// Moedas e unidades do estado
+	if err := core.ValidateCurrencies(state); err != nil {
+		return nil, fmt.Errorf("invalid currency: %w", err)
+	}
+	if err := core.ValidateItemUnits(state, p.units); err != nil {
+		return nil, fmt.Errorf("invalid unit: %w", err)
+	}
+
+	// Declarar agregados nomeados do RulePack (freight, insurance, ...) também no Original, para
+	// que os declarados (e o arredondamento dos recebidos) não apareçam como mudança no delta
+	engineCtx.State.Totals.Declare(rules.Totals)
+	engineCtx.Original.Totals.Declare(rules.Totals)
+
 	// Executar pipeline
 	if err := pipeline.RunPipeline(engineCtx, rules); err != nil {
 		return nil, fmt.Errorf("pipeline execution failed: %w", err)
@@ -39,5 +163,6 @@ func RunEngine(ctx context.Context, state core.State, rules core.RulePack, conte
 		Reasons:       engineCtx.Reasons,
 		Violations:    engineCtx.Violations,
 		RulesVersion:  rules.Version,
+		Promotions:    engineCtx.AppliedPromotions,
 	}, nil
 }
@@ -0,0 +1,50 @@
+package engine_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	engine "github.com/dolphin-sistemas/computations-engine"
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/internal/benchdata"
+)
+
+// BenchmarkRunEngine executa o pipeline completo para cada combinação de tamanho de pedido e de RulePack
+func BenchmarkRunEngine(b *testing.B) {
+	for _, items := range benchdata.ItemCounts {
+		state := benchdata.Order(items)
+		for _, rules := range benchdata.RuleCounts {
+			pack := benchdata.Pack(rules)
+			b.Run(fmt.Sprintf("items=%d/rules=%d", items, rules), func(b *testing.B) {
+				benchdata.SkipLarge(b, items, rules)
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := engine.RunEngine(context.Background(), state, pack, benchdata.Context); err != nil {
+						b.Fatalf("RunEngine failed: %v", err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkRunBatch avalia 100 pedidos de 10 itens por operação com o pool de workers padrão
+func BenchmarkRunBatch(b *testing.B) {
+	pack := benchdata.Pack(8)
+	state := benchdata.Order(10)
+	states := func(yield func(core.State) bool) {
+		for i := 0; i < 100; i++ {
+			if !yield(state) {
+				return
+			}
+		}
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		stats, err := engine.RunBatch(context.Background(), states, pack, engine.BatchOptions{Context: benchdata.Context})
+		if err != nil || stats.Failed > 0 {
+			b.Fatalf("RunBatch failed: %v (%d failed)", err, stats.Failed)
+		}
+	}
+}
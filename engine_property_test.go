@@ -0,0 +1,174 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	engine "github.com/dolphin-sistemas/computations-engine"
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/pipeline"
+	"github.com/dolphin-sistemas/computations-engine/vectors"
+)
+
+// propertyPack altera itens, negociações aninhadas, campos e totais (tudo o que poderia vazar para a entrada)
+const propertyPack = `{
+  "id": "property-pack",
+  "version": "v1",
+  "phases": [
+    {
+      "name": "baseline",
+      "rules": [
+        {
+          "id": "negotiations", "phase": "baseline", "priority": 1, "enabled": true,
+          "actions": [
+            { "type": "compute", "target": "items[*].negotiations[*].amount",
+              "logic": { "*": [ { "var": ["price", 0] }, { "/": [ { "var": ["percent", 0] }, 100 ] } ] } }
+          ]
+        },
+        {
+          "id": "lines", "phase": "baseline", "priority": 2, "enabled": true,
+          "actions": [
+            { "type": "compute", "target": "items[*].total", "logic": { "*": [ { "var": "amount" }, { "var": ["price", 0] } ] } },
+            { "type": "set", "target": "items[*].tags", "value": ["priced"] }
+          ]
+        },
+        {
+          "id": "totals", "phase": "baseline", "priority": 3, "enabled": true,
+          "actions": [
+            { "type": "compute", "target": "totals.total", "logic": { "sum": [ { "var": "items" }, "total" ] } },
+            { "type": "set", "target": "fields.customer.checked", "value": true },
+            { "type": "set", "target": "fields.discount", "value": 5 }
+          ]
+        },
+        {
+          "id": "limit", "phase": "baseline", "priority": 4, "enabled": true,
+          "condition": { ">": [ { "var": "totals.total" }, 5000 ] },
+          "actions": [ { "type": "validate", "logic": { "==": [1, 1] }, "message": "total above limit" } ]
+        }
+      ]
+    }
+  ]
+}`
+
+var propertyContext = core.ContextMeta{TenantID: "t1", UserID: "u1", Locale: "pt-BR", Now: "2025-01-15T12:00:00Z"}
+
+func loadPropertyPack(t *testing.T) core.RulePack {
+	t.Helper()
+	var pack core.RulePack
+	if err := json.Unmarshal([]byte(propertyPack), &pack); err != nil {
+		t.Fatalf("failed to unmarshal pack: %v", err)
+	}
+	return pack
+}
+
+// randomState gera um pedido com itens, negociações aninhadas e campos de cliente
+func randomState(rng *rand.Rand) core.State {
+	items := make([]core.Item, rng.Intn(8))
+	for i := range items {
+		negotiations := make([]interface{}, rng.Intn(3))
+		for j := range negotiations {
+			negotiations[j] = map[string]interface{}{"percent": float64(rng.Intn(30))}
+		}
+		items[i] = core.Item{
+			ID:     fmt.Sprintf("item-%d", i),
+			Amount: float64(1 + rng.Intn(10)),
+			Fields: map[string]interface{}{"price": float64(rng.Intn(100000)) / 100, "negotiations": negotiations},
+		}
+	}
+	return core.State{
+		TenantID: "t1",
+		Items:    items,
+		Fields:   map[string]interface{}{"customer": map[string]interface{}{"uf": "SP", "limits": []interface{}{1000.0}}},
+		Totals:   core.Totals{},
+	}
+}
+
+func marshal(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	return string(data)
+}
+
+// TestRunEngine_Deterministic: o mesmo vector (com relógio fixo) produz sempre o mesmo resultado
+func TestRunEngine_Deterministic(t *testing.T) {
+	files, err := vectors.Files("testdata/vectors")
+	if err != nil {
+		t.Fatalf("failed to list vectors: %v", err)
+	}
+	for _, file := range files {
+		v, err := vectors.Load(file)
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", file, err)
+		}
+		if v.Input.Context.Now == "" {
+			v.Input.Context.Now = propertyContext.Now
+		}
+
+		first, err := engine.RunEngine(context.Background(), v.Input.Order, v.Input.RulePack, v.Input.Context)
+		if err != nil {
+			t.Fatalf("%s: RunEngine failed: %v", file, err)
+		}
+		for run := 0; run < 3; run++ {
+			again, err := engine.RunEngine(context.Background(), v.Input.Order, v.Input.RulePack, v.Input.Context)
+			if err != nil {
+				t.Fatalf("%s: RunEngine failed: %v", file, err)
+			}
+			if marshal(t, again) != marshal(t, first) {
+				t.Fatalf("%s: run %d differs:\n%s\n%s", file, run+2, marshal(t, first), marshal(t, again))
+			}
+		}
+	}
+}
+
+// TestRunEngine_DoesNotMutateInput: mapas e slices do State recebido ficam intactos após a execução
+func TestRunEngine_DoesNotMutateInput(t *testing.T) {
+	pack := loadPropertyPack(t)
+	rng := rand.New(rand.NewSource(47))
+
+	for i := 0; i < 200; i++ {
+		state := randomState(rng)
+		before := marshal(t, state)
+		packBefore := marshal(t, pack)
+
+		if _, err := engine.RunEngine(context.Background(), state, pack, propertyContext); err != nil {
+			t.Fatalf("RunEngine failed: %v", err)
+		}
+		if after := marshal(t, state); after != before {
+			t.Fatalf("input state was modified:\n%s\n%s", before, after)
+		}
+		if after := marshal(t, pack); after != packBefore {
+			t.Fatalf("rule pack was modified:\n%s\n%s", packBefore, after)
+		}
+	}
+}
+
+// TestEngineContext_OriginalUnchanged: o pipeline altera apenas State; Original (base dos deltas) não muda
+func TestEngineContext_OriginalUnchanged(t *testing.T) {
+	pack := loadPropertyPack(t)
+	rng := rand.New(rand.NewSource(48))
+
+	for i := 0; i < 100; i++ {
+		state := randomState(rng)
+		before := marshal(t, state)
+
+		ctx, err := core.NewEngineContext(state, propertyContext)
+		if err != nil {
+			t.Fatalf("NewEngineContext failed: %v", err)
+		}
+		if err := pipeline.RunPipeline(ctx, pack); err != nil {
+			t.Fatalf("RunPipeline failed: %v", err)
+		}
+		if original := marshal(t, ctx.Original); original != before {
+			t.Fatalf("Original was modified:\n%s\n%s", before, original)
+		}
+		if len(state.Items) > 0 && marshal(t, ctx.State) == before {
+			t.Fatalf("pipeline did not change the working state")
+		}
+	}
+}
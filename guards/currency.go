@@ -0,0 +1,39 @@
+package guards
+
+import (
+	"fmt"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+// IsCurrency indica se o código é uma moeda ISO-4217 ativa
+var IsCurrency = pkg.IsCurrency
+
+// ValidateCurrencyCode valida um código de moeda ISO-4217
+func ValidateCurrencyCode(code string, fieldName string) error {
+	if !pkg.IsCurrency(code) {
+		return fmt.Errorf("%s: unknown currency %q", fieldName, code)
+	}
+	return nil
+}
+
+// ValidateSingleCurrency valida que todos os itens estão na moeda do estado, para
+// fluxos em que valores de itens são somados sem conversão (ver ação convertCurrency)
+func ValidateSingleCurrency(state *core.State) error {
+	for i, item := range state.Items {
+		if currency := state.ItemCurrency(i); currency != state.Currency {
+			return fmt.Errorf("item %s: currency %s differs from state currency %q", item.ID, currency, state.Currency)
+		}
+	}
+	return nil
+}
+
+// ValidateSameCurrency valida que duas moedas podem ser combinadas em uma operação
+// (moeda vazia = sem moeda declarada, compatível com qualquer outra)
+func ValidateSameCurrency(a, b string) error {
+	if a != "" && b != "" && a != b {
+		return fmt.Errorf("cannot mix currencies %s and %s (convert the values first)", a, b)
+	}
+	return nil
+}
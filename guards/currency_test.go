@@ -0,0 +1,31 @@
+package guards
+
+import (
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+func TestCurrencyGuards(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{"valid code", ValidateCurrencyCode("BRL", "currency"), false},
+		{"unknown code", ValidateCurrencyCode("XYZ", "currency"), true},
+		{"lowercase code", ValidateCurrencyCode("usd", "currency"), true},
+		{"same currency", ValidateSameCurrency("USD", "USD"), false},
+		{"undeclared currency", ValidateSameCurrency("", "USD"), false},
+		{"mixed currencies", ValidateSameCurrency("BRL", "USD"), true},
+		{"items inherit state currency", ValidateSingleCurrency(&core.State{Currency: "BRL", Items: []core.Item{{ID: "a"}, {ID: "b", Currency: "BRL"}}}), false},
+		{"item in another currency", ValidateSingleCurrency(&core.State{Currency: "BRL", Items: []core.Item{{ID: "a", Currency: "USD"}}}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if (tt.err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", tt.err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package guards
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+// Tipos de documento aceitos por ValidateDocument
+const (
+	DocumentCPF  = "cpf"
+	DocumentCNPJ = "cnpj"
+	DocumentCEP  = "cep"
+	DocumentGTIN = "gtin"
+	DocumentNCM  = "ncm"
+	DocumentCFOP = "cfop"
+)
+
+// Validadores de documentos brasileiros (os mesmos usados pelos operadores isCPF, isCNPJ, ...)
+var (
+	IsCPF  = pkg.IsCPF
+	IsCNPJ = pkg.IsCNPJ
+	IsCEP  = pkg.IsCEP
+	IsGTIN = pkg.IsGTIN
+	IsNCM  = pkg.IsNCM
+	IsCFOP = pkg.IsCFOP
+	IsIE   = pkg.IsIE
+)
+
+var documentValidators = map[string]func(string) bool{
+	DocumentCPF:  pkg.IsCPF,
+	DocumentCNPJ: pkg.IsCNPJ,
+	DocumentCEP:  pkg.IsCEP,
+	DocumentGTIN: pkg.IsGTIN,
+	DocumentNCM:  pkg.IsNCM,
+	DocumentCFOP: pkg.IsCFOP,
+}
+
+// ValidateDocument valida um documento do tipo informado (cpf, cnpj, cep, gtin, ncm, cfop)
+func ValidateDocument(kind, value, fieldName string) error {
+	validate, ok := documentValidators[strings.ToLower(kind)]
+	if !ok {
+		return fmt.Errorf("unknown document type %q", kind)
+	}
+	if !validate(value) {
+		return fmt.Errorf("%s is not a valid %s", fieldName, strings.ToUpper(kind))
+	}
+	return nil
+}
+
+// ValidateIE valida uma inscrição estadual conforme a UF
+func ValidateIE(uf, value, fieldName string) error {
+	if !pkg.IsUF(uf) {
+		return fmt.Errorf("unknown UF %q", uf)
+	}
+	if !pkg.IsIE(uf, value) {
+		return fmt.Errorf("%s is not a valid IE for %s", fieldName, strings.ToUpper(uf))
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package guards
+
+import "testing"
+
+func TestDocumentValidators(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(string) bool
+		value    string
+		want     bool
+	}{
+		{"cpf formatted", IsCPF, "529.982.247-25", true},
+		{"cpf digits", IsCPF, "52998224725", true},
+		{"cpf wrong digit", IsCPF, "529.982.247-24", false},
+		{"cpf repeated digits", IsCPF, "111.111.111-11", false},
+		{"cpf short", IsCPF, "5299822472", false},
+		{"cpf letters", IsCPF, "529.982.247-2A", false},
+
+		{"cnpj formatted", IsCNPJ, "11.222.333/0001-81", true},
+		{"cnpj digits", IsCNPJ, "11222333000181", true},
+		{"cnpj wrong digit", IsCNPJ, "11.222.333/0001-80", false},
+		{"cnpj repeated digits", IsCNPJ, "00.000.000/0000-00", false},
+		{"cnpj alphanumeric", IsCNPJ, "12.ABC.345/01DE-35", true},
+		{"cnpj alphanumeric lowercase", IsCNPJ, "12abc34501de35", true},
+		{"cnpj alphanumeric wrong digit", IsCNPJ, "12.ABC.345/01DE-36", false},
+		{"cnpj letter in check digits", IsCNPJ, "12.ABC.345/01DE-3A", false},
+
+		{"cep formatted", IsCEP, "01310-100", true},
+		{"cep digits", IsCEP, "01310100", true},
+		{"cep zeros", IsCEP, "00000-000", false},
+		{"cep short", IsCEP, "1310-100", false},
+
+		{"gtin-13", IsGTIN, "4006381333931", true},
+		{"gtin-13 wrong digit", IsGTIN, "4006381333932", false},
+		{"gtin-12", IsGTIN, "036000291452", true},
+		{"gtin-8", IsGTIN, "96385074", true},
+		{"gtin-14", IsGTIN, "14006381333938", true},
+		{"gtin invalid length", IsGTIN, "40063813339", false},
+
+		{"ncm formatted", IsNCM, "8471.30.12", true},
+		{"ncm digits", IsNCM, "22021000", true},
+		{"ncm chapter 00", IsNCM, "0012.34.56", false},
+		{"ncm chapter 77", IsNCM, "7701.00.00", false},
+		{"ncm chapter 98", IsNCM, "9801.00.00", false},
+		{"ncm short", IsNCM, "8471.30", false},
+
+		{"cfop formatted", IsCFOP, "5.102", true},
+		{"cfop entry", IsCFOP, "1102", true},
+		{"cfop invalid group", IsCFOP, "4.102", false},
+		{"cfop short", IsCFOP, "510", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.validate(tt.value); got != tt.want {
+				t.Errorf("%q: got %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsIE(t *testing.T) {
+	tests := []struct {
+		uf    string
+		value string
+		want  bool
+	}{
+		{"AC", "01.004.823/001-12", true},
+		{"AC", "01.004.823/001-13", false},
+		{"AL", "240000048", true},
+		{"AL", "240000049", false},
+		{"AP", "030123459", true},
+		{"AP", "030123458", false},
+		{"AM", "999999990", true},
+		{"AM", "999999991", false},
+		{"BA", "123456-63", true},
+		{"BA", "1000003-06", true},
+		{"BA", "123456-64", false},
+		{"CE", "06000001-5", true},
+		{"CE", "06000001-6", false},
+		{"DF", "07300001001-09", true},
+		{"DF", "07300001001-08", false},
+		{"ES", "99999999-0", true},
+		{"ES", "99999999-1", false},
+		{"GO", "10.987.654-7", true},
+		{"GO", "10.987.654-8", false},
+		{"MA", "12000038-5", true},
+		{"MA", "12000038-6", false},
+		{"MT", "0013000001-9", true},
+		{"MT", "0013000001-8", false},
+		{"MS", "28.310.712-0", true},
+		{"MS", "28.310.712-6", false},
+		{"MG", "062.307.904/0081", true},
+		{"MG", "062.307.904/0082", false},
+		{"PA", "15-999999-5", true},
+		{"PA", "15-999999-4", false},
+		{"PB", "06000001-5", true},
+		{"PB", "06000001-4", false},
+		{"PR", "123.45678-50", true},
+		{"PR", "123.45678-51", false},
+		{"PE", "0321418-40", true},
+		{"PE", "18.1.001.0000004-9", true},
+		{"PE", "0321418-41", false},
+		{"PI", "012345679", true},
+		{"PI", "012345678", false},
+		{"RJ", "99.999.99-3", true},
+		{"RJ", "99.999.99-4", false},
+		{"RN", "20.040.040-1", true},
+		{"RN", "20.0.040.040-0", true},
+		{"RN", "20.040.040-2", false},
+		{"RS", "224/3658792", true},
+		{"RS", "224/3658793", false},
+		{"RO", "0000000062521-3", true},
+		{"RO", "101.62521-3", true},
+		{"RO", "0000000062521-4", false},
+		{"RR", "24006628-1", true},
+		{"RR", "24006628-2", false},
+		{"SC", "251.040.852", true},
+		{"SC", "251.040.853", false},
+		{"SP", "110.042.490.114", true},
+		{"SP", "110.042.490.115", false},
+		{"SP", "P-01100424.3/002", true},
+		{"SP", "P-01100424.4/002", false},
+		{"SE", "27123456-3", true},
+		{"SE", "27123456-4", false},
+		{"TO", "29.01.022783-6", true},
+		{"TO", "29.01.022783-7", false},
+		{"sp", "ISENTO", true},
+		{"XX", "ISENTO", false},
+		{"SP", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uf+"_"+tt.value, func(t *testing.T) {
+			if got := IsIE(tt.uf, tt.value); got != tt.want {
+				t.Errorf("IsIE(%q, %q): got %v, want %v", tt.uf, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	if err := ValidateDocument(DocumentCPF, "529.982.247-25", "customer.cpf"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateDocument(DocumentCNPJ, "11.222.333/0001-80", "customer.cnpj"); err == nil {
+		t.Error("expected error for invalid CNPJ")
+	}
+	if err := ValidateDocument("passport", "X123", "customer.passport"); err == nil {
+		t.Error("expected error for unknown document type")
+	}
+	if err := ValidateIE("SP", "110.042.490.114", "customer.ie"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateIE("ZZ", "110.042.490.114", "customer.ie"); err == nil {
+		t.Error("expected error for unknown UF")
+	}
+}
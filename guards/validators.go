@@ -2,9 +2,11 @@ package guards
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dolphin-sistemas/computations-engine/core"
 	"github.com/dolphin-sistemas/computations-engine/operators"
+	"github.com/dolphin-sistemas/computations-engine/pipeline"
 )
 
 // ValidateMaxRatio valida se uma razão não excede um máximo (genérico)
@@ -59,17 +61,8 @@ func ValidateRequiredIDs(state *core.State, requiredIDs []string, idField string
 // getNumericValue obtém valor numérico de um campo do estado
 func getNumericValue(state *core.State, fieldPath string) (float64, bool) {
 	// Tentar totals primeiro
-	if fieldPath == "totals.discount" {
-		return state.Totals.Discount, true
-	}
-	if fieldPath == "totals.subtotal" {
-		return state.Totals.Subtotal, true
-	}
-	if fieldPath == "totals.tax" {
-		return state.Totals.Tax, true
-	}
-	if fieldPath == "totals.total" {
-		return state.Totals.Total, true
+	if name, ok := strings.CutPrefix(fieldPath, "totals."); ok {
+		return state.Totals.Get(name)
 	}
 
 	// Tentar fields
@@ -107,8 +100,11 @@ func ValidateMaxValue(value float64, maxValue float64, fieldName string) error {
 
 // EvaluateGuardCondition avalia uma condição de guard usando JsonLogic
 func EvaluateGuardCondition(logic map[string]interface{}, ctx *core.EngineContext) (bool, error) {
-	// Usar BuildEvaluationData do pipeline
-	evalData := buildEvaluationDataForGuard(ctx)
+	// Mesmos dados de avaliação das regras do pipeline
+	evalData, err := pipeline.EvaluationData(ctx)
+	if err != nil {
+		return false, err
+	}
 	result, err := operators.EvaluateJsonLogic(logic, evalData)
 	if err != nil {
 		return false, err
@@ -121,47 +117,3 @@ func EvaluateGuardCondition(logic map[string]interface{}, ctx *core.EngineContex
 	// Truthy check
 	return result != nil && result != false && result != 0 && result != "", nil
 }
-
-// buildEvaluationDataForGuard monta contexto de dados para guards (similar ao pipeline)
-func buildEvaluationDataForGuard(ctx *core.EngineContext) map[string]interface{} {
-	data := make(map[string]interface{})
-	state := ctx.State
-
-	// Context
-	data["context"] = map[string]interface{}{
-		"tenantId": ctx.Context.TenantID,
-		"userId":   ctx.Context.UserID,
-		"locale":   ctx.Context.Locale,
-	}
-
-	// Fields do estado
-	for k, v := range state.Fields {
-		data[k] = v
-	}
-
-	// Totals
-	if state.Totals != (core.Totals{}) {
-		data["totals"] = map[string]interface{}{
-			"subtotal": state.Totals.Subtotal,
-			"discount": state.Totals.Discount,
-			"tax":      state.Totals.Tax,
-			"total":    state.Totals.Total,
-		}
-	}
-
-	// Items
-	itemsData := make([]map[string]interface{}, len(state.Items))
-	for i, item := range state.Items {
-		itemData := map[string]interface{}{
-			"id":     item.ID,
-			"amount": item.Amount,
-		}
-		for k, v := range item.Fields {
-			itemData[k] = v
-		}
-		itemsData[i] = itemData
-	}
-	data["items"] = itemsData
-
-	return data
-}
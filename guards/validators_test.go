@@ -0,0 +1,35 @@
+package guards
+
+import (
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+// TestEvaluateGuardCondition: a condição lê os mesmos dados das regras do pipeline
+// (itens normalizados, projeções padrão, totais declarados)
+func TestEvaluateGuardCondition(t *testing.T) {
+	ctx, err := core.NewEngineContext(core.State{
+		Items: []core.Item{
+			{ID: "a", Amount: 2, Fields: map[string]interface{}{"value": 10}},
+			{ID: "b", Amount: 1, Fields: map[string]interface{}{"value": 5}},
+		},
+	}, core.ContextMeta{TenantID: "test-tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.State.Totals.Declare([]core.TotalDef{{Name: "freight"}})
+
+	ok, err := EvaluateGuardCondition(map[string]interface{}{
+		"and": []interface{}{
+			map[string]interface{}{"==": []interface{}{map[string]interface{}{"sum": []interface{}{map[string]interface{}{"var": "itemValues"}}}, 15.0}},
+			map[string]interface{}{"==": []interface{}{map[string]interface{}{"var": "totals.freight"}, 0.0}},
+		},
+	}, ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("condition = false, want true")
+	}
+}
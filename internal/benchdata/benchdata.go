@@ -0,0 +1,141 @@
+// Package benchdata gera pedidos e RulePacks sintéticos, de tamanho configurável, para os benchmarks
+package benchdata
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+// ItemCounts são os tamanhos de pedido usados nos benchmarks
+var ItemCounts = []int{10, 100, 1000, 10000}
+
+// RuleCounts são os tamanhos de RulePack usados nos benchmarks
+var RuleCounts = []int{8, 32, 128}
+
+// Budget é o custo máximo (itens × regras) executado por padrão: acima disso uma operação leva
+// dezenas de segundos (items=10000/rules=128). As combinações maiores só rodam com ENGINE_BENCH_LARGE=1
+const Budget = 400_000
+
+// SkipLarge pula o benchmark quando o custo passa de Budget e ENGINE_BENCH_LARGE não está ligado
+func SkipLarge(tb testing.TB, items, rules int) {
+	tb.Helper()
+	if items*rules > Budget && os.Getenv("ENGINE_BENCH_LARGE") == "" {
+		tb.Skipf("items=%d rules=%d exceeds the default budget (set ENGINE_BENCH_LARGE=1)", items, rules)
+	}
+}
+
+// Context é o ContextMeta dos benchmarks (relógio fixo)
+var Context = core.ContextMeta{TenantID: "bench", UserID: "bench", Locale: "pt-BR", Now: "2025-01-15T12:00:00Z"}
+
+// Order gera um pedido com n itens (preço, categoria e de 0 a 3 negociações cada), sempre o mesmo para o mesmo n
+func Order(n int) core.State {
+	rng := rand.New(rand.NewSource(int64(n)))
+	items := make([]core.Item, n)
+	for i := range items {
+		negotiations := make([]interface{}, rng.Intn(4))
+		for j := range negotiations {
+			negotiations[j] = map[string]interface{}{"percent": float64(1 + rng.Intn(15))}
+		}
+		items[i] = core.Item{
+			ID:     fmt.Sprintf("item-%d", i),
+			Amount: float64(1 + rng.Intn(20)),
+			Fields: map[string]interface{}{
+				"price":        float64(100+rng.Intn(100000)) / 100,
+				"category":     fmt.Sprintf("cat-%d", rng.Intn(8)),
+				"negotiations": negotiations,
+			},
+		}
+	}
+	return core.State{
+		TenantID: "bench",
+		Items:    items,
+		Fields: map[string]interface{}{
+			"customer": map[string]interface{}{"uf": "SP", "segment": "retail", "limit": 500000.0},
+			"channel":  "store",
+		},
+		Totals: core.Totals{},
+	}
+}
+
+// Pack gera um RulePack com n regras (n >= 4): as quatro regras básicas de um pedido (negociações,
+// total do item, total do pedido e limite de crédito) e regras extras alternando cálculo por item,
+// flags condicionais e métricas do pedido
+func Pack(n int) core.RulePack {
+	rules := []core.Rule{
+		rule("negotiations", "baseline", 1, nil, core.Action{
+			Type: "compute", Target: "items[*].negotiations[*].amount",
+			Logic: mul(v("price", 0), div(v("percent", 0), 100.0)),
+		}),
+		rule("item-total", "baseline", 2, nil, core.Action{
+			Type: "compute", Target: "items[*].itemTotal",
+			Logic: mul(v("amount", 0), v("price", 0)),
+		}),
+		rule("order-total", "totals", 1, nil, core.Action{
+			Type: "compute", Target: "totals.total",
+			Logic: map[string]interface{}{"sum": []interface{}{v("itemTotals", nil)}},
+		}),
+		rule("credit-limit", "guards", 1, nil, core.Action{
+			Type:   "validate",
+			Logic:  map[string]interface{}{">": []interface{}{v("totals.total", 0), v("customer.limit", 0)}},
+			Params: map[string]interface{}{"field": "totals.total", "code": "CREDIT_LIMIT", "message": "total above credit limit"},
+		}),
+	}
+
+	for i := len(rules); i < n; i++ {
+		id := fmt.Sprintf("extra-%d", i)
+		switch i % 3 {
+		case 0:
+			rules = append(rules, rule(id, "allocation", i, nil, core.Action{
+				Type: "compute", Target: fmt.Sprintf("items[*].adjusted%d", i),
+				Logic: mul(v("price", 0), 1-float64(i%10)/100),
+			}))
+		case 1:
+			condition := map[string]interface{}{"==": []interface{}{v("channel", ""), "store"}}
+			rules = append(rules, rule(id, "allocation", i, condition, core.Action{
+				Type: "set", Target: fmt.Sprintf("fields.flag%d", i), Value: true,
+			}))
+		default:
+			rules = append(rules, rule(id, "totals", i, nil, core.Action{
+				Type: "compute", Target: fmt.Sprintf("fields.metric%d", i),
+				Logic: mul(v("totals.total", 0), float64(i)/1000),
+			}))
+		}
+	}
+
+	phases := make([]core.RulePhase, 0, len(rules))
+	byPhase := make(map[string]int)
+	for _, r := range rules {
+		idx, ok := byPhase[r.Phase]
+		if !ok {
+			idx = len(phases)
+			byPhase[r.Phase] = idx
+			phases = append(phases, core.RulePhase{Name: r.Phase})
+		}
+		phases[idx].Rules = append(phases[idx].Rules, r)
+	}
+
+	return core.RulePack{ID: fmt.Sprintf("bench-%d", n), Version: "v1", Phases: phases}
+}
+
+func rule(id, phase string, priority int, condition map[string]interface{}, action core.Action) core.Rule {
+	return core.Rule{ID: id, Phase: phase, Priority: priority, Enabled: true, Condition: condition, Actions: []core.Action{action}}
+}
+
+func v(name string, def interface{}) map[string]interface{} {
+	if def == nil {
+		return map[string]interface{}{"var": name}
+	}
+	return map[string]interface{}{"var": []interface{}{name, def}}
+}
+
+func mul(a, b interface{}) map[string]interface{} {
+	return map[string]interface{}{"*": []interface{}{a, b}}
+}
+
+func div(a, b interface{}) map[string]interface{} {
+	return map[string]interface{}{"/": []interface{}{a, b}}
+}
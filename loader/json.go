@@ -5,14 +5,33 @@ import (
 	"fmt"
 
 	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/pipeline"
 )
 
 // LoadRulePackFromJSON carrega um RulePack de dados JSON
+// Os dados não têm diretório: tabelas com source são rejeitadas (ver LoadRulePackFromFile)
 func LoadRulePackFromJSON(data []byte) (core.RulePack, error) {
+	return loadRulePackFromJSON(data, "")
+}
+
+func loadRulePackFromJSON(data []byte, baseDir string) (core.RulePack, error) {
 	var rulePack core.RulePack
 	if err := json.Unmarshal(data, &rulePack); err != nil {
 		return core.RulePack{}, fmt.Errorf("failed to unmarshal JSON: %w", err)
 	}
+	return finishRulePack(rulePack, baseDir)
+}
+
+// finishRulePack carrega as tabelas externas relativas a baseDir e valida o RulePack.
+// baseDir vazio indica um RulePack em memória, que não pode ler arquivos.
+func finishRulePack(rulePack core.RulePack, baseDir string) (core.RulePack, error) {
+	if baseDir == "" {
+		if err := RejectTableSources(rulePack); err != nil {
+			return core.RulePack{}, err
+		}
+	} else if err := LoadTableSources(&rulePack, baseDir); err != nil {
+		return core.RulePack{}, err
+	}
 
 	// Validar RulePack
 	if err := validateRulePack(rulePack); err != nil {
@@ -22,6 +41,18 @@ func LoadRulePackFromJSON(data []byte) (core.RulePack, error) {
 	return rulePack, nil
 }
 
+// ValidateRulePack valida um RulePack recebido em memória (ex: por uma API), sem acessar
+// arquivos: tabelas com source são rejeitadas
+func ValidateRulePack(rulePack core.RulePack) error {
+	if err := RejectTableSources(rulePack); err != nil {
+		return err
+	}
+	if err := validateRulePack(rulePack); err != nil {
+		return fmt.Errorf("invalid rule pack: %w", err)
+	}
+	return nil
+}
+
 // validateRulePack valida um RulePack
 func validateRulePack(rulePack core.RulePack) error {
 	if rulePack.ID == "" {
@@ -30,5 +61,26 @@ func validateRulePack(rulePack core.RulePack) error {
 	if rulePack.Version == "" {
 		return fmt.Errorf("rulePack.version is required")
 	}
+	if err := core.ValidateTotalDefs(rulePack.Totals); err != nil {
+		return err
+	}
+	if err := pipeline.ValidateProjections(rulePack.Projections); err != nil {
+		return fmt.Errorf("rulePack.projections: %w", err)
+	}
+	if err := pipeline.ValidatePromotions(rulePack.Promotions); err != nil {
+		return fmt.Errorf("rulePack.promotions: %w", err)
+	}
+	if err := pipeline.PrecompileRulePack(rulePack); err != nil {
+		return err
+	}
+	if _, err := core.CompileTables(rulePack.Tables); err != nil {
+		return fmt.Errorf("rulePack.tables: %w", err)
+	}
+	if err := rulePack.ExchangeRates.Validate(); err != nil {
+		return fmt.Errorf("rulePack.exchangeRates: %w", err)
+	}
+	if _, err := core.CompileUnits(rulePack.Units); err != nil {
+		return fmt.Errorf("rulePack.units: %w", err)
+	}
 	return nil
 }
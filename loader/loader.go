@@ -10,6 +10,7 @@ import (
 )
 
 // LoadRulePackFromFile carrega um RulePack de um arquivo (JSON ou YAML)
+// Tabelas com source são lidas relativas ao diretório do arquivo
 func LoadRulePackFromFile(path string) (core.RulePack, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -19,9 +20,9 @@ func LoadRulePackFromFile(path string) (core.RulePack, error) {
 	ext := strings.ToLower(filepath.Ext(path))
 	switch ext {
 	case ".json":
-		return LoadRulePackFromJSON(data)
+		return loadRulePackFromJSON(data, filepath.Dir(path))
 	case ".yaml", ".yml":
-		return LoadRulePackFromYAML(data)
+		return loadRulePackFromYAML(data, filepath.Dir(path))
 	default:
 		return core.RulePack{}, fmt.Errorf("unsupported file format: %s (use .json or .yaml)", ext)
 	}
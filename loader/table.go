@@ -0,0 +1,111 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+// RejectTableSources falha se alguma tabela usa source: RulePacks recebidos em memória
+// (ex: de uma requisição) não podem ler arquivos locais
+func RejectTableSources(rulePack core.RulePack) error {
+	names := make([]string, 0, len(rulePack.Tables))
+	for name, table := range rulePack.Tables {
+		if table.Source != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	return fmt.Errorf("rulePack.tables.%s: source is only supported when loading the rule pack from a file", names[0])
+}
+
+// LoadTableSources lê as linhas das tabelas com source (CSV ou JSON) relativas a baseDir.
+// O source precisa ser um caminho local (sem "..", nem absoluto); linhas inline e source
+// são mutuamente exclusivos.
+func LoadTableSources(rulePack *core.RulePack, baseDir string) error {
+	names := make([]string, 0, len(rulePack.Tables))
+	for name, table := range rulePack.Tables {
+		if table.Source != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		table := rulePack.Tables[name]
+		if len(table.Rows) > 0 {
+			return fmt.Errorf("rulePack.tables.%s: use either rows or source, not both", name)
+		}
+		if !filepath.IsLocal(table.Source) {
+			return fmt.Errorf("rulePack.tables.%s: source must be a relative path inside the rule pack directory", name)
+		}
+		rows, err := LoadTableRows(filepath.Join(baseDir, table.Source))
+		if err != nil {
+			return fmt.Errorf("rulePack.tables.%s: %w", name, err)
+		}
+		table.Rows = rows
+		rulePack.Tables[name] = table
+	}
+	return nil
+}
+
+// LoadTableRows lê as linhas de uma tabela de um arquivo CSV (cabeçalho na primeira linha)
+// ou JSON (array de objetos)
+func LoadTableRows(path string) ([]map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table: %w", err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".csv":
+		return parseCSVRows(data)
+	case ".json":
+		var rows []map[string]interface{}
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal table JSON: %w", err)
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("unsupported table format: %s (use .csv or .json)", ext)
+	}
+}
+
+// parseCSVRows converte um CSV em linhas; os valores ficam como texto e são
+// convertidos para os tipos das colunas na compilação da tabela
+func parseCSVRows(data []byte) ([]map[string]interface{}, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse table CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("table CSV is empty")
+	}
+
+	header := records[0]
+	for i, h := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+	}
+	rows := make([]map[string]interface{}, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]interface{}, len(header))
+		for i, h := range header {
+			row[h] = strings.TrimSpace(record[i])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -9,16 +9,15 @@ import (
 )
 
 // LoadRulePackFromYAML carrega um RulePack de dados YAML
+// Os dados não têm diretório: tabelas com source são rejeitadas (ver LoadRulePackFromFile)
 func LoadRulePackFromYAML(data []byte) (core.RulePack, error) {
+	return loadRulePackFromYAML(data, "")
+}
+
+func loadRulePackFromYAML(data []byte, baseDir string) (core.RulePack, error) {
 	var rulePack core.RulePack
 	if err := yaml.Unmarshal(data, &rulePack); err != nil {
 		return core.RulePack{}, fmt.Errorf("failed to unmarshal YAML: %w", err)
 	}
-
-	// Validar RulePack
-	if err := validateRulePack(rulePack); err != nil {
-		return core.RulePack{}, fmt.Errorf("invalid rule pack: %w", err)
-	}
-
-	return rulePack, nil
+	return finishRulePack(rulePack, baseDir)
 }
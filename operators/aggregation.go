@@ -0,0 +1,171 @@
+package operators
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func init() {
+	// Registrar agregações sobre paths com wildcards:
+	// {"sumPath": ["items[*].negotiations[*].percent", filtro?]}
+	// O path usa a mesma gramática dos targets das ações (actions.ParsePath).
+	registerCollectionOperator("sumPath", pathAggregation("sumPath", aggregateSum))
+	registerCollectionOperator("minPath", pathAggregation("minPath", aggregateMin))
+	registerCollectionOperator("maxPath", pathAggregation("maxPath", aggregateMax))
+	registerCollectionOperator("avgPath", pathAggregation("avgPath", aggregateAvg))
+	registerCollectionOperator("countPath", pathAggregation("countPath", aggregateCount))
+}
+
+// pathAggregator reduz os valores selecionados (já filtrados) a um resultado
+type pathAggregator func(values []interface{}) interface{}
+
+// pathAggregation cria um operador que seleciona valores por path e aplica o filtro opcional.
+//
+// No filtro, "value" é o valor selecionado, "item" é o elemento que o contém
+// (ex: a negociação em items[*].negotiations[*].percent) e "parent" o elemento acima dele.
+//
+// Exceto countPath, agregar valores de elementos com moedas ("currency") diferentes é erro.
+func pathAggregation(op string, aggregate pathAggregator) collectionOperator {
+	return func(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("%s requires a path", op)
+		}
+		rawPath, err := evaluateArg(args[0], data, frame)
+		if err != nil {
+			return nil, fmt.Errorf("%s: path: %w", op, err)
+		}
+		path, ok := rawPath.(string)
+		if !ok || path == "" {
+			return nil, fmt.Errorf("%s: path must be a non-empty string, got %T", op, rawPath)
+		}
+
+		matches, err := selectPath(pathSource(path, data, frame), path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		endsWithWildcard := strings.HasSuffix(path, "[*]")
+
+		values := make([]interface{}, 0, len(matches))
+		kept := make([]pathMatch, 0, len(matches))
+		for i, m := range matches {
+			if len(args) > 1 && args[1] != nil {
+				elem := collectionElement{value: m.Value}
+				parents := m.Parents
+				if !endsWithWildcard && len(parents) > 0 {
+					elem.value = parents[len(parents)-1]
+					parents = parents[:len(parents)-1]
+				}
+				if len(parents) > 0 {
+					elem.parent = parents[len(parents)-1]
+					elem.hasPar = true
+				}
+				keep, err := evaluateInScope(args[1], data, frame, elem, i, map[string]interface{}{"value": m.Value})
+				if err != nil {
+					return nil, fmt.Errorf("%s: filter: element %d: %w", op, i, err)
+				}
+				if !isTruthy(keep) {
+					continue
+				}
+			}
+			values = append(values, m.Value)
+			kept = append(kept, m)
+		}
+		if op != "countPath" {
+			if currencies := matchCurrencies(kept); len(currencies) > 1 {
+				return nil, fmt.Errorf("%s: cannot mix currencies %s (convert the values first)", op, strings.Join(currencies, ", "))
+			}
+		}
+		return aggregate(values), nil
+	}
+}
+
+// pathSource escolhe onde resolver o path: no escopo atual (ex: "item.negotiations[*].percent"
+// dentro de um map) ou, se a primeira chave não existir nele, nos dados raiz.
+func pathSource(path string, data map[string]interface{}, frame *scopeFrame) map[string]interface{} {
+	if frame == nil || frame.root == nil {
+		return data
+	}
+	first := path
+	if i := strings.IndexAny(first, ".["); i >= 0 {
+		first = first[:i]
+	}
+	if _, ok := data[first]; ok {
+		return data
+	}
+	return frame.root
+}
+
+func aggregateSum(values []interface{}) interface{} {
+	var sum float64
+	for _, v := range values {
+		if f, ok := asNumber(v); ok {
+			sum += f
+		}
+	}
+	return sum
+}
+
+func aggregateMin(values []interface{}) interface{} {
+	var result interface{}
+	for _, v := range values {
+		if f, ok := asNumber(v); ok {
+			if result == nil || f < result.(float64) {
+				result = f
+			}
+		}
+	}
+	return result
+}
+
+func aggregateMax(values []interface{}) interface{} {
+	var result interface{}
+	for _, v := range values {
+		if f, ok := asNumber(v); ok {
+			if result == nil || f > result.(float64) {
+				result = f
+			}
+		}
+	}
+	return result
+}
+
+func aggregateAvg(values []interface{}) interface{} {
+	var sum float64
+	count := 0
+	for _, v := range values {
+		if f, ok := asNumber(v); ok {
+			sum += f
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	return sum / float64(count)
+}
+
+func aggregateCount(values []interface{}) interface{} {
+	return float64(len(values))
+}
+
+// asNumber converte valores numéricos (ignorando strings, nil, objetos)
+func asNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
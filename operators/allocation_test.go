@@ -0,0 +1,54 @@
+package operators
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestAllocate_SharesSumToTotal: para totais e pesos aleatórios (inclusive todos zero), allocate
+// devolve uma parcela por peso e a soma das parcelas é o total
+func TestAllocate_SharesSumToTotal(t *testing.T) {
+	rng := rand.New(rand.NewSource(47))
+
+	for i := 0; i < 2000; i++ {
+		total := math.Round((rng.Float64()*2-1)*1e6) / 100
+		weights := make([]interface{}, 1+rng.Intn(12))
+		for j := range weights {
+			switch rng.Intn(4) {
+			case 0:
+				weights[j] = 0.0
+			case 1:
+				weights[j] = float64(rng.Intn(100))
+			default:
+				weights[j] = rng.Float64() * 1000
+			}
+		}
+		if i%10 == 0 {
+			for j := range weights {
+				weights[j] = 0.0
+			}
+		}
+
+		got, err := EvaluateJsonLogic(map[string]interface{}{"allocate": []interface{}{total, weights}}, map[string]interface{}{})
+		if err != nil {
+			t.Fatalf("allocate(%v, %v) failed: %v", total, weights, err)
+		}
+		shares, ok := got.([]interface{})
+		if !ok || len(shares) != len(weights) {
+			t.Fatalf("allocate(%v, %v) = %#v, want %d shares", total, weights, got, len(weights))
+		}
+
+		var sum float64
+		for _, share := range shares {
+			v, ok := share.(float64)
+			if !ok || math.IsNaN(v) || math.IsInf(v, 0) {
+				t.Fatalf("allocate(%v, %v): invalid share %#v", total, weights, share)
+			}
+			sum += v
+		}
+		if math.Abs(sum-total) > 1e-9*math.Max(1, math.Abs(total)) {
+			t.Fatalf("allocate(%v, %v): shares sum to %v", total, weights, sum)
+		}
+	}
+}
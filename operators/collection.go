@@ -0,0 +1,465 @@
+package operators
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Operadores de coleção: mapEach, filterEach, reduceEach, groupBy, sortBy (e foreach, ver iteration.go).
+// Os built-ins map, filter e reduce do JsonLogic continuam com a semântica padrão
+// ({"var": ""} é o elemento; o primeiro argumento precisa ser um array).
+//
+// Diferente dos operadores registrados via jsonlogic.AddOperator (que recebem os argumentos
+// já avaliados), o corpo destes operadores é avaliado uma vez por elemento, com o escopo:
+//   - item:   elemento atual
+//   - index:  posição do elemento (float64)
+//   - parent: elemento da iteração externa (ou o pai no path, ex: o item em "items[*].negotiations[*]")
+//   - root:   dados de avaliação originais
+//
+// Os campos do elemento são acessados por "item" ({"var": "item.amount"}); os demais nomes
+// continuam resolvendo nos dados de avaliação, como no foreach original.
+//
+// O primeiro argumento é um array (ex: {"var": "items"}) ou um path com wildcards
+// ("items[*].negotiations[*]"). Erros de avaliação do corpo são propagados.
+var collectionOperators = map[string]collectionOperator{}
+
+func init() {
+	registerCollectionOperator("mapEach", mapOperator)
+	registerCollectionOperator("filterEach", filterOperator)
+	registerCollectionOperator("reduceEach", reduceOperator)
+	registerCollectionOperator("groupBy", groupByOperator)
+	registerCollectionOperator("sortBy", sortByOperator)
+}
+
+type collectionOperator func(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error)
+
+// scopeFrame carrega o escopo da iteração corrente (nil no nível raiz)
+type scopeFrame struct {
+	root map[string]interface{}
+	item interface{}
+}
+
+// collectionElement é um elemento a iterar com seu pai (quando vem de um path aninhado)
+type collectionElement struct {
+	value  interface{}
+	parent interface{}
+	hasPar bool
+}
+
+// registerCollectionOperator registra um operador avaliado com escopo por elemento
+func registerCollectionOperator(name string, op collectionOperator) {
+	collectionOperators[name] = op
+}
+
+// IsCollectionOperator indica se o operador é avaliado pela engine com escopo por elemento
+func IsCollectionOperator(name string) bool {
+	_, ok := collectionOperators[name]
+	return ok
+}
+
+func mapOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	elems, body, err := collectionArgs("mapEach", args, data, frame)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]interface{}, len(elems))
+	for i, elem := range elems {
+		v, err := evaluateInScope(body, data, frame, elem, i, nil)
+		if err != nil {
+			return nil, fmt.Errorf("mapEach: element %d: %w", i, err)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+func filterOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	elems, body, err := collectionArgs("filterEach", args, data, frame)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]interface{}, 0, len(elems))
+	for i, elem := range elems {
+		v, err := evaluateInScope(body, data, frame, elem, i, nil)
+		if err != nil {
+			return nil, fmt.Errorf("filterEach: element %d: %w", i, err)
+		}
+		if isTruthy(v) {
+			out = append(out, elem.value)
+		}
+	}
+	return out, nil
+}
+
+// reduceOperator: {"reduceEach": [coleção, corpo, inicial]}
+// No corpo, "accumulator" é o valor acumulado e "current" (além de "item") o elemento atual.
+func reduceOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	elems, body, err := collectionArgs("reduceEach", args, data, frame)
+	if err != nil {
+		return nil, err
+	}
+	var acc interface{}
+	if len(args) > 2 {
+		acc, err = evaluateArg(args[2], data, frame)
+		if err != nil {
+			return nil, fmt.Errorf("reduceEach: initial value: %w", err)
+		}
+	}
+	for i, elem := range elems {
+		acc, err = evaluateInScope(body, data, frame, elem, i, map[string]interface{}{
+			"accumulator": acc,
+			"current":     elem.value,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reduceEach: element %d: %w", i, err)
+		}
+	}
+	return acc, nil
+}
+
+// groupByOperator: {"groupBy": [coleção, chave, agregado?]}
+// Retorna [{"key": k, "items": [...], "value": agregado}] na ordem da primeira ocorrência.
+// O agregado (opcional) é avaliado com "item" = grupo ({"key", "items"}).
+func groupByOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	elems, keyLogic, err := collectionArgs("groupBy", args, data, frame)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []map[string]interface{}
+	index := make(map[string]int)
+	for i, elem := range elems {
+		key, err := evaluateInScope(keyLogic, data, frame, elem, i, nil)
+		if err != nil {
+			return nil, fmt.Errorf("groupBy: element %d: %w", i, err)
+		}
+		encoded, err := json.Marshal(key)
+		if err != nil {
+			return nil, fmt.Errorf("groupBy: element %d: invalid key: %w", i, err)
+		}
+		pos, exists := index[string(encoded)]
+		if !exists {
+			pos = len(groups)
+			index[string(encoded)] = pos
+			groups = append(groups, map[string]interface{}{"key": key, "items": []interface{}{}})
+		}
+		groups[pos]["items"] = append(groups[pos]["items"].([]interface{}), elem.value)
+	}
+
+	out := make([]interface{}, len(groups))
+	for i, group := range groups {
+		if len(args) > 2 {
+			v, err := evaluateInScope(args[2], data, frame, collectionElement{value: group}, i, nil)
+			if err != nil {
+				return nil, fmt.Errorf("groupBy: group %v: %w", group["key"], err)
+			}
+			group["value"] = v
+		}
+		out[i] = group
+	}
+	return out, nil
+}
+
+// sortByOperator: {"sortBy": [coleção, chave, "asc"|"desc"]} (ordenação estável)
+func sortByOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	elems, keyLogic, err := collectionArgs("sortBy", args, data, frame)
+	if err != nil {
+		return nil, err
+	}
+	descending := false
+	if len(args) > 2 {
+		dir, err := evaluateArg(args[2], data, frame)
+		if err != nil {
+			return nil, fmt.Errorf("sortBy: direction: %w", err)
+		}
+		switch strings.ToLower(fmt.Sprint(dir)) {
+		case "asc":
+		case "desc":
+			descending = true
+		default:
+			return nil, fmt.Errorf("sortBy: direction must be \"asc\" or \"desc\", got %v", dir)
+		}
+	}
+
+	keys := make([]interface{}, len(elems))
+	order := make([]int, len(elems))
+	for i, elem := range elems {
+		key, err := evaluateInScope(keyLogic, data, frame, elem, i, nil)
+		if err != nil {
+			return nil, fmt.Errorf("sortBy: element %d: %w", i, err)
+		}
+		keys[i] = key
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		c := compareSortKeys(keys[order[a]], keys[order[b]])
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	out := make([]interface{}, len(elems))
+	for i, idx := range order {
+		out[i] = elems[idx].value
+	}
+	return out, nil
+}
+
+// compareSortKeys ordena nil < números < strings < demais (comparados como JSON)
+func compareSortKeys(a, b interface{}) int {
+	rank := func(v interface{}) int {
+		switch v.(type) {
+		case nil:
+			return 0
+		case float64:
+			return 1
+		case string:
+			return 2
+		default:
+			return 3
+		}
+	}
+	ra, rb := rank(a), rank(b)
+	if ra != rb {
+		return ra - rb
+	}
+	switch av := a.(type) {
+	case float64:
+		bv := b.(float64)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(av, b.(string))
+	case nil:
+		return 0
+	default:
+		ja, _ := json.Marshal(a)
+		jb, _ := json.Marshal(b)
+		return strings.Compare(string(ja), string(jb))
+	}
+}
+
+// collectionArgs resolve a coleção (array ou path com wildcards) e retorna o corpo
+func collectionArgs(op string, args []interface{}, data map[string]interface{}, frame *scopeFrame) ([]collectionElement, interface{}, error) {
+	if len(args) < 2 {
+		return nil, nil, fmt.Errorf("%s requires a collection and an expression", op)
+	}
+
+	source, err := evaluateArg(args[0], data, frame)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: collection: %w", op, err)
+	}
+
+	switch src := source.(type) {
+	case nil:
+		return nil, args[1], nil
+	case string:
+		matches, err := selectPath(data, src)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: collection path: %w", op, err)
+		}
+		elems := make([]collectionElement, len(matches))
+		for i, m := range matches {
+			elems[i] = collectionElement{value: m.Value}
+			if len(m.Parents) > 0 {
+				elems[i].parent = m.Parents[len(m.Parents)-1]
+				elems[i].hasPar = true
+			}
+		}
+		return elems, args[1], nil
+	default:
+		arr, ok := toInterfaceSlice(source)
+		if !ok {
+			return nil, nil, fmt.Errorf("%s: collection must be an array or a path, got %T", op, source)
+		}
+		elems := make([]collectionElement, len(arr))
+		for i, v := range arr {
+			elems[i] = collectionElement{value: v}
+		}
+		return elems, args[1], nil
+	}
+}
+
+// evaluateInScope avalia body com o escopo do elemento (item, index, parent, root)
+func evaluateInScope(body interface{}, data map[string]interface{}, frame *scopeFrame, elem collectionElement, index int, extra map[string]interface{}) (interface{}, error) {
+	root := data
+	var parent interface{}
+	if frame != nil {
+		root = frame.root
+		parent = frame.item
+	}
+	if elem.hasPar {
+		parent = elem.parent
+	}
+
+	scope := make(map[string]interface{}, len(data)+4+len(extra))
+	for k, v := range data {
+		scope[k] = v
+	}
+	scope["item"] = elem.value
+	scope["index"] = float64(index)
+	scope["parent"] = parent
+	scope["root"] = root
+	for k, v := range extra {
+		scope[k] = v
+	}
+
+	return evaluateArg(body, scope, &scopeFrame{root: root, item: elem.value})
+}
+
+// evaluateArg avalia um argumento que pode ser JsonLogic, array de argumentos ou literal
+func evaluateArg(arg interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	switch v := arg.(type) {
+	case map[string]interface{}:
+		return evaluateScoped(v, data, frame)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, elem := range v {
+			r, err := evaluateArg(elem, data, frame)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = r
+		}
+		return out, nil
+	default:
+		return arg, nil
+	}
+}
+
+// expandCollectionOperators substitui cada operador de coleção pelo seu resultado.
+// O resultado é guardado em bindings e o nó vira {"var": chave}, para que objetos
+// retornados não sejam interpretados como operações pelo JsonLogic.
+func expandCollectionOperators(node interface{}, data map[string]interface{}, frame *scopeFrame, bindings map[string]interface{}) (interface{}, error) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if len(v) == 1 {
+			for name, rawArgs := range v {
+				if conditionalOperators[name] && hasCollectionOperator(rawArgs) {
+					return expandConditional(name, rawArgs, data, frame, bindings)
+				}
+				if builtinIterators[name] {
+					return expandBuiltinIterator(name, rawArgs, data, frame, bindings)
+				}
+				op, ok := collectionOperators[name]
+				if !ok {
+					break
+				}
+				args, ok := rawArgs.([]interface{})
+				if !ok {
+					args = []interface{}{rawArgs}
+				}
+				result, err := op(args, data, frame)
+				if err != nil {
+					return nil, err
+				}
+				key := fmt.Sprintf("$collection%d", len(bindings))
+				bindings[key] = result
+				return map[string]interface{}{"var": key}, nil
+			}
+		}
+		out := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			expanded, err := expandCollectionOperators(child, data, frame, bindings)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = expanded
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			expanded, err := expandCollectionOperators(child, data, frame, bindings)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = expanded
+		}
+		return out, nil
+	default:
+		return node, nil
+	}
+}
+
+// conditionalOperators são os operadores do JsonLogic que não avaliam todos os argumentos
+var conditionalOperators = map[string]bool{"if": true, "?:": true, "and": true, "or": true}
+
+// expandConditional avalia if/?:, and e or como o JsonLogic quando os argumentos têm operadores
+// de coleção: as condições (ou operandos) em ordem, até a decisão, e só o ramo escolhido. Um erro
+// em um ramo não tomado (ex: lookup em uma tabela ausente) não interrompe a regra.
+func expandConditional(name string, rawArgs interface{}, data map[string]interface{}, frame *scopeFrame, bindings map[string]interface{}) (interface{}, error) {
+	result, err := evaluateConditional(name, argsOf(rawArgs), data, frame)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("$collection%d", len(bindings))
+	bindings[key] = result
+	return map[string]interface{}{"var": key}, nil
+}
+
+func evaluateConditional(name string, args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	if name == "if" || name == "?:" {
+		for i := 0; i+1 < len(args); i += 2 {
+			cond, err := evaluateArg(args[i], data, frame)
+			if err != nil {
+				return nil, err
+			}
+			if isTruthy(cond) {
+				return evaluateArg(args[i+1], data, frame)
+			}
+		}
+		if len(args)%2 == 1 {
+			return evaluateArg(args[len(args)-1], data, frame)
+		}
+		return nil, nil
+	}
+
+	// and: primeiro operando falso (ou o último); or: primeiro verdadeiro (ou o último)
+	var result interface{}
+	for _, arg := range args {
+		v, err := evaluateArg(arg, data, frame)
+		if err != nil {
+			return nil, err
+		}
+		result = v
+		if isTruthy(v) == (name == "or") {
+			break
+		}
+	}
+	return result, nil
+}
+
+// builtinIterators são os operadores do JsonLogic que avaliam o corpo com o elemento como dados
+var builtinIterators = map[string]bool{"map": true, "filter": true, "reduce": true, "all": true, "some": true, "none": true}
+
+// expandBuiltinIterator expande apenas a coleção de um iterador built-in: o corpo é avaliado
+// pelo JsonLogic com o elemento como dados, então um operador de coleção ali seria resolvido
+// no escopo errado e é rejeitado.
+func expandBuiltinIterator(name string, rawArgs interface{}, data map[string]interface{}, frame *scopeFrame, bindings map[string]interface{}) (interface{}, error) {
+	args, ok := rawArgs.([]interface{})
+	if !ok || len(args) == 0 {
+		return map[string]interface{}{name: rawArgs}, nil
+	}
+	for _, body := range args[1:] {
+		if hasCollectionOperator(body) {
+			return nil, fmt.Errorf("%s: collection operators are not supported inside the body of the built-in %s (use mapEach, filterEach or reduceEach)", name, name)
+		}
+	}
+	collection, err := expandCollectionOperators(args[0], data, frame, bindings)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]interface{}, len(args))
+	out[0] = collection
+	copy(out[1:], args[1:])
+	return map[string]interface{}{name: out}, nil
+}
@@ -0,0 +1,129 @@
+package operators
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func parseLogic(t *testing.T, src string) map[string]interface{} {
+	t.Helper()
+	var logic map[string]interface{}
+	if err := json.Unmarshal([]byte(src), &logic); err != nil {
+		t.Fatalf("invalid logic %s: %v", src, err)
+	}
+	return logic
+}
+
+// TestBuiltinIterators_StandardSemantics: map, filter e reduce do JsonLogic não são
+// substituídos pelos operadores de coleção ({"var": ""} é o elemento)
+func TestBuiltinIterators_StandardSemantics(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"amount": 2.0},
+			map[string]interface{}{"amount": 3.0},
+		},
+	}
+
+	tests := []struct {
+		logic    string
+		expected string
+	}{
+		{`{"map":[[1,2,3],{"*":[{"var":""},2]}]}`, `[2,4,6]`},
+		{`{"filter":[[1,2,3],{">":[{"var":""},1]}]}`, `[2,3]`},
+		{`{"reduce":[[1,2,3],{"+":[{"var":"current"},{"var":"accumulator"}]},0]}`, `6`},
+		{`{"map":[{"var":"items"},{"var":"amount"}]}`, `[2,3]`},
+		{`{"reduce":[{"filter":[{"var":"items"},{">":[{"var":"amount"},2]}]},{"+":[{"var":"current.amount"},{"var":"accumulator"}]},0]}`, `3`},
+		{`{"some":[[1,2,3],{">":[{"var":""},2]}]}`, `true`},
+		// String não é lida como path pelo built-in (use mapEach)
+		{`{"map":["items[*]",{"var":""}]}`, `[]`},
+		// Coleção de um built-in pode vir de um operador de coleção
+		{`{"map":[{"sortBy":[{"var":"items"},{"var":"item.amount"},"desc"]},{"var":"amount"}]}`, `[3,2]`},
+	}
+
+	for _, tt := range tests {
+		result, err := EvaluateJsonLogic(parseLogic(t, tt.logic), data)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.logic, err)
+		}
+		got, _ := json.Marshal(result)
+		if string(got) != tt.expected {
+			t.Fatalf("%s = %s, want %s", tt.logic, got, tt.expected)
+		}
+	}
+}
+
+func TestBuiltinIterators_Errors(t *testing.T) {
+	data := map[string]interface{}{"items": []interface{}{1.0, 2.0}}
+
+	// O corpo do built-in é avaliado pelo JsonLogic com o elemento como dados
+	for _, logic := range []string{
+		`{"map":[{"var":"items"},{"mapEach":[{"var":"items"},{"var":"item"}]}]}`,
+		`{"filter":[{"var":"items"},{"some":[{"groupBy":[{"var":"items"},{"var":"item"}]},true]}]}`,
+	} {
+		_, err := EvaluateJsonLogic(parseLogic(t, logic), data)
+		if err == nil || !strings.Contains(err.Error(), "not supported inside the body") {
+			t.Fatalf("%s: error = %v, want collection operator rejected", logic, err)
+		}
+	}
+}
+
+// TestCollectionScope_ElementFieldsDoNotShadowRoot: campos do elemento só existem em "item";
+// um campo ausente no elemento não é resolvido silenciosamente em outro lugar
+func TestCollectionScope_ElementFieldsDoNotShadowRoot(t *testing.T) {
+	data := map[string]interface{}{
+		"discount": 10.0,
+		"items": []interface{}{
+			map[string]interface{}{"discount": 1.0},
+			map[string]interface{}{},
+		},
+	}
+
+	result, err := EvaluateJsonLogic(parseLogic(t, `{"mapEach":[{"var":"items"},[{"var":"item.discount"},{"var":"discount"}]]}`), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := json.Marshal(result)
+	if string(got) != `[[1,10],[null,10]]` {
+		t.Fatalf("result = %s, want [[1,10],[null,10]]", got)
+	}
+}
+
+// TestConditionals_LazyCollectionOperators: if, ?:, and e or avaliam só o ramo tomado, também
+// com operadores de coleção; um erro em um ramo não tomado não interrompe a avaliação
+func TestConditionals_LazyCollectionOperators(t *testing.T) {
+	data := map[string]interface{}{
+		"hasTable": false,
+		"items":    []interface{}{map[string]interface{}{"amount": 2.0}},
+	}
+	// lookup em uma tabela que não existe: erro se for avaliado
+	failing := `{"lookup":["missing",{"uf":"SP"},"rate"]}`
+
+	tests := []struct {
+		logic    string
+		expected string
+	}{
+		{`{"if":[{"var":"hasTable"},` + failing + `,0]}`, `0`},
+		{`{"?:":[{"var":"hasTable"},` + failing + `,1]}`, `1`},
+		{`{"if":[{"var":"hasTable"},` + failing + `,{">":[{"countPath":["items[*]"]},0]},"items",` + failing + `]}`, `"items"`},
+		{`{"if":[{"!":{"var":"hasTable"}},{"sumPath":["items[*].amount"]},` + failing + `]}`, `2`},
+		{`{"and":[{"var":"hasTable"},` + failing + `]}`, `false`},
+		{`{"or":[{"sumPath":["items[*].amount"]},` + failing + `]}`, `2`},
+		{`{"$if":[0,{"var":"hasTable"},` + failing + `,3]}`, `3`},
+	}
+	for _, tt := range tests {
+		result, err := EvaluateJsonLogic(parseLogic(t, tt.logic), data)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.logic, err)
+		}
+		got, _ := json.Marshal(result)
+		if string(got) != tt.expected {
+			t.Fatalf("%s = %s, want %s", tt.logic, got, tt.expected)
+		}
+	}
+
+	// O ramo tomado continua propagando o erro
+	if _, err := EvaluateJsonLogic(parseLogic(t, `{"if":[{"!":{"var":"hasTable"}},`+failing+`,0]}`), data); err == nil {
+		t.Fatal("expected error from the taken branch")
+	}
+}
@@ -0,0 +1,56 @@
+package operators
+
+import (
+	"fmt"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+// CoverageKey é a chave dos dados de avaliação com o coletor de cobertura da execução (core.CoverageRecorder)
+const CoverageKey = "$coverage"
+
+// BranchOperator é o if instrumentado pela coleta de cobertura: {"$if": [id, condição, então, senão]}.
+// Avalia como o if (só o ramo tomado é avaliado) e registra o resultado da condição no coletor.
+const BranchOperator = "$if"
+
+func init() {
+	registerCollectionOperator(BranchOperator, branchOperator)
+}
+
+func branchOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("%s requires an id and a condition", BranchOperator)
+	}
+	id, ok := args[0].(float64)
+	if !ok {
+		return nil, fmt.Errorf("%s: id must be a number", BranchOperator)
+	}
+	cond, err := evaluateArg(args[1], data, frame)
+	if err != nil {
+		return nil, err
+	}
+	taken := isTruthy(cond)
+	if recorder := coverageOf(data, frame); recorder != nil {
+		recorder.RecordBranch(int(id), taken)
+	}
+	branch := 2
+	if !taken {
+		branch = 3
+	}
+	if branch >= len(args) {
+		return nil, nil
+	}
+	return evaluateArg(args[branch], data, frame)
+}
+
+func coverageOf(data map[string]interface{}, frame *scopeFrame) core.CoverageRecorder {
+	if recorder, ok := data[CoverageKey].(core.CoverageRecorder); ok {
+		return recorder
+	}
+	if frame != nil {
+		if recorder, ok := frame.root[CoverageKey].(core.CoverageRecorder); ok {
+			return recorder
+		}
+	}
+	return nil
+}
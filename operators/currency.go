@@ -0,0 +1,157 @@
+package operators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+// ExchangeRatesKey é a chave dos dados de avaliação com as cotações da execução (core.ExchangeRates)
+const ExchangeRatesKey = "$exchangeRates"
+
+func init() {
+	// {"convert": [valor, de, para, cotação?]} -> valor em "para", arredondado nas casas da moeda
+	// "de" nulo usa a moeda do escopo (do item ou do State); sem cotação usa as do RulePack/ContextMeta
+	registerCollectionOperator("convert", convertOperator)
+
+	// {"roundCurrency": [valor, moeda?]} -> arredonda nas casas da moeda (BRL = 2, JPY = 0, KWD = 3)
+	registerCollectionOperator("roundCurrency", roundCurrencyOperator)
+}
+
+func convertOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	if len(args) < 3 {
+		return nil, fmt.Errorf("convert requires a value, a source and a target currency")
+	}
+	value, err := numberOperand("convert", args[0], data, frame)
+	if err != nil {
+		return nil, err
+	}
+	from, err := currencyArg(args[1], data, frame)
+	if err != nil {
+		return nil, fmt.Errorf("convert: from: %w", err)
+	}
+	to, err := currencyArg(args[2], data, frame)
+	if err != nil {
+		return nil, fmt.Errorf("convert: to: %w", err)
+	}
+
+	var rate float64
+	if len(args) > 3 && args[3] != nil {
+		if rate, err = numberOperand("convert", args[3], data, frame); err != nil {
+			return nil, err
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("convert: rate must be positive")
+		}
+	} else if rate, err = exchangeRatesOf(data, frame).Rate(from, to); err != nil {
+		return nil, fmt.Errorf("convert: %w", err)
+	}
+
+	converted, _ := pkg.RoundCurrency(value*rate, to)
+	return converted, nil
+}
+
+func roundCurrencyOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("roundCurrency requires a value")
+	}
+	value, err := numberOperand("roundCurrency", args[0], data, frame)
+	if err != nil {
+		return nil, err
+	}
+	var currencyArgument interface{}
+	if len(args) > 1 {
+		currencyArgument = args[1]
+	}
+	code, err := currencyArg(currencyArgument, data, frame)
+	if err != nil {
+		return nil, fmt.Errorf("roundCurrency: %w", err)
+	}
+	rounded, _ := pkg.RoundCurrency(value, code)
+	return rounded, nil
+}
+
+// numberOperand avalia um argumento numérico (null = 0)
+func numberOperand(op string, arg interface{}, data map[string]interface{}, frame *scopeFrame) (float64, error) {
+	raw, err := evaluateArg(arg, data, frame)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if raw == nil {
+		return 0, nil
+	}
+	n, ok := asNumber(raw)
+	if !ok {
+		return 0, fmt.Errorf("%s: expected a number, got %T", op, raw)
+	}
+	return n, nil
+}
+
+// currencyArg avalia um código ISO-4217; nulo usa a moeda do escopo ("currency" do item ou do State)
+func currencyArg(arg interface{}, data map[string]interface{}, frame *scopeFrame) (string, error) {
+	var raw interface{}
+	if arg != nil {
+		var err error
+		if raw, err = evaluateArg(arg, data, frame); err != nil {
+			return "", err
+		}
+	}
+	if raw == nil {
+		raw = data["currency"]
+		if raw == nil && frame != nil {
+			raw = frame.root["currency"]
+		}
+		if raw == nil {
+			return "", fmt.Errorf("currency is required (no currency in scope)")
+		}
+	}
+	code, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("currency must be a string, got %T", raw)
+	}
+	code = strings.ToUpper(code)
+	if !pkg.IsCurrency(code) {
+		return "", fmt.Errorf("unknown currency %q", code)
+	}
+	return code, nil
+}
+
+// exchangeRatesOf localiza as cotações no escopo atual ou nos dados raiz
+func exchangeRatesOf(data map[string]interface{}, frame *scopeFrame) core.ExchangeRates {
+	if rates, ok := data[ExchangeRatesKey].(core.ExchangeRates); ok {
+		return rates
+	}
+	if frame != nil {
+		if rates, ok := frame.root[ExchangeRatesKey].(core.ExchangeRates); ok {
+			return rates
+		}
+	}
+	return nil
+}
+
+// matchCurrencies retorna as moedas distintas dos elementos que contêm os valores selecionados
+// (o "currency" do pai mais próximo), em ordem alfabética
+func matchCurrencies(matches []pathMatch) []string {
+	seen := map[string]bool{}
+	for _, m := range matches {
+		for i := len(m.Parents) - 1; i >= 0; i-- {
+			parent, ok := m.Parents[i].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if code, ok := parent["currency"].(string); ok && code != "" {
+				seen[code] = true
+				break
+			}
+		}
+	}
+	currencies := make([]string, 0, len(seen))
+	for code := range seen {
+		currencies = append(currencies, code)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
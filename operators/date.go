@@ -0,0 +1,441 @@
+package operators
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	_ "time/tzdata" // Fusos horários embutidos (WASM e ambientes sem zoneinfo)
+
+	"github.com/diegoholiveira/jsonlogic/v3"
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+// Datas circulam no JsonLogic como strings ISO-8601: "2026-01-15" (data) ou
+// "2026-01-15T10:30:00-03:00" (data/hora). Os operadores preservam a forma da entrada.
+//
+// "now" vem de context.now (ContextMeta.Now) e o fuso padrão de context.timezone,
+// o que torna as regras determinísticas para um relógio informado. Dias úteis
+// excluem sábados, domingos e as datas em context.holidays.
+
+const dateLayout = "2006-01-02"
+
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+}
+
+// dateValue é uma data interpretada, lembrando se a entrada era só data
+type dateValue struct {
+	t        time.Time
+	dateOnly bool
+}
+
+func (d dateValue) String() string {
+	if d.dateOnly {
+		return d.t.Format(dateLayout)
+	}
+	return d.t.Format(time.RFC3339)
+}
+
+func init() {
+	// {"now": [timezone?]} -> data/hora atual (context.now) no fuso informado ou context.timezone
+	jsonlogic.AddOperator("now", func(values, data interface{}) interface{} {
+		now := contextNow("now", data)
+		loc := locationArg("now", values, 0, data)
+		return dateValue{t: now.In(loc)}.String()
+	})
+
+	// {"today": [timezone?]} -> data atual (sem hora) no fuso informado ou context.timezone
+	jsonlogic.AddOperator("today", func(values, data interface{}) interface{} {
+		now := contextNow("today", data).In(locationArg("today", values, 0, data))
+		return dateValue{t: civilDate(now), dateOnly: true}.String()
+	})
+
+	// {"date": [valor, timezone?]} -> normaliza uma data/hora ISO-8601
+	jsonlogic.AddOperator("date", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		d := dateArg("date", args, 0, data)
+		if len(args) > 1 && !d.dateOnly {
+			d.t = d.t.In(locationArg("date", values, 1, data))
+		}
+		return d.String()
+	})
+
+	// {"dateAdd": [data, quantidade, unidade]}
+	// unidades: days, weeks, months, years, hours, minutes, businessDays
+	jsonlogic.AddOperator("dateAdd", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		d := dateArg("dateAdd", args, 0, data)
+		amount := int(math.Round(numberArg("dateAdd", args, 1)))
+		unit := stringArg("dateAdd", args, 2, "days")
+
+		switch unit {
+		case "days", "day":
+			d.t = d.t.AddDate(0, 0, amount)
+		case "weeks", "week":
+			d.t = d.t.AddDate(0, 0, 7*amount)
+		case "months", "month":
+			d.t = addMonthsClamped(d.t, amount)
+		case "years", "year":
+			d.t = addMonthsClamped(d.t, 12*amount)
+		case "hours", "hour":
+			d = dateValue{t: d.t.Add(time.Duration(amount) * time.Hour)}
+		case "minutes", "minute":
+			d = dateValue{t: d.t.Add(time.Duration(amount) * time.Minute)}
+		case "businessDays", "businessDay":
+			d.t = addBusinessDays(d.t, amount, holidaysOf(data))
+		default:
+			failOperator("dateAdd", "unknown unit %q", unit)
+		}
+		return d.String()
+	})
+
+	// {"dateDiff": [de, até, unidade]} -> diferença inteira (até - de)
+	// unidades: days, weeks, months, years, hours, minutes, businessDays
+	jsonlogic.AddOperator("dateDiff", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		from := dateArg("dateDiff", args, 0, data)
+		to := dateArg("dateDiff", args, 1, data)
+		unit := stringArg("dateDiff", args, 2, "days")
+
+		switch unit {
+		case "days", "day":
+			return float64(daysBetween(from.t, to.t))
+		case "weeks", "week":
+			return float64(daysBetween(from.t, to.t) / 7)
+		case "months", "month":
+			return float64(monthsBetween(from.t, to.t))
+		case "years", "year":
+			return float64(monthsBetween(from.t, to.t) / 12)
+		case "hours", "hour":
+			return math.Trunc(to.t.Sub(from.t).Hours())
+		case "minutes", "minute":
+			return math.Trunc(to.t.Sub(from.t).Minutes())
+		case "businessDays", "businessDay":
+			return float64(businessDaysBetween(from.t, to.t, holidaysOf(data)))
+		default:
+			failOperator("dateDiff", "unknown unit %q", unit)
+			return nil
+		}
+	})
+
+	// {"age": [nascimento, referência?]} -> idade em anos completos (referência padrão: today)
+	jsonlogic.AddOperator("age", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		birth := dateArg("age", args, 0, data)
+		ref := dateValue{t: civilDate(contextNow("age", data).In(contextLocation("age", data))), dateOnly: true}
+		if len(args) > 1 && args[1] != nil {
+			ref = dateArg("age", args, 1, data)
+		}
+		return float64(monthsBetween(birth.t, ref.t) / 12)
+	})
+
+	// {"dayOfWeek": [data]} -> 0 (domingo) a 6 (sábado)
+	jsonlogic.AddOperator("dayOfWeek", func(values, data interface{}) interface{} {
+		return float64(dateArg("dayOfWeek", argsOf(values), 0, data).t.Weekday())
+	})
+
+	// {"startOfMonth": [data]} / {"endOfMonth": [data]}
+	jsonlogic.AddOperator("startOfMonth", func(values, data interface{}) interface{} {
+		d := dateArg("startOfMonth", argsOf(values), 0, data)
+		d.t = time.Date(d.t.Year(), d.t.Month(), 1, 0, 0, 0, 0, d.t.Location())
+		return d.String()
+	})
+	jsonlogic.AddOperator("endOfMonth", func(values, data interface{}) interface{} {
+		d := dateArg("endOfMonth", argsOf(values), 0, data)
+		first := time.Date(d.t.Year(), d.t.Month(), 1, 0, 0, 0, 0, d.t.Location())
+		if d.dateOnly {
+			d.t = first.AddDate(0, 1, -1)
+		} else {
+			d.t = first.AddDate(0, 1, 0).Add(-time.Second)
+		}
+		return d.String()
+	})
+
+	// {"isBusinessDay": [data]} / {"nextBusinessDay": [data]} (a própria data se já for dia útil)
+	jsonlogic.AddOperator("isBusinessDay", func(values, data interface{}) interface{} {
+		return isBusinessDay(dateArg("isBusinessDay", argsOf(values), 0, data).t, holidaysOf(data))
+	})
+	jsonlogic.AddOperator("nextBusinessDay", func(values, data interface{}) interface{} {
+		d := dateArg("nextBusinessDay", argsOf(values), 0, data)
+		holidays := holidaysOf(data)
+		for !isBusinessDay(d.t, holidays) {
+			d.t = d.t.AddDate(0, 0, 1)
+		}
+		return d.String()
+	})
+
+	// {"formatDate": [data, layout, timezone?]}
+	// Tokens: YYYY, YY, MM, DD, HH, mm, ss (ex: "DD/MM/YYYY", "YYYY-MM-DD HH:mm")
+	jsonlogic.AddOperator("formatDate", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		d := dateArg("formatDate", args, 0, data)
+		layout := stringArg("formatDate", args, 1, "YYYY-MM-DD")
+		if len(args) > 2 && !d.dateOnly {
+			d.t = d.t.In(locationArg("formatDate", values, 2, data))
+		}
+		return formatDateLayout(d.t, layout)
+	})
+}
+
+// failOperator interrompe a avaliação; o erro é propagado por jsonlogic.Apply
+func failOperator(op, format string, args ...interface{}) {
+	panic(fmt.Errorf("%s: %s", op, fmt.Sprintf(format, args...)))
+}
+
+// argsOf normaliza os argumentos de um operador para slice
+func argsOf(values interface{}) []interface{} {
+	if args, ok := values.([]interface{}); ok {
+		return args
+	}
+	if values == nil {
+		return nil
+	}
+	return []interface{}{values}
+}
+
+func stringArg(op string, args []interface{}, i int, fallback string) string {
+	if i >= len(args) || args[i] == nil {
+		return fallback
+	}
+	s, ok := args[i].(string)
+	if !ok {
+		failOperator(op, "argument %d must be a string, got %T", i+1, args[i])
+	}
+	return s
+}
+
+func numberArg(op string, args []interface{}, i int) float64 {
+	if i >= len(args) {
+		failOperator(op, "missing argument %d", i+1)
+	}
+	if _, ok := asNumber(args[i]); !ok {
+		failOperator(op, "argument %d must be a number, got %T", i+1, args[i])
+	}
+	return pkg.ExtractFloat64FromValue(args[i])
+}
+
+func dateArg(op string, args []interface{}, i int, data interface{}) dateValue {
+	if i >= len(args) || args[i] == nil {
+		failOperator(op, "missing date argument %d", i+1)
+	}
+	s, ok := args[i].(string)
+	if !ok {
+		failOperator(op, "argument %d must be an ISO-8601 date string, got %T", i+1, args[i])
+	}
+	d, err := parseDate(s, contextLocation(op, data))
+	if err != nil {
+		failOperator(op, "%v", err)
+	}
+	return d
+}
+
+// parseDate interpreta datas ISO-8601; data/hora sem fuso usa loc
+func parseDate(s string, loc *time.Location) (dateValue, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(dateLayout, s); err == nil {
+		return dateValue{t: t, dateOnly: true}, nil
+	}
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return dateValue{t: t}, nil
+		}
+	}
+	return dateValue{}, fmt.Errorf("invalid ISO-8601 date %q", s)
+}
+
+// contextNow lê context.now dos dados de avaliação
+func contextNow(op string, data interface{}) time.Time {
+	raw, _ := contextValue(data, "now").(string)
+	if raw == "" {
+		failOperator(op, "context.now is not set")
+	}
+	d, err := parseDate(raw, contextLocation(op, data))
+	if err != nil {
+		failOperator(op, "context.now: %v", err)
+	}
+	return d.t
+}
+
+// contextLocation retorna o fuso de context.timezone (UTC se ausente; inválido é erro do operador)
+func contextLocation(op string, data interface{}) *time.Location {
+	name, _ := contextValue(data, "timezone").(string)
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		failOperator(op, "context.timezone: unknown timezone %q", name)
+	}
+	return loc
+}
+
+// ValidateClock valida o relógio e o fuso da execução (ContextMeta.Now e Timezone), lidos pelos
+// operadores de data; vazios são válidos (now ausente só falha nos operadores que o usam)
+func ValidateClock(now, timezone string) error {
+	loc := time.UTC
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("timezone: unknown timezone %q", timezone)
+		}
+	}
+	if now != "" {
+		if _, err := parseDate(now, loc); err != nil {
+			return fmt.Errorf("now: %w", err)
+		}
+	}
+	return nil
+}
+
+func locationArg(op string, values interface{}, i int, data interface{}) *time.Location {
+	args := argsOf(values)
+	if i >= len(args) || args[i] == nil || args[i] == "" {
+		return contextLocation(op, data)
+	}
+	name, ok := args[i].(string)
+	if !ok {
+		failOperator(op, "timezone must be a string, got %T", args[i])
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		failOperator(op, "unknown timezone %q", name)
+	}
+	return loc
+}
+
+func contextValue(data interface{}, key string) interface{} {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	ctx, ok := m["context"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return ctx[key]
+}
+
+// holidaysOf lê context.holidays ("YYYY-MM-DD")
+func holidaysOf(data interface{}) map[string]bool {
+	raw, _ := contextValue(data, "holidays").([]interface{})
+	holidays := make(map[string]bool, len(raw))
+	for _, h := range raw {
+		if s, ok := h.(string); ok {
+			holidays[s] = true
+		}
+	}
+	return holidays
+}
+
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func isBusinessDay(t time.Time, holidays map[string]bool) bool {
+	switch t.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return !holidays[t.Format(dateLayout)]
+}
+
+// addMonthsClamped soma meses mantendo o dia dentro do mês (31/01 + 1 mês = 28/02 ou 29/02)
+func addMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	target := first.AddDate(0, months, 0)
+	lastDay := target.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return target.AddDate(0, 0, day-1)
+}
+
+func addBusinessDays(t time.Time, n int, holidays map[string]bool) time.Time {
+	step := 1
+	if n < 0 {
+		step = -1
+		n = -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if isBusinessDay(t, holidays) {
+			n--
+		}
+	}
+	return t
+}
+
+// daysBetween conta dias de calendário entre as datas civis de from e to
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(math.Round(b.Sub(a).Hours() / 24))
+}
+
+// monthsBetween conta meses completos entre from e to (negativo se to < from)
+func monthsBetween(from, to time.Time) int {
+	if to.Before(from) {
+		return -monthsBetween(to, from)
+	}
+	months := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	if months > 0 && addMonthsClamped(from, months).After(to) {
+		months--
+	}
+	return months
+}
+
+// businessDaysBetween conta dias úteis em (from, to]; negativo se to < from
+func businessDaysBetween(from, to time.Time, holidays map[string]bool) int {
+	if to.Before(from) {
+		return -businessDaysBetween(to, from, holidays)
+	}
+	count := 0
+	for d := civilDate(from).AddDate(0, 0, 1); !d.After(civilDate(to)); d = d.AddDate(0, 0, 1) {
+		if isBusinessDay(d, holidays) {
+			count++
+		}
+	}
+	return count
+}
+
+// dateTokens são os tokens de formatDate, do mais longo para o mais curto
+var dateTokens = []struct {
+	token  string
+	format func(t time.Time) string
+}{
+	{"YYYY", func(t time.Time) string { return fmt.Sprintf("%04d", t.Year()) }},
+	{"YY", func(t time.Time) string { return fmt.Sprintf("%02d", t.Year()%100) }},
+	{"MM", func(t time.Time) string { return fmt.Sprintf("%02d", int(t.Month())) }},
+	{"DD", func(t time.Time) string { return fmt.Sprintf("%02d", t.Day()) }},
+	{"HH", func(t time.Time) string { return fmt.Sprintf("%02d", t.Hour()) }},
+	{"mm", func(t time.Time) string { return fmt.Sprintf("%02d", t.Minute()) }},
+	{"ss", func(t time.Time) string { return fmt.Sprintf("%02d", t.Second()) }},
+}
+
+// formatDateLayout formata t pelos tokens (YYYY, YY, MM, DD, HH, mm, ss); o restante do layout
+// é copiado literalmente (não passa pelo layout do pacote time, em que "1", "Jan" ou "PM" são tokens)
+func formatDateLayout(t time.Time, layout string) string {
+	var b strings.Builder
+	for i := 0; i < len(layout); {
+		matched := false
+		for _, tok := range dateTokens {
+			if strings.HasPrefix(layout[i:], tok.token) {
+				b.WriteString(tok.format(t))
+				i += len(tok.token)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteByte(layout[i])
+			i++
+		}
+	}
+	return b.String()
+}
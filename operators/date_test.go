@@ -0,0 +1,32 @@
+package operators
+
+import "testing"
+
+// TestFormatDate_Literals: texto fora dos tokens é copiado como está, mesmo quando é um token
+// do layout do pacote time ("1", "2", "06", "Jan", "Mon", "PM", "MST")
+func TestFormatDate_Literals(t *testing.T) {
+	data := map[string]interface{}{"d": "2026-03-05T14:07:09Z"}
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{"DD/MM/YYYY HH:mm:ss", "05/03/2026 14:07:09"},
+		{"YY-MM-DD", "26-03-05"},
+		{"Q1 YYYY", "Q1 2026"},
+		{"Lote 2 - DD/MM", "Lote 2 - 05/03"},
+		{"Jan/Mon DD", "Jan/Mon 05"},
+		{"HH:mm PM MST", "14:07 PM MST"},
+		{"06 15 04", "06 15 04"},
+	}
+	for _, tt := range tests {
+		got, err := EvaluateJsonLogic(map[string]interface{}{
+			"formatDate": []interface{}{map[string]interface{}{"var": "d"}, tt.layout},
+		}, data)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.layout, err)
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %v, want %q", tt.layout, got, tt.want)
+		}
+	}
+}
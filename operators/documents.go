@@ -0,0 +1,39 @@
+package operators
+
+import (
+	"github.com/diegoholiveira/jsonlogic/v3"
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+func init() {
+	// Documentos brasileiros: {"isCPF": [valor]} -> true/false (pontuação é ignorada; nulo -> false)
+	registerDocumentOperator("isCPF", pkg.IsCPF)
+	registerDocumentOperator("isCNPJ", pkg.IsCNPJ)
+	registerDocumentOperator("isCEP", pkg.IsCEP)
+	registerDocumentOperator("isGTIN", pkg.IsGTIN)
+	registerDocumentOperator("isNCM", pkg.IsNCM)
+	registerDocumentOperator("isCFOP", pkg.IsCFOP)
+
+	// {"isIE": [uf, valor]} -> inscrição estadual conforme o algoritmo da UF ("ISENTO" é aceito)
+	jsonlogic.AddOperator("isIE", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		if len(args) < 2 || args[1] == nil {
+			return false
+		}
+		uf := toText(args[0])
+		if !pkg.IsUF(uf) {
+			failOperator("isIE", "unknown UF %q", uf)
+		}
+		return pkg.IsIE(uf, toText(args[1]))
+	})
+}
+
+func registerDocumentOperator(name string, validate func(string) bool) {
+	jsonlogic.AddOperator(name, func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		if len(args) == 0 || args[0] == nil {
+			return false
+		}
+		return validate(toText(args[0]))
+	})
+}
@@ -1,7 +1,6 @@
 package operators
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 
@@ -15,43 +14,119 @@ const (
 
 // EvaluateJsonLogic avalia uma expressão JsonLogic e retorna o resultado
 func EvaluateJsonLogic(logic map[string]interface{}, data map[string]interface{}) (interface{}, error) {
+	if err := validateLogic(logic); err != nil {
+		return nil, err
+	}
+	logic, data, err := normalizeInput(logic, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	return evaluateScoped(logic, data, nil)
+}
+
+// EvaluateJsonLogicWithRoot avalia logic em um escopo derivado (ex: um elemento de items[*]),
+// mantendo root como dados raiz para "root" nos operadores de coleção e para os operadores de path.
+// root já deve estar normalizado (ver NormalizeData), como os dados montados pelo pipeline.
+func EvaluateJsonLogicWithRoot(logic map[string]interface{}, data map[string]interface{}, root map[string]interface{}) (interface{}, error) {
+	if err := validateLogic(logic); err != nil {
+		return nil, err
+	}
+	logic, data, err := normalizeInput(logic, data, root)
+	if err != nil {
+		return nil, err
+	}
+	return evaluateScoped(logic, data, &scopeFrame{root: root})
+}
+
+// normalizeInput normaliza lógica e dados (ver NormalizeValue); valores de data compartilhados
+// com root não são percorridos de novo
+func normalizeInput(logic, data, root map[string]interface{}) (map[string]interface{}, map[string]interface{}, error) {
+	normalized, err := NormalizeValue(logic)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid logic: %w", err)
+	}
+	data, err = normalizeScope(data, root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid data: %w", err)
+	}
+	return normalized.(map[string]interface{}), data, nil
+}
+
+// validateLogic valida tamanho e profundidade da lógica (prevenir DoS)
+func validateLogic(logic map[string]interface{}) error {
 	// Validar tamanho
 	logicJSON, err := json.Marshal(logic)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal logic: %w", err)
+		return fmt.Errorf("failed to marshal logic: %w", err)
 	}
 	if len(logicJSON) > MaxLogicSize {
-		return nil, fmt.Errorf("logic exceeds maximum size of %d bytes", MaxLogicSize)
+		return fmt.Errorf("logic exceeds maximum size of %d bytes", MaxLogicSize)
 	}
 
 	// Validar profundidade
 	if err := validateDepth(logic, 0); err != nil {
-		return nil, fmt.Errorf("logic exceeds maximum depth: %w", err)
+		return fmt.Errorf("logic exceeds maximum depth: %w", err)
 	}
 
-	// Avaliar JsonLogic
-	dataJSON, err := json.Marshal(data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal data: %w", err)
-	}
+	return nil
+}
 
-	logicReader := bytes.NewReader(logicJSON)
-	dataReader := bytes.NewReader(dataJSON)
+// evaluateScoped expande os operadores de coleção e avalia a lógica no escopo informado.
+// Lógica e dados já estão normalizados: o JsonLogic lê os mapas diretamente, sem serializar
+// os dados a cada avaliação, e o resultado é copiado para não compartilhar nada com eles.
+func evaluateScoped(logic map[string]interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	if hasCollectionOperator(logic) {
+		bindings := make(map[string]interface{})
+		expanded, err := expandCollectionOperators(logic, data, frame, bindings)
+		if err != nil {
+			return nil, err
+		}
+		scoped := make(map[string]interface{}, len(data)+len(bindings))
+		for k, v := range data {
+			scoped[k] = v
+		}
+		for k, v := range bindings {
+			if scoped[k], err = NormalizeValue(v); err != nil {
+				return nil, fmt.Errorf("failed to apply jsonlogic: %w", err)
+			}
+		}
+		logic = expanded.(map[string]interface{})
+		data = scoped
+	}
 
-	var resultBuffer bytes.Buffer
-	err = jsonlogic.Apply(logicReader, dataReader, &resultBuffer)
+	result, err := jsonlogic.ApplyInterface(logic, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to apply jsonlogic: %w", err)
 	}
-
-	var result interface{}
-	if err := json.Unmarshal(resultBuffer.Bytes(), &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+	result, err = cloneResult(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply jsonlogic: %w", err)
 	}
-
 	return result, nil
 }
 
+// hasCollectionOperator indica se a lógica usa algum operador de coleção (mapEach, groupBy, ...)
+func hasCollectionOperator(logic interface{}) bool {
+	switch v := logic.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if len(v) == 1 && IsCollectionOperator(k) {
+				return true
+			}
+			if hasCollectionOperator(child) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if hasCollectionOperator(child) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // validateDepth valida a profundidade máxima da lógica (prevenir DoS)
 func validateDepth(logic interface{}, currentDepth int) error {
 	if currentDepth > MaxDepth {
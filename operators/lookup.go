@@ -0,0 +1,115 @@
+package operators
+
+import (
+	"fmt"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+// TablesKey é a chave dos dados de avaliação com as tabelas compiladas do RulePack (*core.TableSet)
+const TablesKey = "$tables"
+
+func init() {
+	// {"lookup": [tabela, critérios, coluna?, padrão?]}
+	// Ex: {"lookup": ["icmsRates", {"origin": {"var": "originUF"}, "dest": {"var": "destUF"}}, "rate", 0]}
+	// Sem coluna retorna a linha inteira; sem correspondência retorna o padrão (ou null).
+	registerCollectionOperator("lookup", lookupOperator)
+}
+
+func lookupOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("lookup requires a table name and criteria")
+	}
+	tables := tablesOf(data, frame)
+
+	rawName, err := evaluateArg(args[0], data, frame)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: table: %w", err)
+	}
+	name, ok := rawName.(string)
+	if !ok || !tables.Has(name) {
+		return nil, fmt.Errorf("lookup: unknown table %v", rawName)
+	}
+
+	criteria, err := lookupCriteria(args[1], name, tables, data, frame)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s: criteria: %w", name, err)
+	}
+
+	column := ""
+	if len(args) > 2 && args[2] != nil {
+		rawColumn, err := evaluateArg(args[2], data, frame)
+		if err != nil {
+			return nil, fmt.Errorf("lookup %s: column: %w", name, err)
+		}
+		if column, ok = rawColumn.(string); !ok {
+			return nil, fmt.Errorf("lookup %s: column must be a string, got %T", name, rawColumn)
+		}
+		if !tables.HasColumn(name, column) {
+			return nil, fmt.Errorf("lookup %s: unknown column %q", name, column)
+		}
+	}
+
+	row, found, err := tables.Lookup(name, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	if !found {
+		if len(args) > 3 {
+			return evaluateArg(args[3], data, frame)
+		}
+		return nil, nil
+	}
+	if column == "" {
+		return row, nil
+	}
+	return row[column], nil
+}
+
+// lookupCriteria avalia os critérios: um objeto literal com as colunas-chave
+// ({"origin": {"var": "uf"}}, cada valor avaliado) ou uma expressão que retorne esse objeto
+func lookupCriteria(arg interface{}, table string, tables *core.TableSet, data map[string]interface{}, frame *scopeFrame) (map[string]interface{}, error) {
+	if obj, ok := arg.(map[string]interface{}); ok && len(obj) > 0 {
+		literal := true
+		for k := range obj {
+			if !tables.HasColumn(table, k) {
+				literal = false
+				break
+			}
+		}
+		if literal {
+			criteria := make(map[string]interface{}, len(obj))
+			for k, v := range obj {
+				value, err := evaluateArg(v, data, frame)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", k, err)
+				}
+				criteria[k] = value
+			}
+			return criteria, nil
+		}
+	}
+
+	value, err := evaluateArg(arg, data, frame)
+	if err != nil {
+		return nil, err
+	}
+	criteria, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be an object, got %T", value)
+	}
+	return criteria, nil
+}
+
+// tablesOf localiza as tabelas compiladas no escopo atual ou nos dados raiz
+func tablesOf(data map[string]interface{}, frame *scopeFrame) *core.TableSet {
+	if tables, ok := data[TablesKey].(*core.TableSet); ok {
+		return tables
+	}
+	if frame != nil {
+		if tables, ok := frame.root[TablesKey].(*core.TableSet); ok {
+			return tables
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package operators
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// engineKeys são as chaves dos dados de avaliação com valores Go da execução, lidos
+// diretamente pelos operadores (não passam pela normalização)
+var engineKeys = map[string]bool{TablesKey: true, ExchangeRatesKey: true, UnitsKey: true, CoverageKey: true}
+
+// NormalizeValue devolve v na forma do JSON decodificado (nil, bool, float64, string,
+// []interface{} e map[string]interface{}), que é a forma lida pelo JsonLogic.
+// O que já está nessa forma é reaproveitado sem cópia; números não finitos são rejeitados.
+func NormalizeValue(v interface{}) (interface{}, error) {
+	out, _, err := normalize(v, false)
+	return out, err
+}
+
+// NormalizeData normaliza os valores de data (ver NormalizeValue), exceto os das chaves da
+// engine ($tables, $exchangeRates, ...). Retorna o próprio data quando nada muda.
+func NormalizeData(data map[string]interface{}) (map[string]interface{}, error) {
+	return normalizeScope(data, nil)
+}
+
+// normalizeScope normaliza data como NormalizeData, sem percorrer os valores compartilhados
+// com root (já normalizado): o escopo de um item repete a maior parte dos dados raiz.
+func normalizeScope(data, root map[string]interface{}) (map[string]interface{}, error) {
+	var out map[string]interface{}
+	for k, v := range data {
+		if engineKeys[k] {
+			continue
+		}
+		if rv, ok := root[k]; ok && sameReference(v, rv) {
+			continue
+		}
+		n, changed, err := normalize(v, false)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]interface{}, len(data))
+			for k2, v2 := range data {
+				out[k2] = v2
+			}
+		}
+		out[k] = n
+	}
+	if out == nil {
+		return data, nil
+	}
+	return out, nil
+}
+
+// cloneResult copia o resultado de uma avaliação na forma do JSON decodificado, para que
+// não compartilhe mapas ou slices com os dados (que apontam para o State)
+func cloneResult(v interface{}) (interface{}, error) {
+	out, _, err := normalize(v, true)
+	return out, err
+}
+
+// sameReference indica se a e b são o mesmo mapa ou slice
+func sameReference(a, b interface{}) bool {
+	switch a.(type) {
+	case map[string]interface{}, []interface{}:
+	default:
+		return false
+	}
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	return ra.Kind() == rb.Kind() && ra.Pointer() == rb.Pointer() && ra.Len() == rb.Len()
+}
+
+// normalize converte v para a forma do JSON decodificado; changed indica se o valor
+// retornado é diferente de v. Com clone, mapas e slices são sempre copiados.
+func normalize(v interface{}, clone bool) (out interface{}, changed bool, err error) {
+	switch x := v.(type) {
+	case nil, bool, string:
+		return v, false, nil
+	case float64:
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return nil, false, fmt.Errorf("unsupported value: %v", x)
+		}
+		return v, false, nil
+	case float32:
+		return normalizeNumber(float64(x))
+	case int:
+		return float64(x), true, nil
+	case int8:
+		return float64(x), true, nil
+	case int16:
+		return float64(x), true, nil
+	case int32:
+		return float64(x), true, nil
+	case int64:
+		return float64(x), true, nil
+	case uint:
+		return float64(x), true, nil
+	case uint8:
+		return float64(x), true, nil
+	case uint16:
+		return float64(x), true, nil
+	case uint32:
+		return float64(x), true, nil
+	case uint64:
+		return float64(x), true, nil
+	case []interface{}:
+		var arr []interface{}
+		if clone {
+			arr = make([]interface{}, len(x))
+		}
+		for i, elem := range x {
+			n, c, err := normalize(elem, clone)
+			if err != nil {
+				return nil, false, err
+			}
+			if c && arr == nil {
+				arr = make([]interface{}, len(x))
+				copy(arr, x[:i])
+			}
+			if arr != nil {
+				arr[i] = n
+			}
+		}
+		if arr == nil {
+			return v, false, nil
+		}
+		return arr, true, nil
+	case map[string]interface{}:
+		var m map[string]interface{}
+		if clone {
+			m = make(map[string]interface{}, len(x))
+		}
+		for k, elem := range x {
+			n, c, err := normalize(elem, clone)
+			if err != nil {
+				return nil, false, err
+			}
+			if c && m == nil {
+				m = make(map[string]interface{}, len(x))
+				for k2, v2 := range x {
+					m[k2] = v2
+				}
+			}
+			if m != nil {
+				m[k] = n
+			}
+		}
+		if m == nil {
+			return v, false, nil
+		}
+		return m, true, nil
+	case []map[string]interface{}:
+		arr := make([]interface{}, len(x))
+		for i, elem := range x {
+			n, _, err := normalize(elem, clone)
+			if err != nil {
+				return nil, false, err
+			}
+			arr[i] = n
+		}
+		return arr, true, nil
+	case []float64:
+		arr := make([]interface{}, len(x))
+		for i, f := range x {
+			n, _, err := normalize(f, false)
+			if err != nil {
+				return nil, false, err
+			}
+			arr[i] = n
+		}
+		return arr, true, nil
+	case []string:
+		arr := make([]interface{}, len(x))
+		for i, s := range x {
+			arr[i] = s
+		}
+		return arr, true, nil
+	default:
+		// Demais tipos (structs, ponteiros, mapas e slices tipados): mesma forma do JSON
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, false, err
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			return nil, false, err
+		}
+		return decoded, true, nil
+	}
+}
+
+func normalizeNumber(f float64) (interface{}, bool, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, false, fmt.Errorf("unsupported value: %v", f)
+	}
+	return f, true, nil
+}
@@ -0,0 +1,59 @@
+package operators
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestEvaluateJsonLogic_TypedData: dados com tipos Go (int, []string, []map, structs) são lidos
+// como se tivessem passado por JSON, e o resultado não compartilha mapas ou slices com os dados
+func TestEvaluateJsonLogic_TypedData(t *testing.T) {
+	type customer struct {
+		UF    string  `json:"uf"`
+		Limit float64 `json:"limit"`
+	}
+	negotiations := []interface{}{map[string]interface{}{"percent": 5.0}}
+	data := map[string]interface{}{
+		"count":        3,
+		"tags":         []string{"a", "b"},
+		"items":        []map[string]interface{}{{"amount": int64(2)}, {"amount": 3}},
+		"customer":     customer{UF: "SP", Limit: 1000},
+		"negotiations": negotiations,
+	}
+
+	tests := []struct {
+		logic    string
+		expected string
+	}{
+		{`{"+":[{"var":"count"},1]}`, `4`},
+		{`{"in":["b",{"var":"tags"}]}`, `true`},
+		{`{"reduce":[{"var":"items"},{"+":[{"var":"current.amount"},{"var":"accumulator"}]},0]}`, `5`},
+		{`{"cat":[{"var":"customer.uf"},"/",{"var":"customer.limit"}]}`, `"SP/1000"`},
+	}
+	for _, tt := range tests {
+		result, err := EvaluateJsonLogic(parseLogic(t, tt.logic), data)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.logic, err)
+		}
+		got, _ := json.Marshal(result)
+		if string(got) != tt.expected {
+			t.Fatalf("%s = %s, want %s", tt.logic, got, tt.expected)
+		}
+	}
+
+	result, err := EvaluateJsonLogic(parseLogic(t, `{"var":"negotiations"}`), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result.([]interface{})[0].(map[string]interface{})["percent"] = 99.0
+	if negotiations[0].(map[string]interface{})["percent"] != 5.0 {
+		t.Fatal("result shares maps with the evaluation data")
+	}
+}
+
+func TestEvaluateJsonLogic_NonFiniteResult(t *testing.T) {
+	_, err := EvaluateJsonLogic(parseLogic(t, `{"/":[1,0]}`), map[string]interface{}{})
+	if err == nil {
+		t.Fatal("expected error for a non-finite result")
+	}
+}
@@ -11,6 +11,9 @@ func RegisterAllOperators() {
 	// Operador de iteração
 	registerIterationOperator()
 
+	// Operadores de coleção
+	registerCollectionOperators()
+
 	// Operador de alocação
 	registerAllocationOperator()
 }
@@ -32,6 +35,11 @@ func registerIterationOperator() {
 	// Implementado em iteration.go via init()
 }
 
+// registerCollectionOperators registra operadores de coleção (mapEach, filterEach, reduceEach, groupBy, sortBy)
+func registerCollectionOperators() {
+	// Implementado em collection.go via init()
+}
+
 // registerAllocationOperator registra operador "allocate"
 func registerAllocationOperator() {
 	// Implementado em allocation.go via init()
@@ -0,0 +1,102 @@
+package operators
+
+import (
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+// pathMatch é um valor encontrado por um path, com os elementos selecionados
+// pelos wildcards que o antecedem (ex: em items[*].negotiations[*], o item é o pai da negociação)
+type pathMatch struct {
+	Value   interface{}
+	Parents []interface{}
+}
+
+// selectPath resolve um path (gramática de pkg.ParsePath) sobre os dados de avaliação.
+//
+// Como nos dados de avaliação os campos do item ficam no nível do item, o segmento
+// "fields" é opcional ("items[*].fields.x" equivale a "items[*].x").
+func selectPath(data interface{}, path string) ([]pathMatch, error) {
+	steps, err := pkg.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	var matches []pathMatch
+	walkPath(data, steps, nil, &matches)
+	return matches, nil
+}
+
+func walkPath(current interface{}, steps []pkg.PathStep, parents []interface{}, out *[]pathMatch) {
+	if len(steps) == 0 {
+		*out = append(*out, pathMatch{Value: current, Parents: parents})
+		return
+	}
+
+	step := steps[0]
+	obj, ok := current.(map[string]interface{})
+	if !ok {
+		return
+	}
+	child, exists := obj[step.Key]
+	if !exists && step.Key == "fields" && !step.Wildcard && !step.HasIndex {
+		// Alias: items[*].fields.x -> items[*].x
+		walkPath(current, steps[1:], parents, out)
+		return
+	}
+	if !exists {
+		return
+	}
+
+	switch {
+	case step.Wildcard:
+		arr, ok := toInterfaceSlice(child)
+		if !ok {
+			return
+		}
+		for _, elem := range arr {
+			if len(steps) == 1 {
+				// O próprio elemento é o resultado; não é pai de si mesmo
+				walkPath(elem, steps[1:], parents, out)
+				continue
+			}
+			walkPath(elem, steps[1:], appendParent(parents, elem), out)
+		}
+	case step.HasIndex:
+		arr, ok := toInterfaceSlice(child)
+		if !ok || step.Index >= len(arr) {
+			return
+		}
+		walkPath(arr[step.Index], steps[1:], parents, out)
+	default:
+		walkPath(child, steps[1:], parents, out)
+	}
+}
+
+// appendParent copia parents antes de acrescentar (evita compartilhar o array entre ramos)
+func appendParent(parents []interface{}, elem interface{}) []interface{} {
+	next := make([]interface{}, len(parents)+1)
+	copy(next, parents)
+	next[len(parents)] = elem
+	return next
+}
+
+// toInterfaceSlice normaliza arrays vindos dos dados de avaliação
+func toInterfaceSlice(v interface{}) ([]interface{}, bool) {
+	switch arr := v.(type) {
+	case []interface{}:
+		return arr, true
+	case []map[string]interface{}:
+		out := make([]interface{}, len(arr))
+		for i, m := range arr {
+			out[i] = m
+		}
+		return out, true
+	case []float64:
+		out := make([]interface{}, len(arr))
+		for i, f := range arr {
+			out[i] = f
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,133 @@
+package operators
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dolphin-sistemas/computations-engine/payments"
+)
+
+// installmentOptions são as chaves aceitas no objeto de opções de "installments"
+var installmentOptions = map[string]bool{
+	"rate": true, "interest": true, "firstDue": true, "intervalDays": true,
+	"minInstallment": true, "remainder": true, "precision": true,
+}
+
+func init() {
+	// {"installments": [total, quantidade, opções?]}
+	// opções: {"rate": 1.99, "interest": "price", "firstDue": "2026-02-10", "intervalDays": 30,
+	//          "minInstallment": 50, "remainder": "last", "precision": 2}
+	// -> [{"number", "dueDate", "amount", "principal", "interest"}, ...] (ver pacote payments)
+	// interest: "price" (padrão com taxa; parcelas iguais, amortização crescente), "simple",
+	// "compound" (total × (1 + taxa)^n dividido em parcelas iguais; amortização e juros iguais em
+	// todas, ao contrário da Price) ou "none". Valores fora do intervalo de int64 na precisão são erro.
+	registerCollectionOperator("installments", installmentsOperator)
+}
+
+func installmentsOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("installments requires a total and a count")
+	}
+	values := map[string]interface{}{}
+	if len(args) > 2 && args[2] != nil {
+		options, err := installmentOptionsArg(args[2], data, frame)
+		if err != nil {
+			return nil, fmt.Errorf("installments: options: %w", err)
+		}
+		for k, v := range options {
+			values[k] = v
+		}
+	}
+	for i, key := range []string{"total", "count"} {
+		v, err := evaluateArg(args[i], data, frame)
+		if err != nil {
+			return nil, fmt.Errorf("installments: %s: %w", key, err)
+		}
+		values[key] = v
+	}
+
+	plan, err := InstallmentPlan(values)
+	if err != nil {
+		return nil, fmt.Errorf("installments: %w", err)
+	}
+	schedule, err := payments.Schedule(plan)
+	if err != nil {
+		return nil, err
+	}
+	return InstallmentValues(schedule), nil
+}
+
+// installmentOptionsArg aceita o objeto de opções literal (chaves conhecidas, valores JsonLogic)
+// ou uma expressão que o retorne
+func installmentOptionsArg(arg interface{}, data map[string]interface{}, frame *scopeFrame) (map[string]interface{}, error) {
+	if obj, ok := arg.(map[string]interface{}); ok && len(obj) > 0 {
+		literal := true
+		for k := range obj {
+			if !installmentOptions[k] {
+				literal = false
+				break
+			}
+		}
+		if literal {
+			options := make(map[string]interface{}, len(obj))
+			for k, v := range obj {
+				value, err := evaluateArg(v, data, frame)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", k, err)
+				}
+				options[k] = value
+			}
+			return options, nil
+		}
+	}
+
+	value, err := evaluateArg(arg, data, frame)
+	if err != nil {
+		return nil, err
+	}
+	options, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be an object, got %T", value)
+	}
+	for k := range options {
+		if !installmentOptions[k] {
+			return nil, fmt.Errorf("unknown option %q", k)
+		}
+	}
+	return options, nil
+}
+
+// InstallmentPlan converte valores avaliados (total, count e as opções) em payments.Plan,
+// rejeitando chaves desconhecidas
+func InstallmentPlan(values map[string]interface{}) (payments.Plan, error) {
+	var plan payments.Plan
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return plan, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&plan); err != nil {
+		return plan, fmt.Errorf("invalid plan: %w", err)
+	}
+	return plan, nil
+}
+
+// InstallmentValues converte as parcelas para valores JsonLogic (array de objetos)
+func InstallmentValues(schedule []payments.Installment) []interface{} {
+	out := make([]interface{}, len(schedule))
+	for i, inst := range schedule {
+		item := map[string]interface{}{
+			"number":    float64(inst.Number),
+			"amount":    inst.Amount,
+			"principal": inst.Principal,
+			"interest":  inst.Interest,
+		}
+		if inst.DueDate != "" {
+			item["dueDate"] = inst.DueDate
+		}
+		out[i] = item
+	}
+	return out
+}
@@ -0,0 +1,382 @@
+package operators
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/diegoholiveira/jsonlogic/v3"
+)
+
+const (
+	MaxPatternSize    = 1024 // Tamanho máximo de uma expressão regular (bytes)
+	maxCachedPatterns = 1024 // Padrões dinâmicos além deste limite não são guardados em cache
+)
+
+// patternCache guarda expressões regulares compiladas (RE2) por padrão
+var patternCache = struct {
+	sync.RWMutex
+	byPattern map[string]*regexp.Regexp
+}{byPattern: make(map[string]*regexp.Regexp)}
+
+func init() {
+	// {"match": [valor, padrão]} -> true se o valor casa com a regex (RE2)
+	jsonlogic.AddOperator("match", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		if len(args) < 2 || args[1] == nil {
+			failOperator("match", "missing pattern")
+		}
+		pattern := stringArg("match", args, 1, "")
+		re, err := CompilePattern(pattern)
+		if err != nil {
+			failOperator("match", "%v", err)
+		}
+		if len(args) == 0 || args[0] == nil {
+			return false
+		}
+		return re.MatchString(toText(args[0]))
+	})
+
+	// {"len": [valor]} -> número de caracteres (string) ou de elementos (array)
+	jsonlogic.AddOperator("len", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		if len(args) == 0 {
+			return 0.0
+		}
+		switch v := args[0].(type) {
+		case nil:
+			return 0.0
+		case []interface{}:
+			return float64(len(v))
+		case map[string]interface{}:
+			return float64(len(v))
+		default:
+			return float64(utf8.RuneCountInString(toText(v)))
+		}
+	})
+
+	jsonlogic.AddOperator("upper", func(values, data interface{}) interface{} {
+		return strings.ToUpper(textArg(values, 0))
+	})
+	jsonlogic.AddOperator("lower", func(values, data interface{}) interface{} {
+		return strings.ToLower(textArg(values, 0))
+	})
+	jsonlogic.AddOperator("trim", func(values, data interface{}) interface{} {
+		return strings.TrimSpace(textArg(values, 0))
+	})
+
+	// {"padLeft": [valor, largura, caractere?]} (padrão: espaço) -> ex: código com zeros à esquerda
+	jsonlogic.AddOperator("padLeft", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		text := textArg(values, 0)
+		width := int(numberArg("padLeft", args, 1))
+		pad := stringArg("padLeft", args, 2, " ")
+		if utf8.RuneCountInString(pad) != 1 {
+			failOperator("padLeft", "pad must be a single character, got %q", pad)
+		}
+		if missing := width - utf8.RuneCountInString(text); missing > 0 {
+			return strings.Repeat(pad, missing) + text
+		}
+		return text
+	})
+
+	// {"replace": [valor, busca, substituto, regex?]}
+	// Sem o 4º argumento a busca é literal; com true, busca é uma regex (RE2, $1 nos grupos)
+	jsonlogic.AddOperator("replace", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		text := textArg(values, 0)
+		search := stringArg("replace", args, 1, "")
+		replacement := stringArg("replace", args, 2, "")
+		if len(args) > 3 && isTruthy(args[3]) {
+			re, err := CompilePattern(search)
+			if err != nil {
+				failOperator("replace", "%v", err)
+			}
+			return re.ReplaceAllString(text, replacement)
+		}
+		if search == "" {
+			return text
+		}
+		return strings.ReplaceAll(text, search, replacement)
+	})
+
+	// {"split": [valor, separador]} -> array de strings
+	jsonlogic.AddOperator("split", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		text := textArg(values, 0)
+		if text == "" {
+			return []interface{}{}
+		}
+		parts := strings.Split(text, stringArg("split", args, 1, ","))
+		out := make([]interface{}, len(parts))
+		for i, p := range parts {
+			out[i] = p
+		}
+		return out
+	})
+
+	// {"join": [array, separador]} -> string
+	jsonlogic.AddOperator("join", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		if len(args) == 0 {
+			return ""
+		}
+		arr, ok := toInterfaceSlice(args[0])
+		if !ok {
+			if args[0] == nil {
+				return ""
+			}
+			failOperator("join", "first argument must be an array, got %T", args[0])
+		}
+		parts := make([]string, len(arr))
+		for i, v := range arr {
+			parts[i] = toText(v)
+		}
+		return strings.Join(parts, stringArg("join", args, 1, ","))
+	})
+
+	// {"format": [valor, estilo?, casas?, moeda?]} -> número formatado conforme context.locale
+	// estilos: "number" (padrão), "currency", "percent" (valor já em %, ex: 12.5 -> "12,50%")
+	jsonlogic.AddOperator("format", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		value := numberArg("format", args, 0)
+		style := stringArg("format", args, 1, "number")
+		locale := localeFormatFor(contextString(data, "locale"))
+
+		decimals := 2
+		if style == "number" {
+			decimals = -1
+		}
+		if len(args) > 2 && args[2] != nil {
+			decimals = int(numberArg("format", args, 2))
+		}
+
+		switch style {
+		case "number":
+			return locale.formatNumber(value, decimals)
+		case "percent":
+			return locale.formatNumber(value, decimals) + "%"
+		case "currency":
+			currency := stringArg("format", args, 3, locale.currency)
+			return locale.formatCurrency(value, decimals, currency)
+		default:
+			failOperator("format", "unknown style %q", style)
+			return nil
+		}
+	})
+}
+
+// CompilePattern compila (com cache) uma regex RE2, respeitando MaxPatternSize
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > MaxPatternSize {
+		return nil, fmt.Errorf("pattern exceeds maximum size of %d bytes", MaxPatternSize)
+	}
+
+	patternCache.RLock()
+	re, ok := patternCache.byPattern[pattern]
+	patternCache.RUnlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	patternCache.Lock()
+	if len(patternCache.byPattern) < maxCachedPatterns {
+		patternCache.byPattern[pattern] = re
+	}
+	patternCache.Unlock()
+	return re, nil
+}
+
+// PrecompileLogic compila antecipadamente os padrões literais de "match" e "replace" (regex)
+// encontrados na lógica, para que padrões inválidos falhem no carregamento do RulePack.
+func PrecompileLogic(logic interface{}) error {
+	switch v := logic.(type) {
+	case map[string]interface{}:
+		for op, rawArgs := range v {
+			args := argsOf(rawArgs)
+			switch {
+			case op == "match" && len(args) < 2:
+				return fmt.Errorf("match: missing pattern")
+			case op == "match":
+				if pattern, ok := args[1].(string); ok {
+					if _, err := CompilePattern(pattern); err != nil {
+						return fmt.Errorf("match: %w", err)
+					}
+				}
+			case op == "replace" && len(args) > 3 && args[3] == true:
+				if pattern, ok := args[1].(string); ok {
+					if _, err := CompilePattern(pattern); err != nil {
+						return fmt.Errorf("replace: %w", err)
+					}
+				}
+			}
+			if err := PrecompileLogic(rawArgs); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if err := PrecompileLogic(child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// textArg lê o argumento i como texto (nil -> "")
+func textArg(values interface{}, i int) string {
+	args := argsOf(values)
+	if i >= len(args) {
+		return ""
+	}
+	return toText(args[i])
+}
+
+// toText converte valores escalares para texto (números sem casas desnecessárias)
+func toText(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case bool:
+		return strconv.FormatBool(t)
+	default:
+		if f, ok := asNumber(v); ok {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+func contextString(data interface{}, key string) string {
+	s, _ := contextValue(data, key).(string)
+	return s
+}
+
+// localeFormat descreve separadores e posição do símbolo monetário de um locale
+type localeFormat struct {
+	decimal     string
+	group       string
+	currency    string // moeda padrão do locale
+	localSymbol string // símbolo da moeda padrão, quando difere do internacional (ex: "$" em en-US)
+	symbolAfter bool   // "1.234,56 €" em vez de "€ 1.234,56"
+	symbolSpace bool
+}
+
+var localeFormats = map[string]localeFormat{
+	"pt-BR": {decimal: ",", group: ".", currency: "BRL", symbolSpace: true},
+	"pt-PT": {decimal: ",", group: " ", currency: "EUR", symbolAfter: true, symbolSpace: true},
+	"en-US": {decimal: ".", group: ",", currency: "USD", localSymbol: "$"},
+	"en-GB": {decimal: ".", group: ",", currency: "GBP"},
+	"es-ES": {decimal: ",", group: ".", currency: "EUR", symbolAfter: true, symbolSpace: true},
+	"es-AR": {decimal: ",", group: ".", currency: "ARS", symbolSpace: true},
+	"es-MX": {decimal: ".", group: ",", currency: "MXN"},
+	"de-DE": {decimal: ",", group: ".", currency: "EUR", symbolAfter: true, symbolSpace: true},
+	"fr-FR": {decimal: ",", group: " ", currency: "EUR", symbolAfter: true, symbolSpace: true},
+}
+
+// localeByLanguage resolve locales informados só pelo idioma (ex: "pt", "en")
+var localeByLanguage = map[string]string{
+	"pt": "pt-BR",
+	"en": "en-US",
+	"es": "es-ES",
+	"de": "de-DE",
+	"fr": "fr-FR",
+}
+
+var currencySymbols = map[string]string{
+	"BRL": "R$",
+	"USD": "US$",
+	"EUR": "€",
+	"GBP": "£",
+	"ARS": "$",
+	"MXN": "$",
+	"CLP": "$",
+	"PYG": "₲",
+	"UYU": "$U",
+	"JPY": "¥",
+}
+
+// localeFormatFor retorna o formato do locale (aceita "pt_BR", "pt-br" ou só "pt"; padrão: pt-BR)
+func localeFormatFor(locale string) localeFormat {
+	locale = strings.ReplaceAll(locale, "_", "-")
+	for name, f := range localeFormats {
+		if strings.EqualFold(name, locale) {
+			return f
+		}
+	}
+	lang, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	if name, ok := localeByLanguage[lang]; ok {
+		return localeFormats[name]
+	}
+	return localeFormats["pt-BR"]
+}
+
+// formatNumber formata com separadores do locale; decimals < 0 mantém as casas do valor
+func (l localeFormat) formatNumber(value float64, decimals int) string {
+	var text string
+	if decimals < 0 {
+		text = strconv.FormatFloat(value, 'f', -1, 64)
+	} else {
+		m := math.Pow(10, float64(decimals))
+		value = math.Round(value*m) / m
+		text = strconv.FormatFloat(value, 'f', decimals, 64)
+	}
+
+	text = strings.TrimPrefix(text, "-")
+	intPart, fracPart, _ := strings.Cut(text, ".")
+
+	var out strings.Builder
+	if value < 0 {
+		out.WriteString("-")
+	}
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			out.WriteString(l.group)
+		}
+		out.WriteRune(r)
+	}
+	if fracPart != "" {
+		out.WriteString(l.decimal)
+		out.WriteString(fracPart)
+	}
+	return out.String()
+}
+
+func (l localeFormat) formatCurrency(value float64, decimals int, currency string) string {
+	currency = strings.ToUpper(currency)
+	symbol, ok := currencySymbols[currency]
+	if !ok {
+		symbol = currency
+	}
+	if currency == l.currency && l.localSymbol != "" {
+		symbol = l.localSymbol
+	}
+
+	number := l.formatNumber(value, decimals)
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign = "-"
+		number = number[1:]
+	}
+
+	space := ""
+	if l.symbolSpace {
+		space = " "
+	}
+	if l.symbolAfter {
+		return sign + number + space + symbol
+	}
+	return sign + symbol + space + number
+}
@@ -0,0 +1,24 @@
+package operators
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestMatch_MissingPattern: sem padrão, match é erro (e não um padrão vazio que casa com tudo)
+func TestMatch_MissingPattern(t *testing.T) {
+	data := map[string]interface{}{"cep": "01001-000"}
+	for _, logic := range []string{
+		`{"match":[{"var":"cep"}]}`,
+		`{"match":[{"var":"cep"},{"var":"pattern"}]}`,
+	} {
+		_, err := EvaluateJsonLogic(parseLogic(t, logic), data)
+		if err == nil || !strings.Contains(err.Error(), "missing pattern") {
+			t.Fatalf("%s: error = %v, want missing pattern", logic, err)
+		}
+	}
+
+	if err := PrecompileLogic(parseLogic(t, `{"!":{"match":[{"var":"cep"}]}}`)); err == nil {
+		t.Fatal("PrecompileLogic accepted match without a pattern")
+	}
+}
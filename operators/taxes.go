@@ -0,0 +1,186 @@
+package operators
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/diegoholiveira/jsonlogic/v3"
+	"github.com/dolphin-sistemas/computations-engine/taxes"
+)
+
+func init() {
+	// Tributos brasileiros (ver pacote taxes); valores arredondados em centavos.
+	// {"icms": [base, alíquota, redução?]}
+	jsonlogic.AddOperator("icms", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		return taxes.ICMSValue(numberArg("icms", args, 0), numberArg("icms", args, 1), optionalNumberArg("icms", args, 2))
+	})
+
+	// {"icmsST": [base com IPI, alíquota interna, MVA, ICMS próprio, redução?]}
+	jsonlogic.AddOperator("icmsST", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		return taxes.STValue(
+			numberArg("icmsST", args, 0),
+			numberArg("icmsST", args, 1),
+			numberArg("icmsST", args, 2),
+			numberArg("icmsST", args, 3),
+			optionalNumberArg("icmsST", args, 4),
+		)
+	})
+
+	// {"ipi": [base, alíquota]}
+	jsonlogic.AddOperator("ipi", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		return taxes.IPIValue(numberArg("ipi", args, 0), numberArg("ipi", args, 1))
+	})
+
+	// {"difal": [valor com IPI, alíquota interestadual, alíquota interna, FCP?, método?]}
+	// -> DIFAL devido ao destino (sem o FCP); método "double" (padrão) ou "single"
+	jsonlogic.AddOperator("difal", func(values, data interface{}) interface{} {
+		args := argsOf(values)
+		result, err := taxes.CalculateDIFAL(
+			numberArg("difal", args, 0),
+			numberArg("difal", args, 1),
+			numberArg("difal", args, 2),
+			optionalNumberArg("difal", args, 3),
+			stringArg("difal", args, 4, taxes.DIFALDouble),
+		)
+		if err != nil {
+			failOperator("difal", "%v", err)
+		}
+		return result.Value
+	})
+}
+
+// optionalNumberArg lê um argumento numérico opcional (ausente ou null = 0)
+func optionalNumberArg(op string, args []interface{}, i int) float64 {
+	if i >= len(args) || args[i] == nil {
+		return 0
+	}
+	return numberArg(op, args, i)
+}
+
+func init() {
+	// Conversão entre preço líquido (B2B) e preço com tributos (B2C), com centavos conciliados.
+	// {"grossUp": [preço líquido, tributos, saída?, casas?]}
+	// {"netDown": [preço com tributos, tributos, saída?, casas?]}
+	// tributos: [{"name": "icms", "rate": 18, "inside": true}, {"name": "ipi", "rate": 10}]
+	// saída: omitida -> {"net", "gross", "taxes": {nome: valor}}; "net", "gross" ou o nome de um tributo
+	registerCollectionOperator("grossUp", priceConversion("grossUp", taxes.GrossUp))
+	registerCollectionOperator("netDown", priceConversion("netDown", taxes.NetDown))
+}
+
+func priceConversion(name string, convert func(float64, []taxes.Rate, int) (taxes.Conversion, error)) collectionOperator {
+	return func(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+		if len(args) < 2 {
+			return nil, fmt.Errorf("%s requires a price and taxes", name)
+		}
+		rawPrice, err := evaluateArg(args[0], data, frame)
+		if err != nil {
+			return nil, fmt.Errorf("%s: price: %w", name, err)
+		}
+		price, ok := asNumber(rawPrice)
+		if !ok {
+			if rawPrice != nil {
+				return nil, fmt.Errorf("%s: price must be a number, got %T", name, rawPrice)
+			}
+			price = 0
+		}
+
+		rawRates, err := objectListArg(args[1], data, frame)
+		if err != nil {
+			return nil, fmt.Errorf("%s: taxes: %w", name, err)
+		}
+		rates, err := ParseRates(rawRates)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		output := ""
+		if len(args) > 2 {
+			if output, err = stringOption(args[2], data, frame, ""); err != nil {
+				return nil, fmt.Errorf("%s: output: %w", name, err)
+			}
+		}
+		precision := 2
+		if len(args) > 3 && args[3] != nil {
+			rawPrecision, err := evaluateArg(args[3], data, frame)
+			if err != nil {
+				return nil, fmt.Errorf("%s: precision: %w", name, err)
+			}
+			p, ok := asNumber(rawPrecision)
+			if !ok || p < 0 || p != math.Trunc(p) {
+				return nil, fmt.Errorf("%s: precision must be a non-negative integer", name)
+			}
+			precision = int(p)
+		}
+
+		result, err := convert(price, rates, precision)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		switch output {
+		case "":
+			amounts := make(map[string]interface{}, len(result.Taxes))
+			for _, tax := range result.Taxes {
+				amounts[tax.Name] = tax.Amount
+			}
+			return map[string]interface{}{"net": result.Net, "gross": result.Gross, "taxes": amounts}, nil
+		case "net":
+			return result.Net, nil
+		case "gross":
+			return result.Gross, nil
+		}
+		for _, tax := range result.Taxes {
+			if tax.Name == output {
+				return tax.Amount, nil
+			}
+		}
+		return nil, fmt.Errorf("%s: unknown output %q (use net, gross or a tax name)", name, output)
+	}
+}
+
+// ParseRates lê a lista de tributos da conversão (name, rate, inside?, compound?)
+func ParseRates(raw interface{}) ([]taxes.Rate, error) {
+	arr, ok := toInterfaceSlice(raw)
+	if !ok {
+		return nil, fmt.Errorf("taxes must be an array")
+	}
+	rates := make([]taxes.Rate, len(arr))
+	for i, elem := range arr {
+		obj, ok := elem.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("taxes[%d] must be an object with name/rate", i)
+		}
+		for key := range obj {
+			switch key {
+			case "name", "rate", "inside", "compound":
+			default:
+				return nil, fmt.Errorf("taxes[%d]: unknown field %q", i, key)
+			}
+		}
+		rate := taxes.Rate{}
+		if rate.Name, ok = obj["name"].(string); !ok {
+			return nil, fmt.Errorf("taxes[%d].name must be a string", i)
+		}
+		if rate.Rate, ok = asNumber(obj["rate"]); !ok {
+			return nil, fmt.Errorf("taxes[%d].rate must be a number", i)
+		}
+		if rate.Inside, ok = optionalBool(obj["inside"]); !ok {
+			return nil, fmt.Errorf("taxes[%d].inside must be a boolean", i)
+		}
+		if rate.Compound, ok = optionalBool(obj["compound"]); !ok {
+			return nil, fmt.Errorf("taxes[%d].compound must be a boolean", i)
+		}
+		rates[i] = rate
+	}
+	return rates, nil
+}
+
+func optionalBool(v interface{}) (bool, bool) {
+	if v == nil {
+		return false, true
+	}
+	b, ok := v.(bool)
+	return b, ok
+}
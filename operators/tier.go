@@ -0,0 +1,195 @@
+package operators
+
+import (
+	"fmt"
+	"math"
+)
+
+// Modos de cálculo por faixas
+const (
+	TierAllUnits    = "allUnits"    // a faixa atingida vale para todo o valor
+	TierIncremental = "incremental" // cada faixa vale só para a parte do valor dentro dela (como IR)
+)
+
+// Tier é uma faixa: vale até UpTo (inclusive); a última pode omitir upTo (sem limite).
+// Rate é um percentual (5 = 5%).
+type Tier struct {
+	UpTo    float64
+	HasUpTo bool
+	Rate    float64
+}
+
+func init() {
+	// {"tier": [valor, faixas, modo?, saída?]}
+	// faixas: [{"upTo": 10, "rate": 0}, {"upTo": 50, "rate": 5}, {"rate": 8}]
+	// modo: "allUnits" (padrão) ou "incremental"; saída: "rate" (padrão) ou "amount"
+	registerCollectionOperator("tier", tierOperator)
+}
+
+func tierOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("tier requires a value and tiers")
+	}
+	rawValue, err := evaluateArg(args[0], data, frame)
+	if err != nil {
+		return nil, fmt.Errorf("tier: value: %w", err)
+	}
+	value, ok := asNumber(rawValue)
+	if !ok {
+		if rawValue != nil {
+			return nil, fmt.Errorf("tier: value must be a number, got %T", rawValue)
+		}
+		value = 0
+	}
+
+	rawTiers, err := objectListArg(args[1], data, frame)
+	if err != nil {
+		return nil, fmt.Errorf("tier: tiers: %w", err)
+	}
+	tiers, err := ParseTiers(rawTiers)
+	if err != nil {
+		return nil, fmt.Errorf("tier: %w", err)
+	}
+
+	mode, output := TierAllUnits, "rate"
+	if len(args) > 2 {
+		if mode, err = stringOption(args[2], data, frame, TierAllUnits); err != nil {
+			return nil, fmt.Errorf("tier: mode: %w", err)
+		}
+	}
+	if len(args) > 3 {
+		if output, err = stringOption(args[3], data, frame, "rate"); err != nil {
+			return nil, fmt.Errorf("tier: output: %w", err)
+		}
+	}
+
+	rate, amount, err := ApplyTiers(value, tiers, mode)
+	if err != nil {
+		return nil, fmt.Errorf("tier: %w", err)
+	}
+	switch output {
+	case "rate":
+		return rate, nil
+	case "amount":
+		return amount, nil
+	default:
+		return nil, fmt.Errorf("tier: unknown output %q (use rate or amount)", output)
+	}
+}
+
+// objectListArg aceita uma lista de objetos literal (ex: a tabela de faixas de tier ou as alíquotas
+// de taxes, que não são JsonLogic) ou uma expressão que a retorne (ex: {"var": "tiers"}, {"lookup": [...]})
+func objectListArg(arg interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	if arr, ok := arg.([]interface{}); ok {
+		literal := true
+		for _, elem := range arr {
+			if _, isObj := elem.(map[string]interface{}); !isObj {
+				literal = false
+				break
+			}
+		}
+		if literal {
+			return arr, nil
+		}
+	}
+	return evaluateArg(arg, data, frame)
+}
+
+// EvaluateTiers avalia a tabela de faixas (literal ou JsonLogic) no escopo data,
+// com root como dados raiz (ver EvaluateJsonLogicWithRoot)
+func EvaluateTiers(arg interface{}, data map[string]interface{}, root map[string]interface{}) ([]Tier, error) {
+	raw, err := objectListArg(arg, data, &scopeFrame{root: root})
+	if err != nil {
+		return nil, err
+	}
+	return ParseTiers(raw)
+}
+
+// ParseTiers valida a tabela: upTo crescente e só a última faixa sem limite
+func ParseTiers(raw interface{}) ([]Tier, error) {
+	arr, ok := toInterfaceSlice(raw)
+	if !ok || len(arr) == 0 {
+		return nil, fmt.Errorf("tiers must be a non-empty array")
+	}
+	tiers := make([]Tier, len(arr))
+	for i, elem := range arr {
+		obj, ok := elem.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("tiers[%d] must be an object with upTo/rate", i)
+		}
+		rate, ok := asNumber(obj["rate"])
+		if !ok {
+			return nil, fmt.Errorf("tiers[%d].rate must be a number", i)
+		}
+		tier := Tier{Rate: rate}
+		if upTo, exists := obj["upTo"]; exists && upTo != nil {
+			if tier.UpTo, ok = asNumber(upTo); !ok {
+				return nil, fmt.Errorf("tiers[%d].upTo must be a number", i)
+			}
+			tier.HasUpTo = true
+		}
+		if i > 0 {
+			prev := tiers[i-1]
+			if !prev.HasUpTo {
+				return nil, fmt.Errorf("tiers[%d]: only the last tier may omit upTo", i-1)
+			}
+			if tier.HasUpTo && tier.UpTo <= prev.UpTo {
+				return nil, fmt.Errorf("tiers[%d].upTo must be greater than %v", i, prev.UpTo)
+			}
+		}
+		tiers[i] = tier
+	}
+	return tiers, nil
+}
+
+// ApplyTiers retorna o percentual aplicável e o valor resultante (valor * percentual).
+// No modo incremental o percentual é o efetivo (valor resultante / valor).
+// Valores acima da última faixa limitada usam o percentual dela.
+func ApplyTiers(value float64, tiers []Tier, mode string) (rate, amount float64, err error) {
+	switch mode {
+	case "", TierAllUnits:
+		rate = tiers[len(tiers)-1].Rate
+		for _, t := range tiers {
+			if !t.HasUpTo || value <= t.UpTo {
+				rate = t.Rate
+				break
+			}
+		}
+		return rate, value * rate / 100, nil
+	case TierIncremental:
+		lower := 0.0
+		for i, t := range tiers {
+			if value <= lower {
+				break
+			}
+			upper := math.Inf(1)
+			if t.HasUpTo && i < len(tiers)-1 {
+				upper = t.UpTo
+			}
+			amount += (math.Min(value, upper) - lower) * t.Rate / 100
+			lower = upper
+		}
+		if value > 0 {
+			rate = amount / value * 100
+		}
+		return rate, amount, nil
+	default:
+		return 0, 0, fmt.Errorf("unknown mode %q (use %s or %s)", mode, TierAllUnits, TierIncremental)
+	}
+}
+
+// stringOption avalia um argumento textual opcional (null = padrão)
+func stringOption(arg interface{}, data map[string]interface{}, frame *scopeFrame, fallback string) (string, error) {
+	v, err := evaluateArg(arg, data, frame)
+	if err != nil {
+		return "", err
+	}
+	if v == nil {
+		return fallback, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("must be a string, got %T", v)
+	}
+	return s, nil
+}
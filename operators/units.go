@@ -0,0 +1,99 @@
+package operators
+
+import (
+	"fmt"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+// UnitsKey é a chave dos dados de avaliação com as unidades de medida compiladas do RulePack (*core.UnitSet)
+const UnitsKey = "$units"
+
+func init() {
+	// {"toUnit": [quantidade base, unidade, tabela?, arredondamento?]} -> quantidade na unidade
+	// Ex: {"toUnit": [30, "case", "beer"]} com caixa de 24 e indivisível -> 2 (arredonda para cima)
+	// Unidades indivisíveis arredondam conforme a unidade (up, down ou nearest), salvo arredondamento explícito.
+	registerCollectionOperator("toUnit", toUnitOperator)
+
+	// {"fromUnit": [quantidade, unidade, tabela?]} -> quantidade na unidade base
+	// Ex: {"fromUnit": [{"var": "amount"}, null]} converte a quantidade do item na unidade do item
+	registerCollectionOperator("fromUnit", fromUnitOperator)
+}
+
+func toUnitOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("toUnit requires a quantity and a unit")
+	}
+	quantity, unit, table, err := unitOperands("toUnit", args, data, frame)
+	if err != nil {
+		return nil, err
+	}
+	var rounding string
+	if len(args) > 3 {
+		if rounding, err = stringOption(args[3], data, frame, ""); err != nil {
+			return nil, fmt.Errorf("toUnit: rounding: %w", err)
+		}
+	}
+	converted, err := unitsOf(data, frame).FromBase(table, unit, quantity, rounding)
+	if err != nil {
+		return nil, fmt.Errorf("toUnit: %w", err)
+	}
+	return converted, nil
+}
+
+func fromUnitOperator(args []interface{}, data map[string]interface{}, frame *scopeFrame) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("fromUnit requires a quantity and a unit")
+	}
+	quantity, unit, table, err := unitOperands("fromUnit", args, data, frame)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := unitsOf(data, frame).ToBase(table, unit, quantity)
+	if err != nil {
+		return nil, fmt.Errorf("fromUnit: %w", err)
+	}
+	return converted, nil
+}
+
+// unitOperands avalia [quantidade, unidade, tabela?]; unidade e tabela nulas usam as do escopo
+// ("unit" e "unitTable" do item), e a tabela cai em core.DefaultUnitTable
+func unitOperands(op string, args []interface{}, data map[string]interface{}, frame *scopeFrame) (float64, string, string, error) {
+	quantity, err := numberOperand(op, args[0], data, frame)
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	scopeUnit, _ := data["unit"].(string)
+	unit, err := stringOption(args[1], data, frame, scopeUnit)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("%s: unit: %w", op, err)
+	}
+	if unit == "" {
+		return 0, "", "", fmt.Errorf("%s: unit is required (no unit in scope)", op)
+	}
+
+	table, _ := data[core.ItemUnitTableField].(string)
+	if len(args) > 2 {
+		if table, err = stringOption(args[2], data, frame, table); err != nil {
+			return 0, "", "", fmt.Errorf("%s: table: %w", op, err)
+		}
+	}
+	if table == "" {
+		table = core.DefaultUnitTable
+	}
+	return quantity, unit, table, nil
+}
+
+// unitsOf localiza as unidades no escopo atual ou nos dados raiz
+func unitsOf(data map[string]interface{}, frame *scopeFrame) *core.UnitSet {
+	if units, ok := data[UnitsKey].(*core.UnitSet); ok {
+		return units
+	}
+	if frame != nil {
+		if units, ok := frame.root[UnitsKey].(*core.UnitSet); ok {
+			return units
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+// Package payments calcula planos de parcelamento: juros simples, compostos ou Tabela Price,
+// vencimentos a partir da primeira data e parcela mínima.
+//
+// As parcelas somam exatamente o total financiado (e as amortizações o valor à vista): o cálculo
+// é feito em unidades inteiras da menor casa decimal e a sobra é distribuída pela política de resto.
+package payments
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Tipos de juros
+const (
+	InterestNone     = "none"
+	InterestSimple   = "simple"   // total × (1 + taxa × n)
+	InterestCompound = "compound" // total × (1 + taxa)^n, em parcelas iguais com amortização e juros constantes
+	InterestPrice    = "price"    // Tabela Price: parcelas iguais com amortização crescente
+)
+
+// Políticas de resto (centavos que não dividem igualmente)
+const (
+	RemainderLast   = "last"   // a última parcela recebe a sobra (padrão)
+	RemainderFirst  = "first"  // a primeira parcela recebe a sobra
+	RemainderSpread = "spread" // um centavo a mais em cada parcela, a partir da primeira
+)
+
+const dateLayout = "2006-01-02"
+
+// MaxCount é a quantidade máxima de parcelas de um plano (30 anos de parcelas mensais)
+const MaxCount = 360
+
+// Plan são as condições do parcelamento
+type Plan struct {
+	Total          float64 `json:"total"`
+	Count          int     `json:"count"`
+	Rate           float64 `json:"rate"`     // juros por período, percentual (1.99 = 1,99%)
+	Interest       string  `json:"interest"` // padrão: price com taxa, none sem taxa
+	FirstDue       string  `json:"firstDue"` // "YYYY-MM-DD"; vazio omite os vencimentos
+	IntervalDays   int     `json:"intervalDays"`
+	MinInstallment float64 `json:"minInstallment"` // reduz a quantidade até a parcela atingir o mínimo
+	Remainder      string  `json:"remainder"`
+	Precision      *int    `json:"precision"` // casas decimais (padrão 2)
+}
+
+// Installment é uma parcela; Amount = Principal + Interest
+type Installment struct {
+	Number    int     `json:"number"`
+	DueDate   string  `json:"dueDate,omitempty"`
+	Amount    float64 `json:"amount"`
+	Principal float64 `json:"principal"`
+	Interest  float64 `json:"interest"`
+}
+
+// Schedule calcula as parcelas do plano
+func Schedule(plan Plan) ([]Installment, error) {
+	if plan.Total < 0 {
+		return nil, fmt.Errorf("installments: total must not be negative")
+	}
+	if plan.Count < 1 {
+		return nil, fmt.Errorf("installments: count must be at least 1")
+	}
+	if plan.Count > MaxCount {
+		return nil, fmt.Errorf("installments: count must be at most %d", MaxCount)
+	}
+	if plan.Rate < 0 {
+		return nil, fmt.Errorf("installments: rate must not be negative")
+	}
+	if plan.IntervalDays < 0 {
+		return nil, fmt.Errorf("installments: intervalDays must not be negative")
+	}
+	interest := plan.Interest
+	if interest == "" {
+		interest = InterestNone
+		if plan.Rate > 0 {
+			interest = InterestPrice
+		}
+	}
+	switch interest {
+	case InterestNone, InterestSimple, InterestCompound, InterestPrice:
+	default:
+		return nil, fmt.Errorf("installments: unknown interest %q (use %s, %s, %s or %s)",
+			interest, InterestNone, InterestSimple, InterestCompound, InterestPrice)
+	}
+	remainder := plan.Remainder
+	if remainder == "" {
+		remainder = RemainderLast
+	}
+	switch remainder {
+	case RemainderLast, RemainderFirst, RemainderSpread:
+	default:
+		return nil, fmt.Errorf("installments: unknown remainder %q (use %s, %s or %s)",
+			remainder, RemainderLast, RemainderFirst, RemainderSpread)
+	}
+	precision := 2
+	if plan.Precision != nil {
+		if precision = *plan.Precision; precision < 0 || precision > 6 {
+			return nil, fmt.Errorf("installments: precision must be between 0 and 6")
+		}
+	}
+	var first time.Time
+	if plan.FirstDue != "" {
+		var err error
+		if first, err = time.Parse(dateLayout, plan.FirstDue); err != nil {
+			return nil, fmt.Errorf("installments: firstDue must be a date (YYYY-MM-DD), got %q", plan.FirstDue)
+		}
+	}
+
+	scale := math.Pow(10, float64(precision))
+	for count := plan.Count; ; count-- {
+		result, err := schedule(plan.Total, count, plan.Rate/100, interest, remainder, scale)
+		if err != nil {
+			return nil, err
+		}
+		if count == 1 || plan.MinInstallment <= 0 || smallest(result) >= plan.MinInstallment {
+			if !first.IsZero() {
+				for i := range result {
+					result[i].DueDate = dueDate(first, i, plan.IntervalDays).Format(dateLayout)
+				}
+			}
+			return result, nil
+		}
+	}
+}
+
+// schedule calcula as parcelas para count períodos, em unidades inteiras de 1/scale
+func schedule(total float64, count int, rate float64, interest, remainder string, scale float64) ([]Installment, error) {
+	n := float64(count)
+	financed := total
+	switch interest {
+	case InterestSimple:
+		financed = total * (1 + rate*n)
+	case InterestCompound:
+		financed = total * math.Pow(1+rate, n)
+	case InterestPrice:
+		if rate > 0 {
+			financed = total * rate / (1 - math.Pow(1+rate, -n)) * n
+		}
+	}
+
+	financedUnits, err := units(financed, scale)
+	if err != nil {
+		return nil, err
+	}
+	totalUnits, err := units(total, scale)
+	if err != nil {
+		return nil, err
+	}
+	amounts := split(financedUnits, count, remainder)
+	var principals []int64
+	if interest == InterestPrice && rate > 0 {
+		principals = amortization(total, totalUnits, count, rate, scale)
+	} else {
+		principals = split(totalUnits, count, remainder)
+	}
+
+	result := make([]Installment, count)
+	for i := range result {
+		result[i] = Installment{
+			Number:    i + 1,
+			Amount:    float64(amounts[i]) / scale,
+			Principal: float64(principals[i]) / scale,
+			Interest:  float64(amounts[i]-principals[i]) / scale,
+		}
+	}
+	return result, nil
+}
+
+// split divide total unidades em count partes iguais, com a sobra conforme a política
+func split(total int64, count int, remainder string) []int64 {
+	parts := make([]int64, count)
+	unit, rest := total/int64(count), total%int64(count)
+	for i := range parts {
+		parts[i] = unit
+	}
+	switch remainder {
+	case RemainderFirst:
+		parts[0] += rest
+	case RemainderSpread:
+		for i := int64(0); i < rest; i++ {
+			parts[i]++
+		}
+	default:
+		parts[count-1] += rest
+	}
+	return parts
+}
+
+// amortization calcula as amortizações da Tabela Price (saldo × taxa de juros em cada período);
+// a última amortiza o saldo restante. Cada amortização fica entre 0 e o total, já validado em unidades.
+func amortization(total float64, totalUnits int64, count int, rate, scale float64) []int64 {
+	payment := total * rate / (1 - math.Pow(1+rate, -float64(count)))
+	principals := make([]int64, count)
+	balance := total
+	paid := int64(0)
+	for i := 0; i < count-1; i++ {
+		principal := payment - balance*rate
+		principals[i], _ = units(principal, scale)
+		paid += principals[i]
+		balance -= principal
+	}
+	principals[count-1] = totalUnits - paid
+	return principals
+}
+
+// units converte value para unidades inteiras de 1/scale (meio para cima, sem ruído binário);
+// valores que não cabem em int64 (ou infinitos, ex: juros compostos altos) são erro
+func units(value, scale float64) (int64, error) {
+	v := math.Round(math.Round(value*scale*1e6) / 1e6)
+	if math.IsNaN(v) || v >= math.MaxInt64 || v < math.MinInt64 {
+		return 0, fmt.Errorf("installments: amount %g is out of range for precision %g", value, math.Log10(scale))
+	}
+	return int64(v), nil
+}
+
+func smallest(installments []Installment) float64 {
+	min := installments[0].Amount
+	for _, inst := range installments[1:] {
+		min = math.Min(min, inst.Amount)
+	}
+	return min
+}
+
+// dueDate é o vencimento da parcela i: a cada intervalDays dias ou, sem intervalo, no mesmo dia
+// dos meses seguintes (limitado ao último dia do mês)
+func dueDate(first time.Time, i, intervalDays int) time.Time {
+	if intervalDays > 0 {
+		return first.AddDate(0, 0, i*intervalDays)
+	}
+	y, m, d := first.Date()
+	target := time.Date(y, m+time.Month(i), 1, 0, 0, 0, 0, time.UTC)
+	if last := target.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return target.AddDate(0, 0, d-1)
+}
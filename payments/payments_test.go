@@ -0,0 +1,115 @@
+package payments
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		plan       Plan
+		amounts    []float64
+		principals []float64
+		dueDates   []string
+	}{
+		{"no interest, remainder last", Plan{Total: 100.02, Count: 4}, []float64{25, 25, 25, 25.02}, nil, nil},
+		{"remainder first", Plan{Total: 100.02, Count: 4, Remainder: RemainderFirst}, []float64{25.02, 25, 25, 25}, nil, nil},
+		{"remainder spread", Plan{Total: 100.02, Count: 4, Remainder: RemainderSpread}, []float64{25.01, 25.01, 25, 25}, nil, nil},
+		{"simple interest", Plan{Total: 1000, Count: 4, Rate: 2, Interest: InterestSimple}, []float64{270, 270, 270, 270}, []float64{250, 250, 250, 250}, nil},
+		{"compound interest", Plan{Total: 1000, Count: 2, Rate: 10, Interest: InterestCompound}, []float64{605, 605}, []float64{500, 500}, nil},
+		// Compostos: total × (1 + taxa)^n dividido em parcelas iguais, com amortização e juros constantes
+		// (na Price, com as mesmas condições, a parcela é menor e a amortização cresce)
+		{"compound interest over three periods", Plan{Total: 1000, Count: 3, Rate: 10, Interest: InterestCompound},
+			[]float64{443.66, 443.66, 443.68}, []float64{333.33, 333.33, 333.34}, nil},
+		{"price table", Plan{Total: 1000, Count: 3, Rate: 10}, []float64{402.11, 402.11, 402.12}, []float64{302.11, 332.33, 365.56}, nil},
+		{"minimum installment reduces count", Plan{Total: 100, Count: 10, MinInstallment: 30}, []float64{33.33, 33.33, 33.34}, nil, nil},
+		{"minimum above total keeps one", Plan{Total: 10, Count: 5, MinInstallment: 30}, []float64{10}, nil, nil},
+		{"monthly due dates clamp to month end", Plan{Total: 90, Count: 3, FirstDue: "2026-01-31"}, []float64{30, 30, 30}, nil,
+			[]string{"2026-01-31", "2026-02-28", "2026-03-31"}},
+		{"due dates every 30 days", Plan{Total: 90, Count: 3, FirstDue: "2026-01-10", IntervalDays: 30}, []float64{30, 30, 30}, nil,
+			[]string{"2026-01-10", "2026-02-09", "2026-03-11"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Schedule(tt.plan)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.amounts) {
+				t.Fatalf("got %d installments, expected %d", len(got), len(tt.amounts))
+			}
+			principal := 0.0
+			for i, inst := range got {
+				if math.Abs(inst.Amount-tt.amounts[i]) > 0.001 {
+					t.Errorf("installment %d amount = %v, expected %v", i+1, inst.Amount, tt.amounts[i])
+				}
+				if tt.principals != nil && math.Abs(inst.Principal-tt.principals[i]) > 0.001 {
+					t.Errorf("installment %d principal = %v, expected %v", i+1, inst.Principal, tt.principals[i])
+				}
+				if math.Abs(inst.Principal+inst.Interest-inst.Amount) > 1e-9 {
+					t.Errorf("installment %d: principal + interest != amount", i+1)
+				}
+				if tt.dueDates != nil && inst.DueDate != tt.dueDates[i] {
+					t.Errorf("installment %d due = %s, expected %s", i+1, inst.DueDate, tt.dueDates[i])
+				}
+				principal += inst.Principal
+			}
+			if math.Abs(principal-tt.plan.Total) > 1e-6 {
+				t.Errorf("principals sum to %v, expected %v", principal, tt.plan.Total)
+			}
+		})
+	}
+}
+
+// TestSchedule_ExactSum confere que as amortizações somam exatamente o valor à vista e que as
+// parcelas diferem no máximo pela sobra de um centavo
+func TestSchedule_ExactSum(t *testing.T) {
+	for _, interest := range []string{InterestNone, InterestSimple, InterestCompound, InterestPrice} {
+		for count := 1; count <= 24; count++ {
+			total := 1234.57
+			got, err := Schedule(Plan{Total: total, Count: count, Rate: 1.99, Interest: interest, Remainder: RemainderSpread})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var principals int64
+			for _, inst := range got {
+				principals += int64(math.Round(inst.Principal * 100))
+			}
+			if principals != 123457 {
+				t.Errorf("%s/%d: principals sum to %d cents", interest, count, principals)
+			}
+			first, last := got[0].Amount, got[len(got)-1].Amount
+			if math.Abs(first-last) > 0.011 {
+				t.Errorf("%s/%d: uneven installments %v and %v", interest, count, first, last)
+			}
+		}
+	}
+}
+
+func TestSchedule_InvalidPlans(t *testing.T) {
+	tests := []struct {
+		name string
+		plan Plan
+		err  string
+	}{
+		{"zero count", Plan{Total: 100}, "count must be at least 1"},
+		{"count too large", Plan{Total: 100, Count: MaxCount + 1}, "count must be at most 360"},
+		{"negative total", Plan{Total: -1, Count: 1}, "total must not be negative"},
+		{"negative rate", Plan{Total: 100, Count: 1, Rate: -1}, "rate must not be negative"},
+		{"unknown interest", Plan{Total: 100, Count: 1, Interest: "daily"}, "unknown interest"},
+		{"unknown remainder", Plan{Total: 100, Count: 1, Remainder: "middle"}, "unknown remainder"},
+		{"invalid date", Plan{Total: 100, Count: 1, FirstDue: "31/01/2026"}, "firstDue must be a date"},
+		{"total out of range", Plan{Total: 1e17, Count: 1}, "out of range"},
+		{"compound interest out of range", Plan{Total: 1000, Count: MaxCount, Rate: 1000, Interest: InterestCompound}, "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Schedule(tt.plan); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
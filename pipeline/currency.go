@@ -0,0 +1,90 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+// ValidateSingleCurrency é uma referência para guards.ValidateSingleCurrency para evitar import circular
+var ValidateSingleCurrency func(state *core.State) error
+
+// ItemProjectionRules retorna os IDs das regras cuja lógica (condition e logic, value e params
+// das ações) lê projeções de itens: itemValues, itemTotals ou as declaradas no RulePack.
+// Os números das projeções não carregam moeda; essas regras só rodam com os itens na moeda
+// do estado (ver checkItemCurrencies).
+func ItemProjectionRules(rulePack core.RulePack) map[string]bool {
+	names := map[string]bool{"itemValues": true, "itemTotals": true}
+	for name := range rulePack.Projections {
+		names[name] = true
+	}
+	rules := make(map[string]bool)
+	for _, phase := range rulePack.Phases {
+		for _, rule := range phase.Rules {
+			if readsProjection(rule.Condition, names) {
+				rules[rule.ID] = true
+				continue
+			}
+			for _, action := range rule.Actions {
+				if readsProjection(action.Logic, names) || readsProjection(action.Value, names) || readsProjection(action.Params, names) {
+					rules[rule.ID] = true
+					break
+				}
+			}
+		}
+	}
+	return rules
+}
+
+// readsProjection indica se logic tem um {"var": ...} sobre uma das projeções
+func readsProjection(logic interface{}, names map[string]bool) bool {
+	switch v := logic.(type) {
+	case map[string]interface{}:
+		for op, args := range v {
+			if op == "var" {
+				path := args
+				if arr, ok := args.([]interface{}); ok && len(arr) > 0 {
+					path = arr[0]
+				}
+				if s, ok := path.(string); ok && names[projectionRoot(s)] {
+					return true
+				}
+			}
+			if readsProjection(args, names) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if readsProjection(child, names) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// projectionRoot é a primeira chave de um path ("itemValues.0" -> "itemValues")
+func projectionRoot(path string) string {
+	if i := strings.IndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
+// checkItemCurrencies exige os itens na moeda do estado antes de uma regra que lê projeções
+// de itens (ctx.ItemProjectionRules): somar itemValues com itens em moedas diferentes é erro,
+// não um total silenciosamente errado. A conversão (ação convertCurrency) deve vir antes.
+func checkItemCurrencies(ctx *core.EngineContext, rule core.Rule) error {
+	if !ctx.ItemProjectionRules[rule.ID] {
+		return nil
+	}
+	if ValidateSingleCurrency == nil {
+		return fmt.Errorf("ValidateSingleCurrency not initialized")
+	}
+	if err := ValidateSingleCurrency(ctx.State); err != nil {
+		return fmt.Errorf("rule %s reads item projections: %w", rule.ID, err)
+	}
+	return nil
+}
@@ -9,23 +9,18 @@ import (
 
 // RunPhase executa todas as regras de uma fase em ordem de prioridade
 func RunPhase(ctx *core.EngineContext, phase core.RulePhase) error {
-	// Ordenar regras por prioridade (menor = primeiro)
-	rules := make([]core.Rule, len(phase.Rules))
-	copy(rules, phase.Rules)
-
-	sort.Slice(rules, func(i, j int) bool {
-		// Se prioridade não especificada, assume 0
-		priI := rules[i].Priority
-		priJ := rules[j].Priority
-		return priI < priJ
-	})
-
 	// Executar cada regra
-	for _, rule := range rules {
+	for _, rule := range sortedRules(phase) {
 		if !rule.Enabled {
 			continue
 		}
+		if ctx.Ctx != nil {
+			if err := ctx.Ctx.Err(); err != nil {
+				return fmt.Errorf("rule %s not executed: %w", rule.ID, err)
+			}
+		}
 
+		ctx.RuleID = rule.ID
 		ruleReasons, ruleViolations, err := RunRule(ctx, rule)
 		if err != nil {
 			return fmt.Errorf("error executing rule %s: %w", rule.ID, err)
@@ -41,3 +36,17 @@ func RunPhase(ctx *core.EngineContext, phase core.RulePhase) error {
 
 	return nil
 }
+
+// sortedRules ordena as regras da fase por prioridade (menor = primeiro; empates mantêm a ordem declarada)
+func sortedRules(phase core.RulePhase) []core.Rule {
+	rules := make([]core.Rule, len(phase.Rules))
+	copy(rules, phase.Rules)
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		// Se prioridade não especificada, assume 0
+		priI := rules[i].Priority
+		priJ := rules[j].Priority
+		return priI < priJ
+	})
+	return rules
+}
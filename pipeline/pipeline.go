@@ -2,8 +2,10 @@ package pipeline
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/operators"
 )
 
 // PhaseOrder define a ordem das fases do pipeline
@@ -17,27 +19,56 @@ var PhaseOrder = []string{
 
 // RunPipeline executa o pipeline completo de fases
 func RunPipeline(ctx *core.EngineContext, rulePack core.RulePack) error {
+	for _, phase := range orderedPhases(rulePack) {
+		if phase.index < 0 {
+			// Fase customizada, executada no final
+			if err := RunPhase(ctx, phase.RulePhase); err != nil {
+				return fmt.Errorf("error in custom phase %s: %w", phase.Name, err)
+			}
+			continue
+		}
+
+		ctx.PhaseIndex = phase.index
+		if err := RunPhase(ctx, phase.RulePhase); err != nil {
+			return fmt.Errorf("error in phase %s: %w", phase.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// OrderedRules retorna as regras do RulePack na ordem em que o pipeline as avalia
+// (fases de PhaseOrder, depois as customizadas; dentro da fase, por prioridade)
+func OrderedRules(rulePack core.RulePack) []core.Rule {
+	var rules []core.Rule
+	for _, phase := range orderedPhases(rulePack) {
+		rules = append(rules, sortedRules(phase.RulePhase)...)
+	}
+	return rules
+}
+
+// scheduledPhase é uma fase com sua posição em PhaseOrder (-1 para fases customizadas)
+type scheduledPhase struct {
+	core.RulePhase
+	index int
+}
+
+// orderedPhases ordena as fases: as de PhaseOrder nessa ordem (com nome repetido vale a última
+// declarada) e depois as customizadas, na ordem declarada
+func orderedPhases(rulePack core.RulePack) []scheduledPhase {
 	// Criar mapa de fases por nome para acesso rápido
 	phaseMap := make(map[string]core.RulePhase)
 	for _, phase := range rulePack.Phases {
 		phaseMap[phase.Name] = phase
 	}
 
-	// Executar fases na ordem definida
+	var phases []scheduledPhase
 	for phaseIndex, phaseName := range PhaseOrder {
-		phase, exists := phaseMap[phaseName]
-		if !exists {
-			// Fase não existe no RulePack, pular
-			continue
-		}
-
-		ctx.PhaseIndex = phaseIndex
-		if err := RunPhase(ctx, phase); err != nil {
-			return fmt.Errorf("error in phase %s: %w", phaseName, err)
+		if phase, exists := phaseMap[phaseName]; exists {
+			phases = append(phases, scheduledPhase{RulePhase: phase, index: phaseIndex})
 		}
 	}
 
-	// Executar fases adicionais que não estão na ordem padrão
 	for _, phase := range rulePack.Phases {
 		found := false
 		for _, standardPhase := range PhaseOrder {
@@ -47,12 +78,49 @@ func RunPipeline(ctx *core.EngineContext, rulePack core.RulePack) error {
 			}
 		}
 		if !found {
-			// Fase customizada, executar no final
-			if err := RunPhase(ctx, phase); err != nil {
-				return fmt.Errorf("error in custom phase %s: %w", phase.Name, err)
-			}
+			phases = append(phases, scheduledPhase{RulePhase: phase, index: -1})
 		}
 	}
+	return phases
+}
 
+// PrecompileRulePack compila antecipadamente os padrões (regex) usados nas regras e projeções,
+// para que padrões inválidos ou grandes demais falhem antes da execução
+func PrecompileRulePack(rulePack core.RulePack) error {
+	for _, phase := range rulePack.Phases {
+		for _, rule := range phase.Rules {
+			if err := operators.PrecompileLogic(rule.Condition); err != nil {
+				return fmt.Errorf("rule %s condition: %w", rule.ID, err)
+			}
+			for i, action := range rule.Actions {
+				if err := operators.PrecompileLogic(action.Logic); err != nil {
+					return fmt.Errorf("rule %s action %d: %w", rule.ID, i, err)
+				}
+				if err := operators.PrecompileLogic(action.Value); err != nil {
+					return fmt.Errorf("rule %s action %d value: %w", rule.ID, i, err)
+				}
+				// Cada param é um literal ou JsonLogic (o mapa de params não é lógica)
+				for _, name := range sortedParamNames(action.Params) {
+					if err := operators.PrecompileLogic(action.Params[name]); err != nil {
+						return fmt.Errorf("rule %s action %d param %s: %w", rule.ID, i, name, err)
+					}
+				}
+			}
+		}
+	}
+	for _, name := range sortedProjectionNames(rulePack.Projections) {
+		if err := operators.PrecompileLogic(rulePack.Projections[name].Logic); err != nil {
+			return fmt.Errorf("projection %s: %w", name, err)
+		}
+	}
 	return nil
 }
+
+func sortedParamNames(params map[string]interface{}) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,429 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/operators"
+)
+
+// GetValue é uma referência para actions.GetValue para evitar import circular
+var GetValue func(state *core.State, target string) (interface{}, error)
+
+// reservedProjectionNames são as chaves dos dados de avaliação que uma projeção não pode
+// sobrepor (itemValues e itemTotals podem: são as projeções padrão)
+var reservedProjectionNames = map[string]bool{
+	"items":                    true,
+	"totals":                   true,
+	"context":                  true,
+	"currency":                 true,
+	operators.TablesKey:        true,
+	operators.ExchangeRatesKey: true,
+	operators.UnitsKey:         true,
+	operators.CoverageKey:      true,
+}
+
+// ValidateProjections valida as projeções declaradas em um RulePack
+func ValidateProjections(projections map[string]core.Projection) error {
+	_, err := CompileProjections(projections)
+	return err
+}
+
+// CompileProjections valida as projeções declaradas e compila as expressões das projeções por
+// path. O resultado é compilado uma vez por RulePack e vai em EngineContext.ProjectionExprs.
+func CompileProjections(projections map[string]core.Projection) (map[string]interface{}, error) {
+	exprs := make(map[string]interface{})
+	for _, name := range sortedProjectionNames(projections) {
+		p := projections[name]
+		if name == "" {
+			return nil, fmt.Errorf("projection name is required")
+		}
+		if reservedProjectionNames[name] {
+			return nil, fmt.Errorf("projection name %s is reserved", name)
+		}
+		if p.Path == "" && len(p.Logic) == 0 {
+			return nil, fmt.Errorf("projection %s requires path or logic", name)
+		}
+		if p.Path != "" && len(p.Logic) > 0 {
+			return nil, fmt.Errorf("projection %s must define either path or logic, not both", name)
+		}
+		if p.Path != "" {
+			node, err := compileProjection(p.Path)
+			if err != nil {
+				return nil, fmt.Errorf("projection %s: %w", name, err)
+			}
+			exprs[name] = node
+		}
+	}
+	return exprs, nil
+}
+
+// applyProjections materializa os arrays das projeções em data
+func applyProjections(ctx *core.EngineContext, data map[string]interface{}) error {
+	for _, name := range sortedProjectionNames(ctx.Projections) {
+		p := ctx.Projections[name]
+		var (
+			values interface{}
+			err    error
+		)
+		if len(p.Logic) > 0 {
+			values, err = evaluateLogicProjection(ctx, p.Logic, data)
+		} else {
+			values, err = evaluatePathProjection(ctx, name, p.Path)
+		}
+		if err == nil {
+			values, err = operators.NormalizeValue(values)
+		}
+		if err != nil {
+			return fmt.Errorf("projection %s: %w", name, err)
+		}
+		data[name] = values
+	}
+	return nil
+}
+
+func sortedProjectionNames(projections map[string]core.Projection) []string {
+	names := make([]string, 0, len(projections))
+	for name := range projections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// evaluateLogicProjection avalia logic uma vez por item (campos do item no escopo). O escopo é
+// copiado de base uma única vez: a cada item só as chaves do item anterior são restauradas.
+func evaluateLogicProjection(ctx *core.EngineContext, logic map[string]interface{}, base map[string]interface{}) ([]interface{}, error) {
+	out := make([]interface{}, len(ctx.State.Items))
+	scope := make(map[string]interface{}, len(base)+8)
+	for k, v := range base {
+		scope[k] = v
+	}
+	var itemKeys []string
+	set := func(k string, v interface{}) {
+		scope[k] = v
+		itemKeys = append(itemKeys, k)
+	}
+	for i, item := range ctx.State.Items {
+		for _, k := range itemKeys {
+			if v, ok := base[k]; ok {
+				scope[k] = v
+			} else {
+				delete(scope, k)
+			}
+		}
+		itemKeys = itemKeys[:0]
+
+		set("id", item.ID)
+		set("amount", item.Amount)
+		set("index", float64(i))
+		for k, v := range item.Fields {
+			set(k, v)
+		}
+
+		result, err := operators.EvaluateJsonLogicWithRoot(logic, scope, base)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		out[i] = result
+	}
+	return out, nil
+}
+
+// evaluatePathProjection resolve um path simples (valores preservados) ou uma expressão aritmética.
+// A expressão vem de ctx.ProjectionExprs; sem ela (contexto montado fora da engine), é compilada aqui.
+func evaluatePathProjection(ctx *core.EngineContext, name, expr string) (interface{}, error) {
+	if GetValue == nil {
+		return nil, fmt.Errorf("GetValue not initialized")
+	}
+	node, ok := ctx.ProjectionExprs[name].(projectionNode)
+	if !ok {
+		var err error
+		if node, err = compileProjection(expr); err != nil {
+			return nil, err
+		}
+	}
+
+	resolve := func(path string) (interface{}, error) {
+		return GetValue(ctx.State, path)
+	}
+
+	// Path simples: preservar os valores como estão (strings, objetos, ...)
+	if operand, ok := node.(projectionPath); ok {
+		v, err := resolve(string(operand))
+		if err != nil {
+			return nil, err
+		}
+		if arr, ok := v.([]interface{}); ok {
+			return arr, nil
+		}
+		if v == nil {
+			return []interface{}{}, nil
+		}
+		return []interface{}{v}, nil
+	}
+
+	result, err := node.eval(resolve)
+	if err != nil {
+		return nil, err
+	}
+	if result.vector == nil {
+		return []float64{result.scalar}, nil
+	}
+	return result.vector, nil
+}
+
+// projectionValue é um escalar ou um vetor (resultado de path com wildcard)
+type projectionValue struct {
+	scalar float64
+	vector []float64
+}
+
+type projectionNode interface {
+	eval(resolve func(path string) (interface{}, error)) (projectionValue, error)
+}
+
+type projectionNumber float64
+
+type projectionPath string
+
+type projectionNeg struct {
+	operand projectionNode
+}
+
+type projectionBinary struct {
+	op          byte
+	left, right projectionNode
+}
+
+func (n projectionNumber) eval(func(string) (interface{}, error)) (projectionValue, error) {
+	return projectionValue{scalar: float64(n)}, nil
+}
+
+func (n projectionPath) eval(resolve func(string) (interface{}, error)) (projectionValue, error) {
+	v, err := resolve(string(n))
+	if err != nil {
+		return projectionValue{}, err
+	}
+	if arr, ok := v.([]interface{}); ok {
+		vector := make([]float64, len(arr))
+		for i, elem := range arr {
+			vector[i] = toFloat64(elem)
+		}
+		return projectionValue{vector: vector}, nil
+	}
+	return projectionValue{scalar: toFloat64(v)}, nil
+}
+
+func (n projectionNeg) eval(resolve func(string) (interface{}, error)) (projectionValue, error) {
+	v, err := n.operand.eval(resolve)
+	if err != nil {
+		return projectionValue{}, err
+	}
+	if v.vector == nil {
+		return projectionValue{scalar: -v.scalar}, nil
+	}
+	out := make([]float64, len(v.vector))
+	for i, f := range v.vector {
+		out[i] = -f
+	}
+	return projectionValue{vector: out}, nil
+}
+
+func (n projectionBinary) eval(resolve func(string) (interface{}, error)) (projectionValue, error) {
+	left, err := n.left.eval(resolve)
+	if err != nil {
+		return projectionValue{}, err
+	}
+	right, err := n.right.eval(resolve)
+	if err != nil {
+		return projectionValue{}, err
+	}
+
+	if left.vector == nil && right.vector == nil {
+		f, err := applyProjectionOp(n.op, left.scalar, right.scalar)
+		return projectionValue{scalar: f}, err
+	}
+
+	size := len(left.vector)
+	if left.vector == nil {
+		size = len(right.vector)
+	} else if right.vector != nil && len(right.vector) != size {
+		return projectionValue{}, fmt.Errorf("operands have different lengths (%d and %d)", len(left.vector), len(right.vector))
+	}
+
+	out := make([]float64, size)
+	for i := range out {
+		l, r := left.scalar, right.scalar
+		if left.vector != nil {
+			l = left.vector[i]
+		}
+		if right.vector != nil {
+			r = right.vector[i]
+		}
+		f, err := applyProjectionOp(n.op, l, r)
+		if err != nil {
+			return projectionValue{}, fmt.Errorf("element %d: %w", i, err)
+		}
+		out[i] = f
+	}
+	return projectionValue{vector: out}, nil
+}
+
+func applyProjectionOp(op byte, l, r float64) (float64, error) {
+	switch op {
+	case '+':
+		return l + r, nil
+	case '-':
+		return l - r, nil
+	case '*':
+		return l * r, nil
+	case '/':
+		if r == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return l / r, nil
+	default:
+		return 0, fmt.Errorf("unknown operator %q", op)
+	}
+}
+
+// compileProjection compila uma expressão de projeção
+func compileProjection(expr string) (projectionNode, error) {
+	tokens, err := tokenizeProjection(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty projection expression")
+	}
+
+	p := &projectionParser{tokens: tokens}
+	node, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in projection expression %q", p.tokens[p.pos], expr)
+	}
+
+	return node, nil
+}
+
+// tokenizeProjection separa operadores, parênteses e operandos.
+// O "*" dentro de colchetes (items[*]) faz parte do path, não é multiplicação.
+func tokenizeProjection(expr string) ([]string, error) {
+	var tokens []string
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.IndexByte("+-*/()", c) >= 0:
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			start := i
+			depth := 0
+			for i < len(expr) {
+				c = expr[i]
+				if c == '[' {
+					depth++
+				} else if c == ']' {
+					depth--
+				} else if depth == 0 && (unicode.IsSpace(rune(c)) || strings.IndexByte("+-*/()", c) >= 0) {
+					break
+				}
+				i++
+			}
+			if depth != 0 {
+				return nil, fmt.Errorf("unbalanced brackets in projection expression %q", expr)
+			}
+			tokens = append(tokens, expr[start:i])
+		}
+	}
+	return tokens, nil
+}
+
+type projectionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *projectionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *projectionParser) parseExpr() (projectionNode, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok == "+" || tok == "-"; tok = p.peek() {
+		p.pos++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = projectionBinary{op: tok[0], left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *projectionParser) parseTerm() (projectionNode, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok == "*" || tok == "/"; tok = p.peek() {
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = projectionBinary{op: tok[0], left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *projectionParser) parseFactor() (projectionNode, error) {
+	tok := p.peek()
+	switch tok {
+	case "":
+		return nil, fmt.Errorf("unexpected end of projection expression")
+	case "-":
+		p.pos++
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return projectionNeg{operand: operand}, nil
+	case "(":
+		p.pos++
+		node, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing ) in projection expression")
+		}
+		p.pos++
+		return node, nil
+	case "+", "*", "/", ")":
+		return nil, fmt.Errorf("unexpected %q in projection expression", tok)
+	}
+
+	p.pos++
+	if f, err := strconv.ParseFloat(tok, 64); err == nil {
+		return projectionNumber(f), nil
+	}
+	return projectionPath(tok), nil
+}
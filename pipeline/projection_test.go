@@ -0,0 +1,100 @@
+package pipeline
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+// projectionPaths resolve os paths das expressões de teste
+var projectionPaths = map[string]interface{}{
+	"a":                 1.0,
+	"b":                 3.0,
+	"zero":              0.0,
+	"items[*].price":    []interface{}{10.0, 20.0},
+	"items[*].amount":   []interface{}{2.0, 0.0},
+	"items[*].discount": []interface{}{1.0, 2.0, 3.0},
+}
+
+func evalProjection(t *testing.T, expr string) (projectionValue, error) {
+	t.Helper()
+	node, err := compileProjection(expr)
+	if err != nil {
+		return projectionValue{}, err
+	}
+	return node.eval(func(path string) (interface{}, error) {
+		return projectionPaths[path], nil
+	})
+}
+
+func TestProjectionExpressions(t *testing.T) {
+	tests := []struct {
+		expr   string
+		scalar float64
+		vector []float64
+	}{
+		{"a + b * 2", 7, nil},
+		{"(a + b) * 2", 8, nil},
+		{"a - b - 1", -3, nil},
+		{"b / a / 2", 1.5, nil},
+		{"-a * 2", -2, nil},
+		{"-(a + b)", -4, nil},
+		{"2", 2, nil},
+		{"items[*].price * items[*].amount", 0, []float64{20, 0}},
+		{"items[*].price * 2 + a", 0, []float64{21, 41}},
+		{"(items[*].price - 5) / 5", 0, []float64{1, 3}},
+		{"-items[*].price", 0, []float64{-10, -20}},
+	}
+	for _, tt := range tests {
+		got, err := evalProjection(t, tt.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.expr, err)
+		}
+		want := projectionValue{scalar: tt.scalar, vector: tt.vector}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s = %+v, want %+v", tt.expr, got, want)
+		}
+	}
+}
+
+func TestProjectionExpressions_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{"", "empty projection expression"},
+		{"a +", "unexpected end of projection expression"},
+		{"(a + b", "missing )"},
+		{"a + b)", `unexpected ")"`},
+		{"* a", `unexpected "*"`},
+		{"a b", `unexpected "b"`},
+		{"items[*.price", "unbalanced brackets"},
+		{"a / zero", "division by zero"},
+		{"items[*].price / items[*].amount", "element 1: division by zero"},
+		{"items[*].price + items[*].discount", "operands have different lengths (2 and 3)"},
+	}
+	for _, tt := range tests {
+		_, err := evalProjection(t, tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Fatalf("%s: error = %v, want %q", tt.expr, err, tt.err)
+		}
+	}
+}
+
+func TestCompileProjections_ReservedNames(t *testing.T) {
+	for _, name := range []string{"items", "totals", "context", "currency", "$tables", "$exchangeRates", "$units", "$coverage"} {
+		_, err := CompileProjections(map[string]core.Projection{name: {Path: "items[*].amount"}})
+		if err == nil || !strings.Contains(err.Error(), "is reserved") {
+			t.Fatalf("%s: error = %v, want reserved name", name, err)
+		}
+	}
+	exprs, err := CompileProjections(map[string]core.Projection{"itemValues": {Path: "items[*].amount"}})
+	if err != nil {
+		t.Fatalf("itemValues: %v", err)
+	}
+	if _, ok := exprs["itemValues"].(projectionNode); !ok {
+		t.Fatal("itemValues was not compiled")
+	}
+}
@@ -0,0 +1,107 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+// PromotionRulePrefix prefixa o ID das regras geradas a partir das promoções
+const PromotionRulePrefix = "promotion:"
+
+// ValidatePromotions valida as promoções declaradas no RulePack
+func ValidatePromotions(promotions []core.Promotion) error {
+	seen := make(map[string]bool, len(promotions))
+	for i, p := range promotions {
+		if p.ID == "" {
+			return fmt.Errorf("promotions[%d].id is required", i)
+		}
+		if seen[p.ID] {
+			return fmt.Errorf("promotions[%d]: duplicate promotion %q", i, p.ID)
+		}
+		seen[p.ID] = true
+
+		switch p.Type {
+		case core.PromotionPercentOff:
+			if p.Percent <= 0 || p.Percent > 100 {
+				return fmt.Errorf("promotion %s: percent must be between 0 and 100", p.ID)
+			}
+		case core.PromotionAmountOff:
+			if p.Amount <= 0 {
+				return fmt.Errorf("promotion %s: amount must be positive", p.ID)
+			}
+		case core.PromotionBuyXGetY:
+			if p.Buy < 1 || p.Get < 1 {
+				return fmt.Errorf("promotion %s: buy and get must be at least 1", p.ID)
+			}
+			if p.Percent < 0 || p.Percent > 100 {
+				return fmt.Errorf("promotion %s: percent must be between 0 and 100", p.ID)
+			}
+		case core.PromotionFreeShipping:
+		default:
+			return fmt.Errorf("promotion %s: unknown type %q", p.ID, p.Type)
+		}
+		if p.MinSubtotal < 0 || p.MaxDiscount < 0 {
+			return fmt.Errorf("promotion %s: minSubtotal and maxDiscount must not be negative", p.ID)
+		}
+		if p.UsageLimit < 0 || p.MaxApplications < 0 {
+			return fmt.Errorf("promotion %s: usageLimit and maxApplications must not be negative", p.ID)
+		}
+	}
+	return nil
+}
+
+// CompilePromotions valida as promoções e retorna o RulePack com uma regra por promoção
+// (condição = eligibility, ação promotion) na fase da promoção, que é criada se não existir.
+// O RulePack recebido não é alterado.
+func CompilePromotions(rulePack core.RulePack) (core.RulePack, error) {
+	if err := ValidatePromotions(rulePack.Promotions); err != nil {
+		return rulePack, err
+	}
+	if len(rulePack.Promotions) == 0 {
+		return rulePack, nil
+	}
+
+	phases := make([]core.RulePhase, len(rulePack.Phases))
+	index := make(map[string]int, len(rulePack.Phases))
+	for i, phase := range rulePack.Phases {
+		phases[i] = core.RulePhase{Name: phase.Name, Rules: append([]core.Rule(nil), phase.Rules...)}
+		index[phase.Name] = i
+	}
+
+	for _, p := range rulePack.Promotions {
+		phaseName := p.Phase
+		if phaseName == "" {
+			phaseName = core.PromotionPhase
+		}
+		i, exists := index[phaseName]
+		if !exists {
+			phases = append(phases, core.RulePhase{Name: phaseName})
+			i = len(phases) - 1
+			index[phaseName] = i
+		}
+		phases[i].Rules = append(phases[i].Rules, core.Rule{
+			ID:        PromotionRulePrefix + p.ID,
+			Phase:     phaseName,
+			Condition: p.Eligibility,
+			Actions:   []core.Action{{Type: "promotion", Params: map[string]interface{}{"id": p.ID}}},
+			Priority:  p.Priority,
+			Enabled:   !p.Disabled,
+		})
+	}
+
+	rulePack.Phases = phases
+	return rulePack, nil
+}
+
+// PromotionIndex indexa as promoções por ID
+func PromotionIndex(promotions []core.Promotion) map[string]core.Promotion {
+	if len(promotions) == 0 {
+		return nil
+	}
+	index := make(map[string]core.Promotion, len(promotions))
+	for _, p := range promotions {
+		index[p.ID] = p
+	}
+	return index
+}
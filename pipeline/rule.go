@@ -12,8 +12,15 @@ var ExecuteActions func(ctx *core.EngineContext, actions []core.Action) ([]core.
 
 // RunRule avalia a condition de uma regra e executa as actions se verdadeira
 func RunRule(ctx *core.EngineContext, rule core.Rule) ([]core.Reason, []core.Violation, error) {
+	if err := checkItemCurrencies(ctx, rule); err != nil {
+		return nil, nil, err
+	}
+
 	// Se não tem condition, sempre executa
 	if rule.Condition == nil || len(rule.Condition) == 0 {
+		if ctx.Coverage != nil {
+			ctx.Coverage.RecordRule(rule.ID, true)
+		}
 		if ExecuteActions == nil {
 			return nil, nil, fmt.Errorf("ExecuteActions not initialized")
 		}
@@ -29,14 +36,21 @@ func RunRule(ctx *core.EngineContext, rule core.Rule) ([]core.Reason, []core.Vio
 	}
 
 	// Avaliar condition com JsonLogic
-	evalData := BuildEvaluationData(ctx)
+	evalData, err := EvaluationData(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build evaluation data for rule %s: %w", rule.ID, err)
+	}
 	shouldExecute, err := operators.EvaluateJsonLogic(rule.Condition, evalData)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to evaluate condition for rule %s: %w", rule.ID, err)
 	}
 
 	// Se condition retornou true, executar actions
-	if shouldExecuteBool, ok := shouldExecute.(bool); ok && shouldExecuteBool {
+	shouldExecuteBool, ok := shouldExecute.(bool)
+	if ctx.Coverage != nil {
+		ctx.Coverage.RecordRule(rule.ID, ok && shouldExecuteBool)
+	}
+	if ok && shouldExecuteBool {
 		if ExecuteActions == nil {
 			return nil, nil, fmt.Errorf("ExecuteActions not initialized")
 		}
@@ -54,74 +68,195 @@ func RunRule(ctx *core.EngineContext, rule core.Rule) ([]core.Reason, []core.Vio
 	return nil, nil, nil
 }
 
-// BuildEvaluationData monta o contexto de dados para avaliação JsonLogic
+// BuildEvaluationData monta o contexto de dados para avaliação JsonLogic.
+// Mantido com a assinatura original: retorna nil se os dados não puderem ser montados
+// (ex: número não finito em um campo); use BuildEvaluationDataWithError para obter o erro.
 func BuildEvaluationData(ctx *core.EngineContext) map[string]interface{} {
+	data, err := BuildEvaluationDataWithError(ctx)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// BuildEvaluationDataWithError monta o contexto de dados para avaliação JsonLogic do zero
+// (sem o cache de EvaluationData), com os valores normalizados (ver operators.NormalizeValue)
+func BuildEvaluationDataWithError(ctx *core.EngineContext) (map[string]interface{}, error) {
+	n := len(ctx.State.Items)
+	items := make([]interface{}, n)
+	itemValues := make([]interface{}, n)
+	itemTotals := make([]interface{}, n)
+	for i := range ctx.State.Items {
+		item, err := buildItemData(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+		itemValues[i], itemTotals[i] = itemProjections(ctx.State.Items[i])
+	}
+	return buildStateData(ctx, items, itemValues, itemTotals)
+}
+
+// EvaluationData retorna os dados de avaliação de ctx.Eval, refazendo só o que as ações
+// invalidaram desde a última leitura (ver core.EvalCache). O resultado é o mesmo de BuildEvaluationDataWithError.
+func EvaluationData(ctx *core.EngineContext) (map[string]interface{}, error) {
+	cache := &ctx.Eval
+	if cache.Data != nil && !cache.Stale {
+		return cache.Data, nil
+	}
+
+	// Itens adicionados ou removidos: montar tudo de novo
+	n := len(ctx.State.Items)
+	if cache.Data == nil || len(cache.Items) != n {
+		cache.Items = make([]interface{}, n)
+		cache.ItemValues = make([]interface{}, n)
+		cache.ItemTotals = make([]interface{}, n)
+		cache.AllItems = true
+	}
+	refresh := func(i int) error {
+		item, err := buildItemData(ctx, i)
+		if err != nil {
+			cache.Invalidate()
+			return err
+		}
+		cache.Items[i] = item
+		cache.ItemValues[i], cache.ItemTotals[i] = itemProjections(ctx.State.Items[i])
+		return nil
+	}
+	if cache.AllItems {
+		for i := 0; i < n; i++ {
+			if err := refresh(i); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		for i := range cache.StaleItems {
+			if i >= 0 && i < n {
+				if err := refresh(i); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	// Parte do estado (barata) e projeções declaradas, que podem depender de qualquer item
+	data, err := buildStateData(ctx, cache.Items, cache.ItemValues, cache.ItemTotals)
+	if err != nil {
+		cache.Invalidate()
+		return nil, err
+	}
+	*cache = core.EvalCache{Data: data, Items: cache.Items, ItemValues: cache.ItemValues, ItemTotals: cache.ItemTotals}
+	return data, nil
+}
+
+// buildStateData monta os dados do estado em torno dos dados já montados dos itens.
+// Os dados ficam na forma do JSON decodificado (operators.NormalizeValue), lida diretamente
+// pelo JsonLogic a cada avaliação.
+func buildStateData(ctx *core.EngineContext, items, itemValues, itemTotals []interface{}) (map[string]interface{}, error) {
 	data := make(map[string]interface{})
 	state := ctx.State
 
 	// Context
+	holidays := make([]interface{}, len(ctx.Context.Holidays))
+	for i, h := range ctx.Context.Holidays {
+		holidays[i] = h
+	}
 	data["context"] = map[string]interface{}{
 		"tenantId": ctx.Context.TenantID,
 		"userId":   ctx.Context.UserID,
 		"locale":   ctx.Context.Locale,
+		"now":      ctx.Context.Now,
+		"timezone": ctx.Context.Timezone,
+		"holidays": holidays,
 	}
 
 	// Fields do estado
 	for k, v := range state.Fields {
-		data[k] = v
+		normalized, err := operators.NormalizeValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", k, err)
+		}
+		data[k] = normalized
+	}
+	if state.Currency != "" {
+		data["currency"] = state.Currency
 	}
 
-	// Totals
-	if state.Totals != (core.Totals{}) {
-		data["totals"] = map[string]interface{}{
-			"subtotal": state.Totals.Subtotal,
-			"discount": state.Totals.Discount,
-			"tax":      state.Totals.Tax,
-			"total":    state.Totals.Total,
-		}
+	// Totals (legados + agregados declarados/gravados, sempre expostos)
+	totals, err := operators.NormalizeValue(state.Totals.Map())
+	if err != nil {
+		return nil, fmt.Errorf("totals: %w", err)
 	}
+	data["totals"] = totals
 
-	// Items - criar array para acesso por índice
-	itemsData := make([]map[string]interface{}, len(state.Items))
-	for i, item := range state.Items {
-		itemData := map[string]interface{}{
-			"id":     item.ID,
-			"amount": item.Amount,
-		}
-		// Fields do item
-		for k, v := range item.Fields {
-			itemData[k] = v
-		}
-		itemsData[i] = itemData
-	}
-	data["items"] = itemsData
-
-	// Helper: itemValues (array de valores dos itens) para facilitar sum(itemValues)
-	itemValues := make([]float64, len(state.Items))
-	itemTotals := make([]float64, len(state.Items))
-	for i, item := range state.Items {
-		var value float64
-		if v, ok := item.Fields["value"]; ok {
-			value = toFloat64(v)
-		} else if v, ok := item.Fields["total"]; ok {
-			value = toFloat64(v)
-		} else if v, ok := item.Fields["itemTotal"]; ok {
-			value = toFloat64(v)
-		} else {
-			value = item.Amount
-		}
-		itemValues[i] = value
-		// itemTotals: prefer itemTotal, then total, value, amount
-		if v, ok := item.Fields["itemTotal"]; ok {
-			itemTotals[i] = toFloat64(v)
-		} else {
-			itemTotals[i] = value
-		}
+	// Items - array para acesso por índice
+	data["items"] = items
+
+	// Tabelas de consulta (operador lookup), cotações (convert) e unidades (toUnit/fromUnit)
+	data[operators.TablesKey] = ctx.Tables
+	data[operators.ExchangeRatesKey] = ctx.ExchangeRates
+	data[operators.UnitsKey] = ctx.Units
+	if ctx.Coverage != nil {
+		data[operators.CoverageKey] = ctx.Coverage
 	}
+
+	// Projeções padrão (legadas): itemValues e itemTotals
+	// Podem ser sobrepostas por projeções com o mesmo nome declaradas no RulePack
 	data["itemValues"] = itemValues
 	data["itemTotals"] = itemTotals
 
-	return data
+	// Projeções declaradas no RulePack
+	if err := applyProjections(ctx, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// buildItemData monta os dados do item i (id, amount, fields, moeda e unidade)
+func buildItemData(ctx *core.EngineContext, i int) (map[string]interface{}, error) {
+	item := ctx.State.Items[i]
+	itemData := map[string]interface{}{
+		"id":     item.ID,
+		"amount": item.Amount,
+	}
+	// Fields do item
+	for k, v := range item.Fields {
+		normalized, err := operators.NormalizeValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: field %s: %w", i, k, err)
+		}
+		itemData[k] = normalized
+	}
+	if currency := ctx.State.ItemCurrency(i); currency != "" {
+		itemData["currency"] = currency
+	}
+	if item.Unit != "" {
+		itemData["unit"] = item.Unit
+		if base, ok := ctx.Units.ItemBaseAmount(item); ok {
+			itemData["baseAmount"] = base
+		}
+	}
+	return itemData, nil
+}
+
+// itemProjections calcula itemValues (value, total, itemTotal ou amount) e itemTotals
+// (itemTotal ou o valor) do item
+func itemProjections(item core.Item) (value, total interface{}) {
+	var v float64
+	if f, ok := item.Fields["value"]; ok {
+		v = toFloat64(f)
+	} else if f, ok := item.Fields["total"]; ok {
+		v = toFloat64(f)
+	} else if f, ok := item.Fields["itemTotal"]; ok {
+		v = toFloat64(f)
+	} else {
+		v = item.Amount
+	}
+	if f, ok := item.Fields["itemTotal"]; ok {
+		return v, toFloat64(f)
+	}
+	return v, v
 }
 
 // toFloat64 converte interface{} para float64
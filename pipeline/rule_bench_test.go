@@ -0,0 +1,47 @@
+package pipeline_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/internal/benchdata"
+	"github.com/dolphin-sistemas/computations-engine/pipeline"
+)
+
+// BenchmarkBuildEvaluationData mede a montagem dos dados de avaliação (feita por condição e por ação)
+func BenchmarkBuildEvaluationData(b *testing.B) {
+	for _, items := range benchdata.ItemCounts {
+		ctx, err := core.NewEngineContext(benchdata.Order(items), benchdata.Context)
+		if err != nil {
+			b.Fatalf("NewEngineContext failed: %v", err)
+		}
+		b.Run(fmt.Sprintf("items=%d", items), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := pipeline.BuildEvaluationDataWithError(ctx); err != nil {
+					b.Fatalf("BuildEvaluationDataWithError failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkEvaluationData mede a leitura reaproveitada depois da escrita em um item (ex: items[0].price)
+func BenchmarkEvaluationData(b *testing.B) {
+	for _, items := range benchdata.ItemCounts {
+		ctx, err := core.NewEngineContext(benchdata.Order(items), benchdata.Context)
+		if err != nil {
+			b.Fatalf("NewEngineContext failed: %v", err)
+		}
+		b.Run(fmt.Sprintf("items=%d", items), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				ctx.Eval.InvalidateItem(0)
+				if _, err := pipeline.EvaluationData(ctx); err != nil {
+					b.Fatalf("EvaluationData failed: %v", err)
+				}
+			}
+		})
+	}
+}
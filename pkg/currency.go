@@ -0,0 +1,46 @@
+package pkg
+
+import "strings"
+
+// currencyMinorUnits são as casas decimais (minor unit) das moedas ISO-4217 ativas
+var currencyMinorUnits = map[string]int{}
+
+func init() {
+	for units, codes := range map[int]string{
+		0: "BIF CLP DJF GNF ISK JPY KMF KRW PYG RWF UGX UYI VND VUV XAF XOF XPF",
+		2: "AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BMD BND BOB BOV BRL BSD BTN BWP " +
+			"BYN BZD CAD CDF CHE CHF CHW CNY COP COU CRC CUC CUP CVE CZK DKK DOP DZD EGP ERN ETB EUR " +
+			"FJD FKP GBP GEL GHS GIP GMD GTQ GYD HKD HNL HTG HUF IDR ILS INR IRR JMD KES KGS KHR KPW " +
+			"KYD KZT LAK LBP LKR LRD LSL MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN " +
+			"NAD NGN NIO NOK NPR NZD PAB PEN PGK PHP PKR PLN QAR RON RSD RUB SAR SBD SCR SDG SEK SGD " +
+			"SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TOP TRY TTD TWD TZS UAH USD USN UYU UZS " +
+			"VED VES WST XCD XCG YER ZAR ZMW ZWG",
+		3: "BHD IQD JOD KWD LYD OMR TND",
+		4: "CLF UYW",
+	} {
+		for _, code := range strings.Fields(codes) {
+			currencyMinorUnits[code] = units
+		}
+	}
+}
+
+// IsCurrency indica se code é uma moeda ISO-4217 ativa (ex: "BRL", "USD")
+func IsCurrency(code string) bool {
+	_, ok := currencyMinorUnits[code]
+	return ok
+}
+
+// CurrencyMinorUnits retorna as casas decimais da moeda (BRL = 2, JPY = 0, KWD = 3)
+func CurrencyMinorUnits(code string) (int, bool) {
+	units, ok := currencyMinorUnits[code]
+	return units, ok
+}
+
+// RoundCurrency arredonda value nas casas decimais da moeda (meio para cima)
+func RoundCurrency(value float64, code string) (float64, bool) {
+	units, ok := currencyMinorUnits[code]
+	if !ok {
+		return 0, false
+	}
+	return Round(value, units), true
+}
@@ -0,0 +1,453 @@
+package pkg
+
+import (
+	"strings"
+)
+
+// Validadores de documentos brasileiros (offline, apenas dígitos verificadores e formato).
+// Pontuação usual (".", "-", "/", espaços) é ignorada.
+
+// IsCPF valida um CPF (11 dígitos, dígitos verificadores módulo 11)
+func IsCPF(value string) bool {
+	d := onlyDigits(value)
+	if len(d) != 11 || allSame(d) {
+		return false
+	}
+	return mod11Digit(d[:9], weightsDesc(10, 9), 2) == d[9] &&
+		mod11Digit(d[:10], weightsDesc(11, 10), 2) == d[10]
+}
+
+// IsCNPJ valida um CNPJ numérico ou alfanumérico (12 caracteres [0-9A-Z] + 2 dígitos verificadores).
+// No formato alfanumérico cada caractere vale seu código ASCII - 48.
+func IsCNPJ(value string) bool {
+	c := strings.ToUpper(stripPunctuation(value))
+	if len(c) != 14 || allSame(c) {
+		return false
+	}
+	for i := 0; i < 14; i++ {
+		ch := c[i]
+		isDigit := ch >= '0' && ch <= '9'
+		if !isDigit && (i >= 12 || ch < 'A' || ch > 'Z') {
+			return false
+		}
+	}
+	dv1 := mod11Digit(c[:12], []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}, 2)
+	dv2 := mod11Digit(c[:13], []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}, 2)
+	return dv1 == c[12] && dv2 == c[13]
+}
+
+// IsCEP valida um CEP (8 dígitos, "01310-100" ou "01310100")
+func IsCEP(value string) bool {
+	s := strings.TrimSpace(value)
+	if len(s) == 9 && s[5] == '-' {
+		s = s[:5] + s[6:]
+	}
+	return len(s) == 8 && isDigits(s) && s != "00000000"
+}
+
+// IsGTIN valida GTIN-8, GTIN-12 (UPC-A), GTIN-13 (EAN-13) ou GTIN-14 pelo dígito verificador módulo 10
+func IsGTIN(value string) bool {
+	s := strings.TrimSpace(value)
+	switch len(s) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+	if !isDigits(s) || allSame(s) {
+		return false
+	}
+	sum := 0
+	for i := len(s) - 2; i >= 0; i-- {
+		n := int(s[i] - '0')
+		if (len(s)-2-i)%2 == 0 {
+			n *= 3
+		}
+		sum += n
+	}
+	return byte('0'+(10-sum%10)%10) == s[len(s)-1]
+}
+
+// IsNCM valida um código NCM (8 dígitos, "8471.30.12" ou "84713012") com capítulo entre 01 e 97 (exceto 77)
+func IsNCM(value string) bool {
+	s := strings.ReplaceAll(strings.TrimSpace(value), ".", "")
+	if len(s) != 8 || !isDigits(s) {
+		return false
+	}
+	chapter := int(s[0]-'0')*10 + int(s[1]-'0')
+	return chapter >= 1 && chapter <= 97 && chapter != 77
+}
+
+// IsCFOP valida um CFOP (4 dígitos, "5.102" ou "5102"): o primeiro dígito indica
+// entrada (1, 2, 3) ou saída (5, 6, 7)
+func IsCFOP(value string) bool {
+	s := strings.ReplaceAll(strings.TrimSpace(value), ".", "")
+	if len(s) != 4 || !isDigits(s) {
+		return false
+	}
+	return strings.IndexByte("123567", s[0]) >= 0 && s[1:] != "000"
+}
+
+// IsIE valida uma inscrição estadual conforme o algoritmo da UF (regras do SINTEGRA).
+// "ISENTO" é aceito para qualquer UF válida.
+func IsIE(uf, value string) bool {
+	uf = strings.ToUpper(strings.TrimSpace(uf))
+	validate, ok := ieValidators[uf]
+	if !ok {
+		return false
+	}
+	if strings.EqualFold(strings.TrimSpace(value), "ISENTO") {
+		return true
+	}
+	s := strings.ToUpper(stripPunctuation(value))
+	if uf == "SP" && strings.HasPrefix(s, "P") {
+		return ieSPProducer(s[1:])
+	}
+	if !isDigits(s) {
+		return false
+	}
+	return validate(s)
+}
+
+// IsUF indica se a sigla corresponde a uma unidade federativa
+func IsUF(uf string) bool {
+	_, ok := ieValidators[strings.ToUpper(strings.TrimSpace(uf))]
+	return ok
+}
+
+var ieValidators = map[string]func(string) bool{
+	"AC": ieAC, "AL": ieAL, "AP": ieAP, "AM": ieAM, "BA": ieBA, "CE": ieCE, "DF": ieDF,
+	"ES": ieES, "GO": ieGO, "MA": ieMA, "MT": ieMT, "MS": ieMS, "MG": ieMG, "PA": iePA,
+	"PB": iePB, "PR": iePR, "PE": iePE, "PI": iePI, "RJ": ieRJ, "RN": ieRN, "RS": ieRS,
+	"RO": ieRO, "RR": ieRR, "SC": ieSC, "SP": ieSP, "SE": ieSE, "TO": ieTO,
+}
+
+// ieStandard cobre as UFs com 9 dígitos e um dígito verificador módulo 11 (pesos 9..2)
+func ieStandard(prefix string) func(string) bool {
+	return func(s string) bool {
+		return len(s) == 9 && strings.HasPrefix(s, prefix) &&
+			mod11Digit(s[:8], weightsDesc(9, 8), 2) == s[8]
+	}
+}
+
+var (
+	ieCE = ieStandard("")
+	ieES = ieStandard("")
+	ieMA = ieStandard("12")
+	iePA = ieStandard("15")
+	iePB = ieStandard("")
+	iePI = ieStandard("")
+	ieSC = ieStandard("")
+	ieSE = ieStandard("")
+)
+
+func ieAC(s string) bool {
+	return len(s) == 13 && strings.HasPrefix(s, "01") && twoDigitsMod11(s)
+}
+
+func ieDF(s string) bool {
+	return len(s) == 13 && strings.HasPrefix(s, "07") && twoDigitsMod11(s)
+}
+
+// twoDigitsMod11 confere os dois últimos dígitos de uma IE de 13 dígitos (AC, DF)
+func twoDigitsMod11(s string) bool {
+	return mod11Digit(s[:11], []int{4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}, 2) == s[11] &&
+		mod11Digit(s[:12], []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}, 2) == s[12]
+}
+
+func ieAL(s string) bool {
+	if len(s) != 9 || !strings.HasPrefix(s, "24") {
+		return false
+	}
+	dv := weightedSum(s[:8], weightsDesc(9, 8)) * 10 % 11
+	return digitOf(dv%10) == s[8]
+}
+
+func ieAP(s string) bool {
+	if len(s) != 9 || !strings.HasPrefix(s, "03") {
+		return false
+	}
+	n := atoi(s[:8])
+	p, d := 0, 0
+	switch {
+	case n >= 3000001 && n <= 3017000:
+		p, d = 5, 0
+	case n >= 3017001 && n <= 3019022:
+		p, d = 9, 1
+	}
+	dv := 11 - (p+weightedSum(s[:8], weightsDesc(9, 8)))%11
+	switch dv {
+	case 10:
+		dv = 0
+	case 11:
+		dv = d
+	}
+	return digitOf(dv) == s[8]
+}
+
+func ieAM(s string) bool {
+	if len(s) != 9 {
+		return false
+	}
+	sum := weightedSum(s[:8], weightsDesc(9, 8))
+	var dv int
+	if sum < 11 {
+		dv = 11 - sum
+	} else if r := sum % 11; r > 1 {
+		dv = 11 - r
+	}
+	return dv < 10 && digitOf(dv) == s[8]
+}
+
+// ieBA: 8 ou 9 dígitos; o segundo dígito verificador é calculado antes do primeiro,
+// em módulo 10 ou 11 conforme o primeiro dígito (8 posições) ou o segundo (9 posições)
+func ieBA(s string) bool {
+	if len(s) != 8 && len(s) != 9 {
+		return false
+	}
+	base := s[:len(s)-2]
+	selector := s[0]
+	if len(s) == 9 {
+		selector = s[1]
+	}
+	mod := 10
+	if selector == '6' || selector == '7' || selector == '9' {
+		mod = 11
+	}
+	check := func(digits string) byte {
+		r := weightedSum(digits, weightsDesc(len(digits)+1, len(digits))) % mod
+		if mod == 10 {
+			return digitOf((10 - r) % 10)
+		}
+		if r < 2 {
+			return '0'
+		}
+		return digitOf(11 - r)
+	}
+	dv2 := check(base)
+	dv1 := check(base + string(dv2))
+	return s[len(s)-2] == dv1 && s[len(s)-1] == dv2
+}
+
+func ieGO(s string) bool {
+	if len(s) != 9 {
+		return false
+	}
+	switch s[:2] {
+	case "10", "11", "15", "20", "21", "22", "23", "24", "25", "26", "27", "28", "29":
+	default:
+		return false
+	}
+	n := atoi(s[:8])
+	if n == 11094402 {
+		return s[8] == '0' || s[8] == '1'
+	}
+	r := weightedSum(s[:8], weightsDesc(9, 8)) % 11
+	dv := 11 - r
+	switch {
+	case r == 0:
+		dv = 0
+	case r == 1 && n >= 10103105 && n <= 10119997:
+		dv = 1
+	case r == 1:
+		dv = 0
+	}
+	return digitOf(dv) == s[8]
+}
+
+func ieMT(s string) bool {
+	if len(s) > 11 {
+		return false
+	}
+	s = strings.Repeat("0", 11-len(s)) + s
+	return mod11Digit(s[:10], []int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2}, 2) == s[10]
+}
+
+func ieMS(s string) bool {
+	if len(s) != 9 || (!strings.HasPrefix(s, "28") && !strings.HasPrefix(s, "50")) {
+		return false
+	}
+	return mod11Digit(s[:8], weightsDesc(9, 8), 1) == s[8]
+}
+
+// ieMG: o primeiro dígito verificador usa o número com "0" inserido após o município,
+// pesos alternados 1 e 2 somando os algarismos dos produtos; o segundo é módulo 11
+func ieMG(s string) bool {
+	if len(s) != 13 {
+		return false
+	}
+	padded := s[:3] + "0" + s[3:11]
+	sum := 0
+	for i := 0; i < len(padded); i++ {
+		p := int(padded[i]-'0') * (1 + i%2)
+		sum += p/10 + p%10
+	}
+	dv1 := digitOf((10 - sum%10) % 10)
+	dv2 := mod11Digit(s[:12], []int{3, 2, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2}, 2)
+	return dv1 == s[11] && dv2 == s[12]
+}
+
+func iePR(s string) bool {
+	return len(s) == 10 &&
+		mod11Digit(s[:8], []int{3, 2, 7, 6, 5, 4, 3, 2}, 2) == s[8] &&
+		mod11Digit(s[:9], []int{4, 3, 2, 7, 6, 5, 4, 3, 2}, 2) == s[9]
+}
+
+// iePE aceita o formato e-Fisco (9 dígitos) e o antigo CACEPE (14 dígitos)
+func iePE(s string) bool {
+	switch len(s) {
+	case 9:
+		return mod11Digit(s[:7], weightsDesc(8, 7), 2) == s[7] &&
+			mod11Digit(s[:8], weightsDesc(9, 8), 2) == s[8]
+	case 14:
+		dv := 11 - weightedSum(s[:13], []int{5, 4, 3, 2, 1, 9, 8, 7, 6, 5, 4, 3, 2})%11
+		return digitOf(dv%10) == s[13]
+	}
+	return false
+}
+
+func ieRJ(s string) bool {
+	return len(s) == 8 && mod11Digit(s[:7], []int{2, 7, 6, 5, 4, 3, 2}, 2) == s[7]
+}
+
+func ieRN(s string) bool {
+	if (len(s) != 9 && len(s) != 10) || !strings.HasPrefix(s, "20") {
+		return false
+	}
+	n := len(s) - 1
+	dv := weightedSum(s[:n], weightsDesc(n+1, n)) * 10 % 11
+	return digitOf(dv%10) == s[n]
+}
+
+func ieRS(s string) bool {
+	return len(s) == 10 && mod11Digit(s[:9], []int{2, 9, 8, 7, 6, 5, 4, 3, 2}, 2) == s[9]
+}
+
+// ieRO aceita o formato atual (14 dígitos) e o anterior a 2000 (9 dígitos, 3 do município)
+func ieRO(s string) bool {
+	var sum int
+	switch len(s) {
+	case 14:
+		sum = weightedSum(s[:13], []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})
+	case 9:
+		sum = weightedSum(s[3:8], weightsDesc(6, 5))
+	default:
+		return false
+	}
+	dv := 11 - sum%11
+	return digitOf(dv%10) == s[len(s)-1]
+}
+
+func ieRR(s string) bool {
+	if len(s) != 9 || !strings.HasPrefix(s, "24") {
+		return false
+	}
+	return digitOf(weightedSum(s[:8], []int{1, 2, 3, 4, 5, 6, 7, 8})%9) == s[8]
+}
+
+// ieSP: comercial/industrial (12 dígitos, verificadores na 9ª e 12ª posições)
+func ieSP(s string) bool {
+	return len(s) == 12 &&
+		spDigit(s[:8], []int{1, 3, 4, 5, 6, 7, 8, 10}) == s[8] &&
+		spDigit(s[:11], []int{3, 2, 10, 9, 8, 7, 6, 5, 4, 3, 2}) == s[11]
+}
+
+// ieSPProducer: produtor rural ("P" + 12 dígitos, verificador na 9ª posição)
+func ieSPProducer(s string) bool {
+	return len(s) == 12 && isDigits(s) && spDigit(s[:8], []int{1, 3, 4, 5, 6, 7, 8, 10}) == s[8]
+}
+
+func spDigit(digits string, weights []int) byte {
+	return digitOf(weightedSum(digits, weights) % 11 % 10)
+}
+
+// ieTO aceita 9 dígitos e o formato antigo de 11 (3º e 4º dígitos = tipo 01, 02, 03 ou 99, fora do cálculo)
+func ieTO(s string) bool {
+	switch len(s) {
+	case 9:
+	case 11:
+		switch s[2:4] {
+		case "01", "02", "03", "99":
+		default:
+			return false
+		}
+		s = s[:2] + s[4:]
+	default:
+		return false
+	}
+	return mod11Digit(s[:8], weightsDesc(9, 8), 2) == s[8]
+}
+
+// mod11Digit calcula o dígito verificador módulo 11: 11 - resto, ou 0 quando o resto é menor que zeroBelow
+// (e também quando 11 - resto não cabe em um dígito)
+func mod11Digit(chars string, weights []int, zeroBelow int) byte {
+	r := weightedSum(chars, weights) % 11
+	if r < zeroBelow || 11-r > 9 {
+		return '0'
+	}
+	return digitOf(11 - r)
+}
+
+// weightedSum soma valor*peso de cada caractere (valor = código ASCII - 48, o que cobre letras do CNPJ)
+func weightedSum(chars string, weights []int) int {
+	sum := 0
+	for i := 0; i < len(chars) && i < len(weights); i++ {
+		sum += int(chars[i]-'0') * weights[i]
+	}
+	return sum
+}
+
+// weightsDesc retorna n pesos decrescentes a partir de start (ex: 9, 8 -> 9..2)
+func weightsDesc(start, n int) []int {
+	weights := make([]int, n)
+	for i := range weights {
+		weights[i] = start - i
+	}
+	return weights
+}
+
+func digitOf(n int) byte {
+	return byte('0' + n)
+}
+
+func atoi(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	return n
+}
+
+func onlyDigits(value string) string {
+	s := stripPunctuation(value)
+	if !isDigits(s) {
+		return ""
+	}
+	return s
+}
+
+// stripPunctuation remove a pontuação usual de documentos (".", "-", "/", espaços)
+func stripPunctuation(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '-', '/', ' ':
+			return -1
+		}
+		return r
+	}, value)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func allSame(s string) bool {
+	return strings.Count(s, s[:1]) == len(s)
+}
@@ -0,0 +1,56 @@
+package pkg
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// formatPath escreve os passos na forma canônica aceita por ParsePath
+func formatPath(steps []PathStep) string {
+	parts := make([]string, len(steps))
+	for i, s := range steps {
+		parts[i] = s.Key
+		switch {
+		case s.Wildcard:
+			parts[i] += "[*]"
+		case s.HasIndex:
+			parts[i] += "[" + strconv.Itoa(s.Index) + "]"
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func FuzzParsePath(f *testing.F) {
+	for _, seed := range []string{
+		"", "totals.total", "items[*].fields.negotiations[*].percent", "items[0].fields.price",
+		"items[ * ].x", " a . b ", "a..b", "items[", "items[]", "[0]", "a[-1]", "a[1]]", "a[+3]", "a]b", "a[99999999999999999999]",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, path string) {
+		steps, err := ParsePath(path)
+		if err != nil {
+			return
+		}
+		for _, s := range steps {
+			if s.Key == "" || s.Key != strings.TrimSpace(s.Key) || strings.ContainsAny(s.Key, ".[") {
+				t.Fatalf("ParsePath(%q): invalid key %q", path, s.Key)
+			}
+			if s.Wildcard && s.HasIndex || s.Index < 0 {
+				t.Fatalf("ParsePath(%q): invalid step %+v", path, s)
+			}
+		}
+
+		// Ida e volta: a forma canônica produz os mesmos passos
+		again, err := ParsePath(formatPath(steps))
+		if err != nil {
+			t.Fatalf("ParsePath(%q) = %+v, but canonical %q fails: %v", path, steps, formatPath(steps), err)
+		}
+		if len(steps) != len(again) || (len(steps) > 0 && !reflect.DeepEqual(steps, again)) {
+			t.Fatalf("round trip of %q: %+v != %+v", path, steps, again)
+		}
+	})
+}
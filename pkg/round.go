@@ -0,0 +1,13 @@
+package pkg
+
+import "math"
+
+// Round arredonda value para decimals casas (meio para cima, afastando do zero).
+// Ruído de representação binária é descartado antes (ex: 0.285 * 100 = 28.499999999999996 -> 28.5),
+// para que valores monetários arredondem como em decimal.
+func Round(value float64, decimals int) float64 {
+	m := math.Pow(10, float64(decimals))
+	scaled := value * m
+	scaled = math.Round(scaled*1e6) / 1e6
+	return math.Round(scaled) / m
+}
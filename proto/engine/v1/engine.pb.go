@@ -0,0 +1,2442 @@
+// Serviço gRPC do motor de regras.
+//
+// As mensagens espelham os tipos JSON de core (State, RulePack, ContextMeta, RunEngineResult):
+// os nomes JSON (protojson) são os mesmos, e os trechos livres (fields, JsonLogic, linhas de
+// tabela, stateFragment) usam google.protobuf.Struct/Value.
+//
+// Gerar o código Go: make proto (protoc + protoc-gen-go + protoc-gen-go-grpc)
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: engine/v1/engine.proto
+
+package enginev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type State struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Items         []*Item                `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	Totals        map[string]float64     `protobuf:"bytes,5,rep,name=totals,proto3" json:"totals,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Fields        *structpb.Struct       `protobuf:"bytes,6,opt,name=fields,proto3" json:"fields,omitempty"`
+	Meta          *structpb.Struct       `protobuf:"bytes,7,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *State) Reset() {
+	*x = State{}
+	mi := &file_engine_v1_engine_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *State) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*State) ProtoMessage() {}
+
+func (x *State) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use State.ProtoReflect.Descriptor instead.
+func (*State) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *State) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *State) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *State) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *State) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *State) GetTotals() map[string]float64 {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *State) GetFields() *structpb.Struct {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *State) GetMeta() *structpb.Struct {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type Item struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Unit          string                 `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	Fields        *structpb.Struct       `protobuf:"bytes,5,opt,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_engine_v1_engine_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Item) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Item) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Item) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Item) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *Item) GetFields() *structpb.Struct {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type RulePack struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Phases        []*RulePhase           `protobuf:"bytes,3,rep,name=phases,proto3" json:"phases,omitempty"`
+	Totals        []*TotalDef            `protobuf:"bytes,4,rep,name=totals,proto3" json:"totals,omitempty"`
+	Projections   map[string]*Projection `protobuf:"bytes,5,rep,name=projections,proto3" json:"projections,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Tables        map[string]*Table      `protobuf:"bytes,6,rep,name=tables,proto3" json:"tables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Promotions    []*Promotion           `protobuf:"bytes,7,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	ExchangeRates map[string]float64     `protobuf:"bytes,8,rep,name=exchange_rates,json=exchangeRates,proto3" json:"exchange_rates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Units         map[string]*UnitTable  `protobuf:"bytes,9,rep,name=units,proto3" json:"units,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RulePack) Reset() {
+	*x = RulePack{}
+	mi := &file_engine_v1_engine_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RulePack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RulePack) ProtoMessage() {}
+
+func (x *RulePack) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RulePack.ProtoReflect.Descriptor instead.
+func (*RulePack) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RulePack) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RulePack) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RulePack) GetPhases() []*RulePhase {
+	if x != nil {
+		return x.Phases
+	}
+	return nil
+}
+
+func (x *RulePack) GetTotals() []*TotalDef {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *RulePack) GetProjections() map[string]*Projection {
+	if x != nil {
+		return x.Projections
+	}
+	return nil
+}
+
+func (x *RulePack) GetTables() map[string]*Table {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *RulePack) GetPromotions() []*Promotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+func (x *RulePack) GetExchangeRates() map[string]float64 {
+	if x != nil {
+		return x.ExchangeRates
+	}
+	return nil
+}
+
+func (x *RulePack) GetUnits() map[string]*UnitTable {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+type RulePhase struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rules         []*Rule                `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RulePhase) Reset() {
+	*x = RulePhase{}
+	mi := &file_engine_v1_engine_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RulePhase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RulePhase) ProtoMessage() {}
+
+func (x *RulePhase) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RulePhase.ProtoReflect.Descriptor instead.
+func (*RulePhase) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RulePhase) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RulePhase) GetRules() []*Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type Rule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Phase         string                 `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	Condition     *structpb.Struct       `protobuf:"bytes,3,opt,name=condition,proto3" json:"condition,omitempty"`
+	Actions       []*Action              `protobuf:"bytes,4,rep,name=actions,proto3" json:"actions,omitempty"`
+	Priority      int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Enabled       bool                   `protobuf:"varint,6,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rule) Reset() {
+	*x = Rule{}
+	mi := &file_engine_v1_engine_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rule) ProtoMessage() {}
+
+func (x *Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
+func (*Rule) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Rule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Rule) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *Rule) GetCondition() *structpb.Struct {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+func (x *Rule) GetActions() []*Action {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *Rule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Rule) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type Action struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Value         *structpb.Value        `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Logic         *structpb.Struct       `protobuf:"bytes,4,opt,name=logic,proto3" json:"logic,omitempty"`
+	Params        *structpb.Struct       `protobuf:"bytes,5,opt,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Action) Reset() {
+	*x = Action{}
+	mi := &file_engine_v1_engine_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Action) ProtoMessage() {}
+
+func (x *Action) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Action.ProtoReflect.Descriptor instead.
+func (*Action) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Action) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Action) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Action) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Action) GetLogic() *structpb.Struct {
+	if x != nil {
+		return x.Logic
+	}
+	return nil
+}
+
+func (x *Action) GetParams() *structpb.Struct {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type TotalDef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Precision     *int32                 `protobuf:"varint,3,opt,name=precision,proto3,oneof" json:"precision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TotalDef) Reset() {
+	*x = TotalDef{}
+	mi := &file_engine_v1_engine_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TotalDef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TotalDef) ProtoMessage() {}
+
+func (x *TotalDef) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TotalDef.ProtoReflect.Descriptor instead.
+func (*TotalDef) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TotalDef) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TotalDef) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TotalDef) GetPrecision() int32 {
+	if x != nil && x.Precision != nil {
+		return *x.Precision
+	}
+	return 0
+}
+
+type Projection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Logic         *structpb.Struct       `protobuf:"bytes,2,opt,name=logic,proto3" json:"logic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Projection) Reset() {
+	*x = Projection{}
+	mi := &file_engine_v1_engine_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Projection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Projection) ProtoMessage() {}
+
+func (x *Projection) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Projection.ProtoReflect.Descriptor instead.
+func (*Projection) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Projection) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Projection) GetLogic() *structpb.Struct {
+	if x != nil {
+		return x.Logic
+	}
+	return nil
+}
+
+type Table struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Columns       []*TableColumn         `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	Rows          []*structpb.Struct     `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Table) Reset() {
+	*x = Table{}
+	mi := &file_engine_v1_engine_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Table) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Table) ProtoMessage() {}
+
+func (x *Table) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Table.ProtoReflect.Descriptor instead.
+func (*Table) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Table) GetColumns() []*TableColumn {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *Table) GetRows() []*structpb.Struct {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *Table) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type TableColumn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Match         string                 `protobuf:"bytes,3,opt,name=match,proto3" json:"match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableColumn) Reset() {
+	*x = TableColumn{}
+	mi := &file_engine_v1_engine_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableColumn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableColumn) ProtoMessage() {}
+
+func (x *TableColumn) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableColumn.ProtoReflect.Descriptor instead.
+func (*TableColumn) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TableColumn) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TableColumn) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TableColumn) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+type Promotion struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type            string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Phase           string                 `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`
+	Priority        int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Disabled        bool                   `protobuf:"varint,6,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	Coupon          string                 `protobuf:"bytes,7,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	Eligibility     *structpb.Struct       `protobuf:"bytes,8,opt,name=eligibility,proto3" json:"eligibility,omitempty"`
+	Items           *structpb.Struct       `protobuf:"bytes,9,opt,name=items,proto3" json:"items,omitempty"`
+	MinSubtotal     float64                `protobuf:"fixed64,10,opt,name=min_subtotal,json=minSubtotal,proto3" json:"min_subtotal,omitempty"`
+	Exclusive       bool                   `protobuf:"varint,11,opt,name=exclusive,proto3" json:"exclusive,omitempty"`
+	UsageLimit      int32                  `protobuf:"varint,12,opt,name=usage_limit,json=usageLimit,proto3" json:"usage_limit,omitempty"`
+	MaxApplications int32                  `protobuf:"varint,13,opt,name=max_applications,json=maxApplications,proto3" json:"max_applications,omitempty"`
+	Percent         float64                `protobuf:"fixed64,14,opt,name=percent,proto3" json:"percent,omitempty"`
+	Amount          float64                `protobuf:"fixed64,15,opt,name=amount,proto3" json:"amount,omitempty"`
+	MaxDiscount     float64                `protobuf:"fixed64,16,opt,name=max_discount,json=maxDiscount,proto3" json:"max_discount,omitempty"`
+	Buy             int32                  `protobuf:"varint,17,opt,name=buy,proto3" json:"buy,omitempty"`
+	Get             int32                  `protobuf:"varint,18,opt,name=get,proto3" json:"get,omitempty"`
+	ShippingTarget  string                 `protobuf:"bytes,19,opt,name=shipping_target,json=shippingTarget,proto3" json:"shipping_target,omitempty"`
+	Base            *structpb.Struct       `protobuf:"bytes,20,opt,name=base,proto3" json:"base,omitempty"`
+	DiscountField   string                 `protobuf:"bytes,21,opt,name=discount_field,json=discountField,proto3" json:"discount_field,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_engine_v1_engine_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Promotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Promotion) ProtoMessage() {}
+
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Promotion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Promotion) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Promotion) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Promotion) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *Promotion) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Promotion) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *Promotion) GetCoupon() string {
+	if x != nil {
+		return x.Coupon
+	}
+	return ""
+}
+
+func (x *Promotion) GetEligibility() *structpb.Struct {
+	if x != nil {
+		return x.Eligibility
+	}
+	return nil
+}
+
+func (x *Promotion) GetItems() *structpb.Struct {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Promotion) GetMinSubtotal() float64 {
+	if x != nil {
+		return x.MinSubtotal
+	}
+	return 0
+}
+
+func (x *Promotion) GetExclusive() bool {
+	if x != nil {
+		return x.Exclusive
+	}
+	return false
+}
+
+func (x *Promotion) GetUsageLimit() int32 {
+	if x != nil {
+		return x.UsageLimit
+	}
+	return 0
+}
+
+func (x *Promotion) GetMaxApplications() int32 {
+	if x != nil {
+		return x.MaxApplications
+	}
+	return 0
+}
+
+func (x *Promotion) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *Promotion) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Promotion) GetMaxDiscount() float64 {
+	if x != nil {
+		return x.MaxDiscount
+	}
+	return 0
+}
+
+func (x *Promotion) GetBuy() int32 {
+	if x != nil {
+		return x.Buy
+	}
+	return 0
+}
+
+func (x *Promotion) GetGet() int32 {
+	if x != nil {
+		return x.Get
+	}
+	return 0
+}
+
+func (x *Promotion) GetShippingTarget() string {
+	if x != nil {
+		return x.ShippingTarget
+	}
+	return ""
+}
+
+func (x *Promotion) GetBase() *structpb.Struct {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *Promotion) GetDiscountField() string {
+	if x != nil {
+		return x.DiscountField
+	}
+	return ""
+}
+
+type UnitTable struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          string                 `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Units         map[string]*UnitDef    `protobuf:"bytes,2,rep,name=units,proto3" json:"units,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnitTable) Reset() {
+	*x = UnitTable{}
+	mi := &file_engine_v1_engine_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnitTable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnitTable) ProtoMessage() {}
+
+func (x *UnitTable) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnitTable.ProtoReflect.Descriptor instead.
+func (*UnitTable) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UnitTable) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *UnitTable) GetUnits() map[string]*UnitDef {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+type UnitDef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Factor        float64                `protobuf:"fixed64,1,opt,name=factor,proto3" json:"factor,omitempty"`
+	Indivisible   bool                   `protobuf:"varint,2,opt,name=indivisible,proto3" json:"indivisible,omitempty"`
+	Decimals      *int32                 `protobuf:"varint,3,opt,name=decimals,proto3,oneof" json:"decimals,omitempty"`
+	Rounding      string                 `protobuf:"bytes,4,opt,name=rounding,proto3" json:"rounding,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnitDef) Reset() {
+	*x = UnitDef{}
+	mi := &file_engine_v1_engine_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnitDef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnitDef) ProtoMessage() {}
+
+func (x *UnitDef) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnitDef.ProtoReflect.Descriptor instead.
+func (*UnitDef) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UnitDef) GetFactor() float64 {
+	if x != nil {
+		return x.Factor
+	}
+	return 0
+}
+
+func (x *UnitDef) GetIndivisible() bool {
+	if x != nil {
+		return x.Indivisible
+	}
+	return false
+}
+
+func (x *UnitDef) GetDecimals() int32 {
+	if x != nil && x.Decimals != nil {
+		return *x.Decimals
+	}
+	return 0
+}
+
+func (x *UnitDef) GetRounding() string {
+	if x != nil {
+		return x.Rounding
+	}
+	return ""
+}
+
+type ContextMeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	Now           string                 `protobuf:"bytes,4,opt,name=now,proto3" json:"now,omitempty"`
+	Timezone      string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Holidays      []string               `protobuf:"bytes,6,rep,name=holidays,proto3" json:"holidays,omitempty"`
+	ExchangeRates map[string]float64     `protobuf:"bytes,7,rep,name=exchange_rates,json=exchangeRates,proto3" json:"exchange_rates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContextMeta) Reset() {
+	*x = ContextMeta{}
+	mi := &file_engine_v1_engine_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContextMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContextMeta) ProtoMessage() {}
+
+func (x *ContextMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContextMeta.ProtoReflect.Descriptor instead.
+func (*ContextMeta) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ContextMeta) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ContextMeta) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ContextMeta) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ContextMeta) GetNow() string {
+	if x != nil {
+		return x.Now
+	}
+	return ""
+}
+
+func (x *ContextMeta) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ContextMeta) GetHolidays() []string {
+	if x != nil {
+		return x.Holidays
+	}
+	return nil
+}
+
+func (x *ContextMeta) GetExchangeRates() map[string]float64 {
+	if x != nil {
+		return x.ExchangeRates
+	}
+	return nil
+}
+
+type RunEngineResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StateFragment *structpb.Struct       `protobuf:"bytes,1,opt,name=state_fragment,json=stateFragment,proto3" json:"state_fragment,omitempty"`
+	ServerDelta   *structpb.Struct       `protobuf:"bytes,2,opt,name=server_delta,json=serverDelta,proto3" json:"server_delta,omitempty"`
+	Reasons       []*Reason              `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	Violations    []*Violation           `protobuf:"bytes,4,rep,name=violations,proto3" json:"violations,omitempty"`
+	RulesVersion  string                 `protobuf:"bytes,5,opt,name=rules_version,json=rulesVersion,proto3" json:"rules_version,omitempty"`
+	Promotions    []*AppliedPromotion    `protobuf:"bytes,6,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunEngineResult) Reset() {
+	*x = RunEngineResult{}
+	mi := &file_engine_v1_engine_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunEngineResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunEngineResult) ProtoMessage() {}
+
+func (x *RunEngineResult) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunEngineResult.ProtoReflect.Descriptor instead.
+func (*RunEngineResult) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RunEngineResult) GetStateFragment() *structpb.Struct {
+	if x != nil {
+		return x.StateFragment
+	}
+	return nil
+}
+
+func (x *RunEngineResult) GetServerDelta() *structpb.Struct {
+	if x != nil {
+		return x.ServerDelta
+	}
+	return nil
+}
+
+func (x *RunEngineResult) GetReasons() []*Reason {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *RunEngineResult) GetViolations() []*Violation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+func (x *RunEngineResult) GetRulesVersion() string {
+	if x != nil {
+		return x.RulesVersion
+	}
+	return ""
+}
+
+func (x *RunEngineResult) GetPromotions() []*AppliedPromotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+type Reason struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Phase         string                 `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reason) Reset() {
+	*x = Reason{}
+	mi := &file_engine_v1_engine_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reason) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reason) ProtoMessage() {}
+
+func (x *Reason) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reason.ProtoReflect.Descriptor instead.
+func (*Reason) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Reason) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *Reason) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *Reason) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type Violation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Violation) Reset() {
+	*x = Violation{}
+	mi := &file_engine_v1_engine_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Violation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Violation) ProtoMessage() {}
+
+func (x *Violation) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Violation.ProtoReflect.Descriptor instead.
+func (*Violation) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Violation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Violation) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Violation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type AppliedPromotion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Coupon        string                 `protobuf:"bytes,3,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Items         []*PromotionItem       `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppliedPromotion) Reset() {
+	*x = AppliedPromotion{}
+	mi := &file_engine_v1_engine_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppliedPromotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppliedPromotion) ProtoMessage() {}
+
+func (x *AppliedPromotion) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppliedPromotion.ProtoReflect.Descriptor instead.
+func (*AppliedPromotion) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AppliedPromotion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AppliedPromotion) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AppliedPromotion) GetCoupon() string {
+	if x != nil {
+		return x.Coupon
+	}
+	return ""
+}
+
+func (x *AppliedPromotion) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AppliedPromotion) GetItems() []*PromotionItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type PromotionItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromotionItem) Reset() {
+	*x = PromotionItem{}
+	mi := &file_engine_v1_engine_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromotionItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromotionItem) ProtoMessage() {}
+
+func (x *PromotionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromotionItem.ProtoReflect.Descriptor instead.
+func (*PromotionItem) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PromotionItem) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *PromotionItem) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type RunEngineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         *State                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	RulePack      *RulePack              `protobuf:"bytes,2,opt,name=rule_pack,json=rulePack,proto3" json:"rule_pack,omitempty"`
+	Context       *ContextMeta           `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunEngineRequest) Reset() {
+	*x = RunEngineRequest{}
+	mi := &file_engine_v1_engine_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunEngineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunEngineRequest) ProtoMessage() {}
+
+func (x *RunEngineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunEngineRequest.ProtoReflect.Descriptor instead.
+func (*RunEngineRequest) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RunEngineRequest) GetState() *State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *RunEngineRequest) GetRulePack() *RulePack {
+	if x != nil {
+		return x.RulePack
+	}
+	return nil
+}
+
+func (x *RunEngineRequest) GetContext() *ContextMeta {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+type RunEngineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *RunEngineResult       `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunEngineResponse) Reset() {
+	*x = RunEngineResponse{}
+	mi := &file_engine_v1_engine_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunEngineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunEngineResponse) ProtoMessage() {}
+
+func (x *RunEngineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunEngineResponse.ProtoReflect.Descriptor instead.
+func (*RunEngineResponse) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RunEngineResponse) GetResult() *RunEngineResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type ValidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RulePack      *RulePack              `protobuf:"bytes,1,opt,name=rule_pack,json=rulePack,proto3" json:"rule_pack,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_engine_v1_engine_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ValidateRequest) GetRulePack() *RulePack {
+	if x != nil {
+		return x.RulePack
+	}
+	return nil
+}
+
+type ValidateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Version       string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // motivo quando valid = false
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_engine_v1_engine_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResponse) ProtoMessage() {}
+
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ValidateResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ExplainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         *State                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	RulePack      *RulePack              `protobuf:"bytes,2,opt,name=rule_pack,json=rulePack,proto3" json:"rule_pack,omitempty"`
+	Context       *ContextMeta           `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainRequest) Reset() {
+	*x = ExplainRequest{}
+	mi := &file_engine_v1_engine_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainRequest) ProtoMessage() {}
+
+func (x *ExplainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainRequest.ProtoReflect.Descriptor instead.
+func (*ExplainRequest) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ExplainRequest) GetState() *State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *ExplainRequest) GetRulePack() *RulePack {
+	if x != nil {
+		return x.RulePack
+	}
+	return nil
+}
+
+func (x *ExplainRequest) GetContext() *ContextMeta {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+type ExplainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*RuleExplanation     `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	Violations    []*Violation           `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	RulesVersion  string                 `protobuf:"bytes,3,opt,name=rules_version,json=rulesVersion,proto3" json:"rules_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainResponse) Reset() {
+	*x = ExplainResponse{}
+	mi := &file_engine_v1_engine_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainResponse) ProtoMessage() {}
+
+func (x *ExplainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainResponse.ProtoReflect.Descriptor instead.
+func (*ExplainResponse) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ExplainResponse) GetRules() []*RuleExplanation {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *ExplainResponse) GetViolations() []*Violation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+func (x *ExplainResponse) GetRulesVersion() string {
+	if x != nil {
+		return x.RulesVersion
+	}
+	return ""
+}
+
+// RuleExplanation é uma regra do RulePack (inclusive as compiladas de promoções) na ordem de execução
+type RuleExplanation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Phase         string                 `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	Priority      int32                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	Enabled       bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Fired         bool                   `protobuf:"varint,5,opt,name=fired,proto3" json:"fired,omitempty"`      // as ações executaram e registraram reasons (violações vêm em ExplainResponse.violations)
+	Messages      []string               `protobuf:"bytes,6,rep,name=messages,proto3" json:"messages,omitempty"` // mensagens (reasons) das ações
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleExplanation) Reset() {
+	*x = RuleExplanation{}
+	mi := &file_engine_v1_engine_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleExplanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleExplanation) ProtoMessage() {}
+
+func (x *RuleExplanation) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleExplanation.ProtoReflect.Descriptor instead.
+func (*RuleExplanation) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RuleExplanation) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *RuleExplanation) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *RuleExplanation) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *RuleExplanation) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *RuleExplanation) GetFired() bool {
+	if x != nil {
+		return x.Fired
+	}
+	return false
+}
+
+func (x *RuleExplanation) GetMessages() []string {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type BatchRunRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RulePack      *RulePack              `protobuf:"bytes,1,opt,name=rule_pack,json=rulePack,proto3" json:"rule_pack,omitempty"`
+	Context       *ContextMeta           `protobuf:"bytes,2,opt,name=context,proto3" json:"context,omitempty"`
+	States        []*State               `protobuf:"bytes,3,rep,name=states,proto3" json:"states,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRunRequest) Reset() {
+	*x = BatchRunRequest{}
+	mi := &file_engine_v1_engine_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRunRequest) ProtoMessage() {}
+
+func (x *BatchRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRunRequest.ProtoReflect.Descriptor instead.
+func (*BatchRunRequest) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BatchRunRequest) GetRulePack() *RulePack {
+	if x != nil {
+		return x.RulePack
+	}
+	return nil
+}
+
+func (x *BatchRunRequest) GetContext() *ContextMeta {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *BatchRunRequest) GetStates() []*State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type BatchRunResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // posição do State em BatchRunRequest.states
+	Result        *RunEngineResult       `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRunResponse) Reset() {
+	*x = BatchRunResponse{}
+	mi := &file_engine_v1_engine_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRunResponse) ProtoMessage() {}
+
+func (x *BatchRunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRunResponse.ProtoReflect.Descriptor instead.
+func (*BatchRunResponse) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BatchRunResponse) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchRunResponse) GetResult() *RunEngineResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *BatchRunResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // código gRPC (ex: InvalidArgument)
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_engine_v1_engine_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_v1_engine_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_engine_v1_engine_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Error) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_engine_v1_engine_proto protoreflect.FileDescriptor
+
+const file_engine_v1_engine_proto_rawDesc = "" +
+	"\n" +
+	"\x16engine/v1/engine.proto\x12\x16computations.engine.v1\x1a\x1cgoogle/protobuf/struct.proto\"\xe0\x02\n" +
+	"\x05State\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x122\n" +
+	"\x05items\x18\x04 \x03(\v2\x1c.computations.engine.v1.ItemR\x05items\x12A\n" +
+	"\x06totals\x18\x05 \x03(\v2).computations.engine.v1.State.TotalsEntryR\x06totals\x12/\n" +
+	"\x06fields\x18\x06 \x01(\v2\x17.google.protobuf.StructR\x06fields\x12+\n" +
+	"\x04meta\x18\a \x01(\v2\x17.google.protobuf.StructR\x04meta\x1a9\n" +
+	"\vTotalsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\x8f\x01\n" +
+	"\x04Item\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04unit\x18\x04 \x01(\tR\x04unit\x12/\n" +
+	"\x06fields\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x06fields\"\x83\a\n" +
+	"\bRulePack\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x129\n" +
+	"\x06phases\x18\x03 \x03(\v2!.computations.engine.v1.RulePhaseR\x06phases\x128\n" +
+	"\x06totals\x18\x04 \x03(\v2 .computations.engine.v1.TotalDefR\x06totals\x12S\n" +
+	"\vprojections\x18\x05 \x03(\v21.computations.engine.v1.RulePack.ProjectionsEntryR\vprojections\x12D\n" +
+	"\x06tables\x18\x06 \x03(\v2,.computations.engine.v1.RulePack.TablesEntryR\x06tables\x12A\n" +
+	"\n" +
+	"promotions\x18\a \x03(\v2!.computations.engine.v1.PromotionR\n" +
+	"promotions\x12Z\n" +
+	"\x0eexchange_rates\x18\b \x03(\v23.computations.engine.v1.RulePack.ExchangeRatesEntryR\rexchangeRates\x12A\n" +
+	"\x05units\x18\t \x03(\v2+.computations.engine.v1.RulePack.UnitsEntryR\x05units\x1ab\n" +
+	"\x10ProjectionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x128\n" +
+	"\x05value\x18\x02 \x01(\v2\".computations.engine.v1.ProjectionR\x05value:\x028\x01\x1aX\n" +
+	"\vTablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x123\n" +
+	"\x05value\x18\x02 \x01(\v2\x1d.computations.engine.v1.TableR\x05value:\x028\x01\x1a@\n" +
+	"\x12ExchangeRatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a[\n" +
+	"\n" +
+	"UnitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x127\n" +
+	"\x05value\x18\x02 \x01(\v2!.computations.engine.v1.UnitTableR\x05value:\x028\x01\"S\n" +
+	"\tRulePhase\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x122\n" +
+	"\x05rules\x18\x02 \x03(\v2\x1c.computations.engine.v1.RuleR\x05rules\"\xd3\x01\n" +
+	"\x04Rule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05phase\x18\x02 \x01(\tR\x05phase\x125\n" +
+	"\tcondition\x18\x03 \x01(\v2\x17.google.protobuf.StructR\tcondition\x128\n" +
+	"\aactions\x18\x04 \x03(\v2\x1e.computations.engine.v1.ActionR\aactions\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x12\x18\n" +
+	"\aenabled\x18\x06 \x01(\bR\aenabled\"\xc2\x01\n" +
+	"\x06Action\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12,\n" +
+	"\x05value\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x05value\x12-\n" +
+	"\x05logic\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x05logic\x12/\n" +
+	"\x06params\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x06params\"c\n" +
+	"\bTotalDef\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12!\n" +
+	"\tprecision\x18\x03 \x01(\x05H\x00R\tprecision\x88\x01\x01B\f\n" +
+	"\n" +
+	"_precision\"O\n" +
+	"\n" +
+	"Projection\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12-\n" +
+	"\x05logic\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x05logic\"\x8b\x01\n" +
+	"\x05Table\x12=\n" +
+	"\acolumns\x18\x01 \x03(\v2#.computations.engine.v1.TableColumnR\acolumns\x12+\n" +
+	"\x04rows\x18\x02 \x03(\v2\x17.google.protobuf.StructR\x04rows\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\"K\n" +
+	"\vTableColumn\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05match\x18\x03 \x01(\tR\x05match\"\xa4\x05\n" +
+	"\tPromotion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05phase\x18\x04 \x01(\tR\x05phase\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x12\x1a\n" +
+	"\bdisabled\x18\x06 \x01(\bR\bdisabled\x12\x16\n" +
+	"\x06coupon\x18\a \x01(\tR\x06coupon\x129\n" +
+	"\veligibility\x18\b \x01(\v2\x17.google.protobuf.StructR\veligibility\x12-\n" +
+	"\x05items\x18\t \x01(\v2\x17.google.protobuf.StructR\x05items\x12!\n" +
+	"\fmin_subtotal\x18\n" +
+	" \x01(\x01R\vminSubtotal\x12\x1c\n" +
+	"\texclusive\x18\v \x01(\bR\texclusive\x12\x1f\n" +
+	"\vusage_limit\x18\f \x01(\x05R\n" +
+	"usageLimit\x12)\n" +
+	"\x10max_applications\x18\r \x01(\x05R\x0fmaxApplications\x12\x18\n" +
+	"\apercent\x18\x0e \x01(\x01R\apercent\x12\x16\n" +
+	"\x06amount\x18\x0f \x01(\x01R\x06amount\x12!\n" +
+	"\fmax_discount\x18\x10 \x01(\x01R\vmaxDiscount\x12\x10\n" +
+	"\x03buy\x18\x11 \x01(\x05R\x03buy\x12\x10\n" +
+	"\x03get\x18\x12 \x01(\x05R\x03get\x12'\n" +
+	"\x0fshipping_target\x18\x13 \x01(\tR\x0eshippingTarget\x12+\n" +
+	"\x04base\x18\x14 \x01(\v2\x17.google.protobuf.StructR\x04base\x12%\n" +
+	"\x0ediscount_field\x18\x15 \x01(\tR\rdiscountField\"\xbe\x01\n" +
+	"\tUnitTable\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\x12B\n" +
+	"\x05units\x18\x02 \x03(\v2,.computations.engine.v1.UnitTable.UnitsEntryR\x05units\x1aY\n" +
+	"\n" +
+	"UnitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x125\n" +
+	"\x05value\x18\x02 \x01(\v2\x1f.computations.engine.v1.UnitDefR\x05value:\x028\x01\"\x8d\x01\n" +
+	"\aUnitDef\x12\x16\n" +
+	"\x06factor\x18\x01 \x01(\x01R\x06factor\x12 \n" +
+	"\vindivisible\x18\x02 \x01(\bR\vindivisible\x12\x1f\n" +
+	"\bdecimals\x18\x03 \x01(\x05H\x00R\bdecimals\x88\x01\x01\x12\x1a\n" +
+	"\brounding\x18\x04 \x01(\tR\broundingB\v\n" +
+	"\t_decimals\"\xc6\x02\n" +
+	"\vContextMeta\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\x12\x10\n" +
+	"\x03now\x18\x04 \x01(\tR\x03now\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12\x1a\n" +
+	"\bholidays\x18\x06 \x03(\tR\bholidays\x12]\n" +
+	"\x0eexchange_rates\x18\a \x03(\v26.computations.engine.v1.ContextMeta.ExchangeRatesEntryR\rexchangeRates\x1a@\n" +
+	"\x12ExchangeRatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xf9\x02\n" +
+	"\x0fRunEngineResult\x12>\n" +
+	"\x0estate_fragment\x18\x01 \x01(\v2\x17.google.protobuf.StructR\rstateFragment\x12:\n" +
+	"\fserver_delta\x18\x02 \x01(\v2\x17.google.protobuf.StructR\vserverDelta\x128\n" +
+	"\areasons\x18\x03 \x03(\v2\x1e.computations.engine.v1.ReasonR\areasons\x12A\n" +
+	"\n" +
+	"violations\x18\x04 \x03(\v2!.computations.engine.v1.ViolationR\n" +
+	"violations\x12#\n" +
+	"\rrules_version\x18\x05 \x01(\tR\frulesVersion\x12H\n" +
+	"\n" +
+	"promotions\x18\x06 \x03(\v2(.computations.engine.v1.AppliedPromotionR\n" +
+	"promotions\"Q\n" +
+	"\x06Reason\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x14\n" +
+	"\x05phase\x18\x02 \x01(\tR\x05phase\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"O\n" +
+	"\tViolation\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xa3\x01\n" +
+	"\x10AppliedPromotion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06coupon\x18\x03 \x01(\tR\x06coupon\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12;\n" +
+	"\x05items\x18\x05 \x03(\v2%.computations.engine.v1.PromotionItemR\x05items\"@\n" +
+	"\rPromotionItem\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"\xc5\x01\n" +
+	"\x10RunEngineRequest\x123\n" +
+	"\x05state\x18\x01 \x01(\v2\x1d.computations.engine.v1.StateR\x05state\x12=\n" +
+	"\trule_pack\x18\x02 \x01(\v2 .computations.engine.v1.RulePackR\brulePack\x12=\n" +
+	"\acontext\x18\x03 \x01(\v2#.computations.engine.v1.ContextMetaR\acontext\"T\n" +
+	"\x11RunEngineResponse\x12?\n" +
+	"\x06result\x18\x01 \x01(\v2'.computations.engine.v1.RunEngineResultR\x06result\"P\n" +
+	"\x0fValidateRequest\x12=\n" +
+	"\trule_pack\x18\x01 \x01(\v2 .computations.engine.v1.RulePackR\brulePack\"h\n" +
+	"\x10ValidateResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xc3\x01\n" +
+	"\x0eExplainRequest\x123\n" +
+	"\x05state\x18\x01 \x01(\v2\x1d.computations.engine.v1.StateR\x05state\x12=\n" +
+	"\trule_pack\x18\x02 \x01(\v2 .computations.engine.v1.RulePackR\brulePack\x12=\n" +
+	"\acontext\x18\x03 \x01(\v2#.computations.engine.v1.ContextMetaR\acontext\"\xb8\x01\n" +
+	"\x0fExplainResponse\x12=\n" +
+	"\x05rules\x18\x01 \x03(\v2'.computations.engine.v1.RuleExplanationR\x05rules\x12A\n" +
+	"\n" +
+	"violations\x18\x02 \x03(\v2!.computations.engine.v1.ViolationR\n" +
+	"violations\x12#\n" +
+	"\rrules_version\x18\x03 \x01(\tR\frulesVersion\"\xa8\x01\n" +
+	"\x0fRuleExplanation\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x14\n" +
+	"\x05phase\x18\x02 \x01(\tR\x05phase\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\x12\x14\n" +
+	"\x05fired\x18\x05 \x01(\bR\x05fired\x12\x1a\n" +
+	"\bmessages\x18\x06 \x03(\tR\bmessages\"\xc6\x01\n" +
+	"\x0fBatchRunRequest\x12=\n" +
+	"\trule_pack\x18\x01 \x01(\v2 .computations.engine.v1.RulePackR\brulePack\x12=\n" +
+	"\acontext\x18\x02 \x01(\v2#.computations.engine.v1.ContextMetaR\acontext\x125\n" +
+	"\x06states\x18\x03 \x03(\v2\x1d.computations.engine.v1.StateR\x06states\"\x9e\x01\n" +
+	"\x10BatchRunResponse\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12?\n" +
+	"\x06result\x18\x02 \x01(\v2'.computations.engine.v1.RunEngineResultR\x06result\x123\n" +
+	"\x05error\x18\x03 \x01(\v2\x1d.computations.engine.v1.ErrorR\x05error\"5\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage2\x8d\x03\n" +
+	"\rEngineService\x12`\n" +
+	"\tRunEngine\x12(.computations.engine.v1.RunEngineRequest\x1a).computations.engine.v1.RunEngineResponse\x12]\n" +
+	"\bValidate\x12'.computations.engine.v1.ValidateRequest\x1a(.computations.engine.v1.ValidateResponse\x12Z\n" +
+	"\aExplain\x12&.computations.engine.v1.ExplainRequest\x1a'.computations.engine.v1.ExplainResponse\x12_\n" +
+	"\bBatchRun\x12'.computations.engine.v1.BatchRunRequest\x1a(.computations.engine.v1.BatchRunResponse0\x01BJZHgithub.com/dolphin-sistemas/computations-engine/proto/engine/v1;enginev1b\x06proto3"
+
+var (
+	file_engine_v1_engine_proto_rawDescOnce sync.Once
+	file_engine_v1_engine_proto_rawDescData []byte
+)
+
+func file_engine_v1_engine_proto_rawDescGZIP() []byte {
+	file_engine_v1_engine_proto_rawDescOnce.Do(func() {
+		file_engine_v1_engine_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_engine_v1_engine_proto_rawDesc), len(file_engine_v1_engine_proto_rawDesc)))
+	})
+	return file_engine_v1_engine_proto_rawDescData
+}
+
+var file_engine_v1_engine_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_engine_v1_engine_proto_goTypes = []any{
+	(*State)(nil),             // 0: computations.engine.v1.State
+	(*Item)(nil),              // 1: computations.engine.v1.Item
+	(*RulePack)(nil),          // 2: computations.engine.v1.RulePack
+	(*RulePhase)(nil),         // 3: computations.engine.v1.RulePhase
+	(*Rule)(nil),              // 4: computations.engine.v1.Rule
+	(*Action)(nil),            // 5: computations.engine.v1.Action
+	(*TotalDef)(nil),          // 6: computations.engine.v1.TotalDef
+	(*Projection)(nil),        // 7: computations.engine.v1.Projection
+	(*Table)(nil),             // 8: computations.engine.v1.Table
+	(*TableColumn)(nil),       // 9: computations.engine.v1.TableColumn
+	(*Promotion)(nil),         // 10: computations.engine.v1.Promotion
+	(*UnitTable)(nil),         // 11: computations.engine.v1.UnitTable
+	(*UnitDef)(nil),           // 12: computations.engine.v1.UnitDef
+	(*ContextMeta)(nil),       // 13: computations.engine.v1.ContextMeta
+	(*RunEngineResult)(nil),   // 14: computations.engine.v1.RunEngineResult
+	(*Reason)(nil),            // 15: computations.engine.v1.Reason
+	(*Violation)(nil),         // 16: computations.engine.v1.Violation
+	(*AppliedPromotion)(nil),  // 17: computations.engine.v1.AppliedPromotion
+	(*PromotionItem)(nil),     // 18: computations.engine.v1.PromotionItem
+	(*RunEngineRequest)(nil),  // 19: computations.engine.v1.RunEngineRequest
+	(*RunEngineResponse)(nil), // 20: computations.engine.v1.RunEngineResponse
+	(*ValidateRequest)(nil),   // 21: computations.engine.v1.ValidateRequest
+	(*ValidateResponse)(nil),  // 22: computations.engine.v1.ValidateResponse
+	(*ExplainRequest)(nil),    // 23: computations.engine.v1.ExplainRequest
+	(*ExplainResponse)(nil),   // 24: computations.engine.v1.ExplainResponse
+	(*RuleExplanation)(nil),   // 25: computations.engine.v1.RuleExplanation
+	(*BatchRunRequest)(nil),   // 26: computations.engine.v1.BatchRunRequest
+	(*BatchRunResponse)(nil),  // 27: computations.engine.v1.BatchRunResponse
+	(*Error)(nil),             // 28: computations.engine.v1.Error
+	nil,                       // 29: computations.engine.v1.State.TotalsEntry
+	nil,                       // 30: computations.engine.v1.RulePack.ProjectionsEntry
+	nil,                       // 31: computations.engine.v1.RulePack.TablesEntry
+	nil,                       // 32: computations.engine.v1.RulePack.ExchangeRatesEntry
+	nil,                       // 33: computations.engine.v1.RulePack.UnitsEntry
+	nil,                       // 34: computations.engine.v1.UnitTable.UnitsEntry
+	nil,                       // 35: computations.engine.v1.ContextMeta.ExchangeRatesEntry
+	(*structpb.Struct)(nil),   // 36: google.protobuf.Struct
+	(*structpb.Value)(nil),    // 37: google.protobuf.Value
+}
+var file_engine_v1_engine_proto_depIdxs = []int32{
+	1,  // 0: computations.engine.v1.State.items:type_name -> computations.engine.v1.Item
+	29, // 1: computations.engine.v1.State.totals:type_name -> computations.engine.v1.State.TotalsEntry
+	36, // 2: computations.engine.v1.State.fields:type_name -> google.protobuf.Struct
+	36, // 3: computations.engine.v1.State.meta:type_name -> google.protobuf.Struct
+	36, // 4: computations.engine.v1.Item.fields:type_name -> google.protobuf.Struct
+	3,  // 5: computations.engine.v1.RulePack.phases:type_name -> computations.engine.v1.RulePhase
+	6,  // 6: computations.engine.v1.RulePack.totals:type_name -> computations.engine.v1.TotalDef
+	30, // 7: computations.engine.v1.RulePack.projections:type_name -> computations.engine.v1.RulePack.ProjectionsEntry
+	31, // 8: computations.engine.v1.RulePack.tables:type_name -> computations.engine.v1.RulePack.TablesEntry
+	10, // 9: computations.engine.v1.RulePack.promotions:type_name -> computations.engine.v1.Promotion
+	32, // 10: computations.engine.v1.RulePack.exchange_rates:type_name -> computations.engine.v1.RulePack.ExchangeRatesEntry
+	33, // 11: computations.engine.v1.RulePack.units:type_name -> computations.engine.v1.RulePack.UnitsEntry
+	4,  // 12: computations.engine.v1.RulePhase.rules:type_name -> computations.engine.v1.Rule
+	36, // 13: computations.engine.v1.Rule.condition:type_name -> google.protobuf.Struct
+	5,  // 14: computations.engine.v1.Rule.actions:type_name -> computations.engine.v1.Action
+	37, // 15: computations.engine.v1.Action.value:type_name -> google.protobuf.Value
+	36, // 16: computations.engine.v1.Action.logic:type_name -> google.protobuf.Struct
+	36, // 17: computations.engine.v1.Action.params:type_name -> google.protobuf.Struct
+	36, // 18: computations.engine.v1.Projection.logic:type_name -> google.protobuf.Struct
+	9,  // 19: computations.engine.v1.Table.columns:type_name -> computations.engine.v1.TableColumn
+	36, // 20: computations.engine.v1.Table.rows:type_name -> google.protobuf.Struct
+	36, // 21: computations.engine.v1.Promotion.eligibility:type_name -> google.protobuf.Struct
+	36, // 22: computations.engine.v1.Promotion.items:type_name -> google.protobuf.Struct
+	36, // 23: computations.engine.v1.Promotion.base:type_name -> google.protobuf.Struct
+	34, // 24: computations.engine.v1.UnitTable.units:type_name -> computations.engine.v1.UnitTable.UnitsEntry
+	35, // 25: computations.engine.v1.ContextMeta.exchange_rates:type_name -> computations.engine.v1.ContextMeta.ExchangeRatesEntry
+	36, // 26: computations.engine.v1.RunEngineResult.state_fragment:type_name -> google.protobuf.Struct
+	36, // 27: computations.engine.v1.RunEngineResult.server_delta:type_name -> google.protobuf.Struct
+	15, // 28: computations.engine.v1.RunEngineResult.reasons:type_name -> computations.engine.v1.Reason
+	16, // 29: computations.engine.v1.RunEngineResult.violations:type_name -> computations.engine.v1.Violation
+	17, // 30: computations.engine.v1.RunEngineResult.promotions:type_name -> computations.engine.v1.AppliedPromotion
+	18, // 31: computations.engine.v1.AppliedPromotion.items:type_name -> computations.engine.v1.PromotionItem
+	0,  // 32: computations.engine.v1.RunEngineRequest.state:type_name -> computations.engine.v1.State
+	2,  // 33: computations.engine.v1.RunEngineRequest.rule_pack:type_name -> computations.engine.v1.RulePack
+	13, // 34: computations.engine.v1.RunEngineRequest.context:type_name -> computations.engine.v1.ContextMeta
+	14, // 35: computations.engine.v1.RunEngineResponse.result:type_name -> computations.engine.v1.RunEngineResult
+	2,  // 36: computations.engine.v1.ValidateRequest.rule_pack:type_name -> computations.engine.v1.RulePack
+	0,  // 37: computations.engine.v1.ExplainRequest.state:type_name -> computations.engine.v1.State
+	2,  // 38: computations.engine.v1.ExplainRequest.rule_pack:type_name -> computations.engine.v1.RulePack
+	13, // 39: computations.engine.v1.ExplainRequest.context:type_name -> computations.engine.v1.ContextMeta
+	25, // 40: computations.engine.v1.ExplainResponse.rules:type_name -> computations.engine.v1.RuleExplanation
+	16, // 41: computations.engine.v1.ExplainResponse.violations:type_name -> computations.engine.v1.Violation
+	2,  // 42: computations.engine.v1.BatchRunRequest.rule_pack:type_name -> computations.engine.v1.RulePack
+	13, // 43: computations.engine.v1.BatchRunRequest.context:type_name -> computations.engine.v1.ContextMeta
+	0,  // 44: computations.engine.v1.BatchRunRequest.states:type_name -> computations.engine.v1.State
+	14, // 45: computations.engine.v1.BatchRunResponse.result:type_name -> computations.engine.v1.RunEngineResult
+	28, // 46: computations.engine.v1.BatchRunResponse.error:type_name -> computations.engine.v1.Error
+	7,  // 47: computations.engine.v1.RulePack.ProjectionsEntry.value:type_name -> computations.engine.v1.Projection
+	8,  // 48: computations.engine.v1.RulePack.TablesEntry.value:type_name -> computations.engine.v1.Table
+	11, // 49: computations.engine.v1.RulePack.UnitsEntry.value:type_name -> computations.engine.v1.UnitTable
+	12, // 50: computations.engine.v1.UnitTable.UnitsEntry.value:type_name -> computations.engine.v1.UnitDef
+	19, // 51: computations.engine.v1.EngineService.RunEngine:input_type -> computations.engine.v1.RunEngineRequest
+	21, // 52: computations.engine.v1.EngineService.Validate:input_type -> computations.engine.v1.ValidateRequest
+	23, // 53: computations.engine.v1.EngineService.Explain:input_type -> computations.engine.v1.ExplainRequest
+	26, // 54: computations.engine.v1.EngineService.BatchRun:input_type -> computations.engine.v1.BatchRunRequest
+	20, // 55: computations.engine.v1.EngineService.RunEngine:output_type -> computations.engine.v1.RunEngineResponse
+	22, // 56: computations.engine.v1.EngineService.Validate:output_type -> computations.engine.v1.ValidateResponse
+	24, // 57: computations.engine.v1.EngineService.Explain:output_type -> computations.engine.v1.ExplainResponse
+	27, // 58: computations.engine.v1.EngineService.BatchRun:output_type -> computations.engine.v1.BatchRunResponse
+	55, // [55:59] is the sub-list for method output_type
+	51, // [51:55] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
+}
+
+func init() { file_engine_v1_engine_proto_init() }
+func file_engine_v1_engine_proto_init() {
+	if File_engine_v1_engine_proto != nil {
+		return
+	}
+	file_engine_v1_engine_proto_msgTypes[6].OneofWrappers = []any{}
+	file_engine_v1_engine_proto_msgTypes[12].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_engine_v1_engine_proto_rawDesc), len(file_engine_v1_engine_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   36,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_engine_v1_engine_proto_goTypes,
+		DependencyIndexes: file_engine_v1_engine_proto_depIdxs,
+		MessageInfos:      file_engine_v1_engine_proto_msgTypes,
+	}.Build()
+	File_engine_v1_engine_proto = out.File
+	file_engine_v1_engine_proto_goTypes = nil
+	file_engine_v1_engine_proto_depIdxs = nil
+}
@@ -0,0 +1,255 @@
+// Serviço gRPC do motor de regras.
+//
+// As mensagens espelham os tipos JSON de core (State, RulePack, ContextMeta, RunEngineResult):
+// os nomes JSON (protojson) são os mesmos, e os trechos livres (fields, JsonLogic, linhas de
+// tabela, stateFragment) usam google.protobuf.Struct/Value.
+//
+// Gerar o código Go: make proto (protoc + protoc-gen-go + protoc-gen-go-grpc)
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: engine/v1/engine.proto
+
+package enginev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EngineService_RunEngine_FullMethodName = "/computations.engine.v1.EngineService/RunEngine"
+	EngineService_Validate_FullMethodName  = "/computations.engine.v1.EngineService/Validate"
+	EngineService_Explain_FullMethodName   = "/computations.engine.v1.EngineService/Explain"
+	EngineService_BatchRun_FullMethodName  = "/computations.engine.v1.EngineService/BatchRun"
+)
+
+// EngineServiceClient is the client API for EngineService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EngineServiceClient interface {
+	// RunEngine executa o pipeline do RulePack sobre o State (engine.RunEngine)
+	RunEngine(ctx context.Context, in *RunEngineRequest, opts ...grpc.CallOption) (*RunEngineResponse, error)
+	// Validate valida um RulePack sem executá-lo (as mesmas validações do loader)
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	// Explain executa e informa, regra a regra na ordem do pipeline, quais executaram e por quê
+	Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error)
+	// BatchRun executa o mesmo RulePack sobre vários States; um resultado por State, na ordem de entrada
+	BatchRun(ctx context.Context, in *BatchRunRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchRunResponse], error)
+}
+
+type engineServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEngineServiceClient(cc grpc.ClientConnInterface) EngineServiceClient {
+	return &engineServiceClient{cc}
+}
+
+func (c *engineServiceClient) RunEngine(ctx context.Context, in *RunEngineRequest, opts ...grpc.CallOption) (*RunEngineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunEngineResponse)
+	err := c.cc.Invoke(ctx, EngineService_RunEngine_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineServiceClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, EngineService_Validate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineServiceClient) Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainResponse)
+	err := c.cc.Invoke(ctx, EngineService_Explain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineServiceClient) BatchRun(ctx context.Context, in *BatchRunRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchRunResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EngineService_ServiceDesc.Streams[0], EngineService_BatchRun_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchRunRequest, BatchRunResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EngineService_BatchRunClient = grpc.ServerStreamingClient[BatchRunResponse]
+
+// EngineServiceServer is the server API for EngineService service.
+// All implementations must embed UnimplementedEngineServiceServer
+// for forward compatibility.
+type EngineServiceServer interface {
+	// RunEngine executa o pipeline do RulePack sobre o State (engine.RunEngine)
+	RunEngine(context.Context, *RunEngineRequest) (*RunEngineResponse, error)
+	// Validate valida um RulePack sem executá-lo (as mesmas validações do loader)
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	// Explain executa e informa, regra a regra na ordem do pipeline, quais executaram e por quê
+	Explain(context.Context, *ExplainRequest) (*ExplainResponse, error)
+	// BatchRun executa o mesmo RulePack sobre vários States; um resultado por State, na ordem de entrada
+	BatchRun(*BatchRunRequest, grpc.ServerStreamingServer[BatchRunResponse]) error
+	mustEmbedUnimplementedEngineServiceServer()
+}
+
+// UnimplementedEngineServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEngineServiceServer struct{}
+
+func (UnimplementedEngineServiceServer) RunEngine(context.Context, *RunEngineRequest) (*RunEngineResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunEngine not implemented")
+}
+func (UnimplementedEngineServiceServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedEngineServiceServer) Explain(context.Context, *ExplainRequest) (*ExplainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Explain not implemented")
+}
+func (UnimplementedEngineServiceServer) BatchRun(*BatchRunRequest, grpc.ServerStreamingServer[BatchRunResponse]) error {
+	return status.Error(codes.Unimplemented, "method BatchRun not implemented")
+}
+func (UnimplementedEngineServiceServer) mustEmbedUnimplementedEngineServiceServer() {}
+func (UnimplementedEngineServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeEngineServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EngineServiceServer will
+// result in compilation errors.
+type UnsafeEngineServiceServer interface {
+	mustEmbedUnimplementedEngineServiceServer()
+}
+
+func RegisterEngineServiceServer(s grpc.ServiceRegistrar, srv EngineServiceServer) {
+	// If the following call panics, it indicates UnimplementedEngineServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EngineService_ServiceDesc, srv)
+}
+
+func _EngineService_RunEngine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunEngineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServiceServer).RunEngine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EngineService_RunEngine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServiceServer).RunEngine(ctx, req.(*RunEngineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EngineService_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServiceServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EngineService_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServiceServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EngineService_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServiceServer).Explain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EngineService_Explain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServiceServer).Explain(ctx, req.(*ExplainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EngineService_BatchRun_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchRunRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EngineServiceServer).BatchRun(m, &grpc.GenericServerStream[BatchRunRequest, BatchRunResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EngineService_BatchRunServer = grpc.ServerStreamingServer[BatchRunResponse]
+
+// EngineService_ServiceDesc is the grpc.ServiceDesc for EngineService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EngineService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "computations.engine.v1.EngineService",
+	HandlerType: (*EngineServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RunEngine",
+			Handler:    _EngineService_RunEngine_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _EngineService_Validate_Handler,
+		},
+		{
+			MethodName: "Explain",
+			Handler:    _EngineService_Explain_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchRun",
+			Handler:       _EngineService_BatchRun_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "engine/v1/engine.proto",
+}
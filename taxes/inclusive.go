@@ -0,0 +1,128 @@
+package taxes
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+// Rate é um tributo na conversão entre preço líquido e preço com tributos.
+//
+// Inside ("por dentro", como ICMS, PIS e COFINS) integra a própria base: incide sobre o preço
+// com os tributos por dentro. Os demais ("por fora", como IPI) incidem sobre esse preço e são
+// somados a ele; Compound inclui na base os tributos por fora anteriores da lista.
+type Rate struct {
+	Name     string  `json:"name"`
+	Rate     float64 `json:"rate"` // percentual (18 = 18%)
+	Inside   bool    `json:"inside"`
+	Compound bool    `json:"compound"`
+}
+
+// TaxAmount é o valor de um tributo na conversão
+type TaxAmount struct {
+	Name   string  `json:"name"`
+	Base   float64 `json:"base"`
+	Amount float64 `json:"amount"`
+}
+
+// Conversion é o resultado de GrossUp/NetDown: Net + soma dos tributos = Gross, exatamente
+type Conversion struct {
+	Net   float64     `json:"net"`
+	Gross float64     `json:"gross"`
+	Taxes []TaxAmount `json:"taxes"`
+}
+
+// GrossUp converte o preço líquido (sem tributos, B2B) no preço com tributos (B2C)
+func GrossUp(net float64, rates []Rate, precision int) (Conversion, error) {
+	inside, factors, err := rateFactors(rates)
+	if err != nil {
+		return Conversion{}, err
+	}
+	net = pkg.Round(net, precision)
+	price := net / (1 - inside)
+	return reconcile(net, pkg.Round(price*outsideTotal(factors), precision), price, rates, factors, precision), nil
+}
+
+// NetDown converte o preço com tributos (B2C) no preço líquido (B2B)
+func NetDown(gross float64, rates []Rate, precision int) (Conversion, error) {
+	inside, factors, err := rateFactors(rates)
+	if err != nil {
+		return Conversion{}, err
+	}
+	gross = pkg.Round(gross, precision)
+	price := gross / outsideTotal(factors)
+	return reconcile(pkg.Round(price*(1-inside), precision), gross, price, rates, factors, precision), nil
+}
+
+// rateFactors valida os tributos e retorna a soma das alíquotas por dentro e, para cada
+// tributo por fora, o fator sobre o preço com tributos por dentro (0 para os por dentro)
+func rateFactors(rates []Rate) (float64, []float64, error) {
+	seen := make(map[string]bool, len(rates))
+	inside, outside := 0.0, 0.0
+	factors := make([]float64, len(rates))
+	for i, r := range rates {
+		switch {
+		case r.Name == "":
+			return 0, nil, fmt.Errorf("taxes[%d]: name is required", i)
+		case seen[r.Name]:
+			return 0, nil, fmt.Errorf("taxes[%d]: duplicate name %q", i, r.Name)
+		case r.Rate < 0:
+			return 0, nil, fmt.Errorf("taxes[%d]: rate must not be negative", i)
+		case r.Inside && r.Compound:
+			return 0, nil, fmt.Errorf("taxes[%d]: compound applies only to outside taxes", i)
+		}
+		seen[r.Name] = true
+		if r.Inside {
+			inside += r.Rate / 100
+			continue
+		}
+		base := 1.0
+		if r.Compound {
+			base += outside
+		}
+		factors[i] = base * r.Rate / 100
+		outside += factors[i]
+	}
+	if inside >= 1 {
+		return 0, nil, fmt.Errorf("inside rates must total below 100%%")
+	}
+	return inside, factors, nil
+}
+
+// outsideTotal é o fator do preço com tributos por dentro para o preço final
+func outsideTotal(factors []float64) float64 {
+	total := 1.0
+	for _, f := range factors {
+		total += f
+	}
+	return total
+}
+
+// reconcile arredonda os tributos sobre price (preço com tributos por dentro, sem arredondar)
+// e lança a diferença de centavos no maior tributo, para que net + tributos = gross
+func reconcile(net, gross, price float64, rates []Rate, factors []float64, precision int) Conversion {
+	out := Conversion{Net: net, Gross: gross, Taxes: make([]TaxAmount, len(rates))}
+	remaining := gross - net
+	largest := -1
+	outsidePaid := 0.0
+	for i, r := range rates {
+		base := price
+		if !r.Inside && r.Compound {
+			base += outsidePaid
+		}
+		amount := pkg.Round(base*r.Rate/100, precision)
+		if !r.Inside {
+			outsidePaid += price * factors[i]
+		}
+		out.Taxes[i] = TaxAmount{Name: r.Name, Base: pkg.Round(base, precision), Amount: amount}
+		remaining -= amount
+		if largest < 0 || math.Abs(amount) > math.Abs(out.Taxes[largest].Amount) {
+			largest = i
+		}
+	}
+	if largest >= 0 {
+		out.Taxes[largest].Amount = pkg.Round(out.Taxes[largest].Amount+remaining, precision)
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package taxes
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+var brazilianRates = []Rate{
+	{Name: "icms", Rate: 18, Inside: true},
+	{Name: "pis", Rate: 1.65, Inside: true},
+	{Name: "cofins", Rate: 7.6, Inside: true},
+	{Name: "ipi", Rate: 10},
+}
+
+func TestGrossUpNetDown(t *testing.T) {
+	tests := []struct {
+		name    string
+		gross   bool // converte a partir do preço com tributos (NetDown)
+		price   float64
+		rates   []Rate
+		net     float64
+		total   float64
+		amounts []float64
+	}{
+		{"no taxes", false, 100, nil, 100, 100, nil},
+		{"outside only", false, 100, []Rate{{Name: "ipi", Rate: 10}}, 100, 110, []float64{10}},
+		{"inside only", false, 82, []Rate{{Name: "icms", Rate: 18, Inside: true}}, 82, 100, []float64{18}},
+		// 24.74 + 2.27 + 10.45 + 13.75 = 51.21 -> o centavo excedente sai do ICMS
+		{"stacked with reconciliation", false, 100, brazilianRates, 100, 151.20, []float64{24.73, 2.27, 10.45, 13.75}},
+		{"compound outside", false, 100, []Rate{{Name: "gst", Rate: 5}, {Name: "qst", Rate: 9.975, Compound: true}}, 100, 115.47, []float64{5, 10.47}},
+		{"net down stacked", true, 151.20, brazilianRates, 100, 151.20, []float64{24.73, 2.27, 10.45, 13.75}},
+		{"net down outside", true, 10, []Rate{{Name: "vat", Rate: 20}}, 8.33, 10, []float64{1.67}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Conversion
+			var err error
+			if tt.gross {
+				got, err = NetDown(tt.price, tt.rates, 2)
+			} else {
+				got, err = GrossUp(tt.price, tt.rates, 2)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(got.Net-tt.net) > 0.001 || math.Abs(got.Gross-tt.total) > 0.001 {
+				t.Fatalf("net/gross = %v/%v, expected %v/%v", got.Net, got.Gross, tt.net, tt.total)
+			}
+			sum := got.Net
+			for i, tax := range got.Taxes {
+				if math.Abs(tax.Amount-tt.amounts[i]) > 0.001 {
+					t.Errorf("%s = %v, expected %v", tax.Name, tax.Amount, tt.amounts[i])
+				}
+				sum += tax.Amount
+			}
+			if math.Abs(sum-got.Gross) > 1e-9 {
+				t.Errorf("net + taxes = %v, expected exactly %v", sum, got.Gross)
+			}
+		})
+	}
+}
+
+func TestGrossUp_RoundTrip(t *testing.T) {
+	for net := 0.01; net < 50; net += 0.37 {
+		up, err := GrossUp(net, brazilianRates, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		down, err := NetDown(up.Gross, brazilianRates, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(down.Net-up.Net) > 0.011 {
+			t.Errorf("net %v -> gross %v -> net %v", up.Net, up.Gross, down.Net)
+		}
+	}
+}
+
+func TestGrossUp_InvalidRates(t *testing.T) {
+	tests := []struct {
+		name  string
+		rates []Rate
+		err   string
+	}{
+		{"missing name", []Rate{{Rate: 5}}, "name is required"},
+		{"duplicate", []Rate{{Name: "a", Rate: 5}, {Name: "a", Rate: 5}}, "duplicate name"},
+		{"negative", []Rate{{Name: "a", Rate: -1}}, "must not be negative"},
+		{"compound inside", []Rate{{Name: "a", Rate: 5, Inside: true, Compound: true}}, "compound applies only"},
+		{"inside 100%", []Rate{{Name: "a", Rate: 60, Inside: true}, {Name: "b", Rate: 40, Inside: true}}, "below 100%"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GrossUp(100, tt.rates, 2); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
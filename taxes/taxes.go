@@ -0,0 +1,288 @@
+// Package taxes calcula os tributos brasileiros de um item de nota (ICMS, ICMS-ST, IPI,
+// PIS/COFINS e DIFAL) a partir de entradas fiscais explícitas (CST/CSOSN, alíquotas,
+// reduções de base e MVA).
+//
+// Valores monetários são arredondados em 2 casas a cada etapa, como na NF-e.
+// Alíquotas, reduções e MVA são percentuais (18 = 18%).
+package taxes
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolphin-sistemas/computations-engine/pkg"
+)
+
+// Métodos de cálculo do DIFAL
+const (
+	DIFALDouble = "double" // base dupla, "por dentro" (padrão)
+	DIFALSingle = "single" // base única: valor × (interna - interestadual)
+)
+
+// Input são os dados fiscais de um item
+type Input struct {
+	Value     float64 `json:"value"` // valor dos produtos (vProd)
+	Freight   float64 `json:"freight"`
+	Insurance float64 `json:"insurance"`
+	Other     float64 `json:"other"` // outras despesas acessórias
+	Discount  float64 `json:"discount"`
+
+	// ICMS próprio: CST (regime normal) ou CSOSN (Simples Nacional). ICMSRate também é o ICMS
+	// deduzido do ICMS-ST quando não há ICMS próprio destacado (CST 30, CSOSN 201 a 203)
+	CST           string  `json:"cst"`
+	CSOSN         string  `json:"csosn"`
+	ICMSRate      float64 `json:"icmsRate"`
+	ICMSReduction float64 `json:"icmsReduction"` // redução da base (pRedBC)
+	IPIInICMSBase bool    `json:"ipiInIcmsBase"` // IPI compõe a base do ICMS (venda a consumidor final)
+	DeferralRate  float64 `json:"deferralRate"`  // parcela diferida do ICMS (CST 51)
+	SNCreditRate  float64 `json:"snCreditRate"`  // crédito do Simples Nacional (CSOSN 101, 201, 900)
+
+	// ICMS-ST
+	MVA         float64 `json:"mva"`
+	STRate      float64 `json:"stRate"`      // alíquota interna do destino
+	STReduction float64 `json:"stReduction"` // redução da base ST (pRedBCST)
+
+	// IPI (CST vazio = tributado)
+	IPICST  string  `json:"ipiCst"`
+	IPIRate float64 `json:"ipiRate"`
+
+	// PIS/COFINS (CST vazio = tributado)
+	PISCST                   string  `json:"pisCst"`
+	PISRate                  float64 `json:"pisRate"`
+	COFINSCST                string  `json:"cofinsCst"`
+	COFINSRate               float64 `json:"cofinsRate"`
+	ExcludeICMSFromPISCOFINS bool    `json:"excludeIcmsFromPisCofins"` // ICMS fora da base (Tema 69 do STF)
+
+	// DIFAL para consumidor final não contribuinte (aplicado quando DestRate > 0;
+	// a alíquota interestadual é ICMSRate)
+	DestRate    float64 `json:"destRate"`
+	FCPRate     float64 `json:"fcpRate"`
+	DIFALMethod string  `json:"difalMethod"`
+}
+
+// Tax é a base, a alíquota e o valor de um tributo
+type Tax struct {
+	Base     float64 `json:"base"`
+	Rate     float64 `json:"rate"`
+	Value    float64 `json:"value"`
+	Deferred float64 `json:"deferred,omitempty"` // ICMS diferido (CST 51)
+	Credit   float64 `json:"credit,omitempty"`   // crédito do Simples Nacional
+}
+
+// DIFAL é a partilha do ICMS devido ao destino
+type DIFAL struct {
+	Base  float64 `json:"base"`
+	Value float64 `json:"value"`
+	FCP   float64 `json:"fcp"`
+}
+
+// Breakdown é o detalhamento dos tributos de um item
+type Breakdown struct {
+	ICMS   Tax     `json:"icms"`
+	ICMSST Tax     `json:"icmsSt"`
+	IPI    Tax     `json:"ipi"`
+	PIS    Tax     `json:"pis"`
+	COFINS Tax     `json:"cofins"`
+	DIFAL  DIFAL   `json:"difal"`
+	Added  float64 `json:"added"` // somado ao total do item na nota (IPI + ICMS-ST)
+}
+
+// Calculate calcula os tributos do item
+func Calculate(in Input) (Breakdown, error) {
+	var out Breakdown
+	operation := round(in.Value + in.Freight + in.Insurance + in.Other - in.Discount)
+
+	// IPI
+	ipiTaxed, err := ipiTaxed(in.IPICST)
+	if err != nil {
+		return out, err
+	}
+	if ipiTaxed {
+		out.IPI = Tax{Base: operation, Rate: in.IPIRate, Value: IPIValue(operation, in.IPIRate)}
+	}
+
+	// ICMS próprio e ICMS-ST
+	icmsBase := operation
+	if in.IPIInICMSBase {
+		icmsBase = round(icmsBase + out.IPI.Value)
+	}
+	rule, err := icmsRuleFor(in.CST, in.CSOSN)
+	if err != nil {
+		return out, err
+	}
+	if in.CST != "" || in.CSOSN != "" {
+		own := Tax{Base: ReducedBase(icmsBase, in.ICMSReduction), Rate: in.ICMSRate}
+		own.Value = round(own.Base * in.ICMSRate / 100)
+		stDeduction := own.Value
+
+		if rule.ownICMS {
+			if rule.deferral {
+				own.Deferred = round(own.Value * in.DeferralRate / 100)
+				own.Value = round(own.Value - own.Deferred)
+			}
+			out.ICMS = own
+		}
+		if rule.snCredit {
+			out.ICMS.Credit = round(operation * in.SNCreditRate / 100)
+		}
+		if rule.st || (rule.optionalST && in.STRate > 0) {
+			out.ICMSST = Tax{Base: STBase(round(operation+out.IPI.Value), in.MVA, in.STReduction), Rate: in.STRate}
+			out.ICMSST.Value = math.Max(0, round(round(out.ICMSST.Base*in.STRate/100)-stDeduction))
+		}
+	}
+
+	// PIS/COFINS
+	pisCofinsBase := operation
+	if in.ExcludeICMSFromPISCOFINS {
+		pisCofinsBase = round(pisCofinsBase - out.ICMS.Value)
+	}
+	if out.PIS, err = contribution("pis", in.PISCST, pisCofinsBase, in.PISRate); err != nil {
+		return out, err
+	}
+	if out.COFINS, err = contribution("cofins", in.COFINSCST, pisCofinsBase, in.COFINSRate); err != nil {
+		return out, err
+	}
+
+	// DIFAL
+	if in.DestRate > 0 {
+		if out.DIFAL, err = CalculateDIFAL(round(operation+out.IPI.Value), in.ICMSRate, in.DestRate, in.FCPRate, in.DIFALMethod); err != nil {
+			return out, err
+		}
+	}
+
+	out.Added = round(out.IPI.Value + out.ICMSST.Value)
+	return out, nil
+}
+
+// ReducedBase aplica o percentual de redução à base
+func ReducedBase(base, reduction float64) float64 {
+	return round(base * (1 - reduction/100))
+}
+
+// ICMSValue calcula o ICMS sobre a base (com redução opcional)
+func ICMSValue(base, rate, reduction float64) float64 {
+	return round(ReducedBase(base, reduction) * rate / 100)
+}
+
+// IPIValue calcula o IPI sobre a base
+func IPIValue(base, rate float64) float64 {
+	return round(base * rate / 100)
+}
+
+// STBase calcula a base do ICMS-ST: (valor + IPI) × (1 + MVA) com redução opcional
+func STBase(base, mva, reduction float64) float64 {
+	return round(base * (1 + mva/100) * (1 - reduction/100))
+}
+
+// STValue calcula o ICMS-ST: ICMS sobre a base ST menos o ICMS próprio (nunca negativo)
+func STValue(base, stRate, mva, ownICMS, reduction float64) float64 {
+	return math.Max(0, round(round(STBase(base, mva, reduction)*stRate/100)-ownICMS))
+}
+
+// CalculateDIFAL calcula o DIFAL e o FCP devidos ao destino sobre o valor da operação (com IPI).
+//
+// Base dupla: base = (valor - ICMS origem) / (1 - (alíquota interna + FCP)); DIFAL = base ×
+// alíquota interna - ICMS origem. Base única: DIFAL = valor × (interna - interestadual).
+func CalculateDIFAL(value, interRate, destRate, fcpRate float64, method string) (DIFAL, error) {
+	origin := round(value * interRate / 100)
+	switch method {
+	case "", DIFALDouble:
+		divisor := 1 - (destRate+fcpRate)/100
+		if divisor <= 0 {
+			return DIFAL{}, fmt.Errorf("difal: destination rate plus FCP must be below 100%%")
+		}
+		base := round((value - origin) / divisor)
+		return DIFAL{
+			Base:  base,
+			Value: math.Max(0, round(round(base*destRate/100)-origin)),
+			FCP:   round(base * fcpRate / 100),
+		}, nil
+	case DIFALSingle:
+		return DIFAL{
+			Base:  value,
+			Value: math.Max(0, round(value*(destRate-interRate)/100)),
+			FCP:   round(value * fcpRate / 100),
+		}, nil
+	default:
+		return DIFAL{}, fmt.Errorf("difal: unknown method %q (use %s or %s)", method, DIFALDouble, DIFALSingle)
+	}
+}
+
+// icmsRule descreve o tratamento do ICMS para um CST/CSOSN
+type icmsRule struct {
+	ownICMS    bool // ICMS próprio destacado
+	deferral   bool // parte do ICMS diferida (CST 51)
+	st         bool // ICMS-ST devido
+	optionalST bool // ICMS-ST quando informado (CST 90, CSOSN 900)
+	snCredit   bool // crédito do Simples Nacional
+}
+
+var cstRules = map[string]icmsRule{
+	"00": {ownICMS: true},
+	"10": {ownICMS: true, st: true},
+	"20": {ownICMS: true},
+	"30": {st: true},
+	"40": {}, "41": {}, "50": {},
+	"51": {ownICMS: true, deferral: true},
+	"60": {},
+	"70": {ownICMS: true, st: true},
+	"90": {ownICMS: true, optionalST: true},
+}
+
+var csosnRules = map[string]icmsRule{
+	"101": {snCredit: true},
+	"102": {}, "103": {}, "300": {}, "400": {},
+	"201": {st: true, snCredit: true},
+	"202": {st: true}, "203": {st: true},
+	"500": {},
+	"900": {ownICMS: true, optionalST: true, snCredit: true},
+}
+
+func icmsRuleFor(cst, csosn string) (icmsRule, error) {
+	switch {
+	case cst != "" && csosn != "":
+		return icmsRule{}, fmt.Errorf("icms: use either cst or csosn, not both")
+	case cst != "":
+		rule, ok := cstRules[cst]
+		if !ok {
+			return rule, fmt.Errorf("icms: unknown cst %q", cst)
+		}
+		return rule, nil
+	case csosn != "":
+		rule, ok := csosnRules[csosn]
+		if !ok {
+			return rule, fmt.Errorf("icms: unknown csosn %q", csosn)
+		}
+		return rule, nil
+	}
+	return icmsRule{}, nil
+}
+
+// ipiTaxed indica se o CST do IPI é tributado (00, 49, 50, 99) ou não (01-05, 51-55)
+func ipiTaxed(cst string) (bool, error) {
+	switch cst {
+	case "", "00", "49", "50", "99":
+		return true, nil
+	case "01", "02", "03", "04", "05", "51", "52", "53", "54", "55":
+		return false, nil
+	}
+	return false, fmt.Errorf("ipi: unknown cst %q", cst)
+}
+
+// contribution calcula PIS ou COFINS por alíquota; CST 04 a 09 não têm contribuição
+func contribution(name, cst string, base, rate float64) (Tax, error) {
+	switch cst {
+	case "04", "05", "06", "07", "08", "09":
+		return Tax{}, nil
+	case "03":
+		return Tax{}, fmt.Errorf("%s: cst 03 (rate per unit) is not supported", name)
+	}
+	if cst != "" && (len(cst) != 2 || cst[0] < '0' || cst[0] > '9' || cst[1] < '0' || cst[1] > '9') {
+		return Tax{}, fmt.Errorf("%s: unknown cst %q", name, cst)
+	}
+	return Tax{Base: base, Rate: rate, Value: round(base * rate / 100)}, nil
+}
+
+func round(v float64) float64 {
+	return pkg.Round(v, 2)
+}
@@ -0,0 +1,78 @@
+package taxes
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCalculate_Vectors executa os vectors de testdata/taxes (entrada fiscal -> detalhamento esperado)
+func TestCalculate_Vectors(t *testing.T) {
+	dir := filepath.Join("..", "testdata", "taxes")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		t.Run(entry.Name(), func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				t.Fatalf("failed to read vector: %v", err)
+			}
+			var vector struct {
+				Input         Input      `json:"input"`
+				Expected      *Breakdown `json:"expected"`
+				ExpectedError string     `json:"expectedError"`
+			}
+			if err := json.Unmarshal(data, &vector); err != nil {
+				t.Fatalf("failed to unmarshal vector: %v", err)
+			}
+
+			got, err := Calculate(vector.Input)
+			if vector.ExpectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), vector.ExpectedError) {
+					t.Fatalf("Expected error containing %q, got %v", vector.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Calculate failed: %v", err)
+			}
+
+			want := *vector.Expected
+			checks := []struct {
+				name      string
+				got, want float64
+			}{
+				{"icms.base", got.ICMS.Base, want.ICMS.Base},
+				{"icms.value", got.ICMS.Value, want.ICMS.Value},
+				{"icms.deferred", got.ICMS.Deferred, want.ICMS.Deferred},
+				{"icms.credit", got.ICMS.Credit, want.ICMS.Credit},
+				{"icmsSt.base", got.ICMSST.Base, want.ICMSST.Base},
+				{"icmsSt.value", got.ICMSST.Value, want.ICMSST.Value},
+				{"ipi.base", got.IPI.Base, want.IPI.Base},
+				{"ipi.value", got.IPI.Value, want.IPI.Value},
+				{"pis.base", got.PIS.Base, want.PIS.Base},
+				{"pis.value", got.PIS.Value, want.PIS.Value},
+				{"cofins.base", got.COFINS.Base, want.COFINS.Base},
+				{"cofins.value", got.COFINS.Value, want.COFINS.Value},
+				{"difal.base", got.DIFAL.Base, want.DIFAL.Base},
+				{"difal.value", got.DIFAL.Value, want.DIFAL.Value},
+				{"difal.fcp", got.DIFAL.FCP, want.DIFAL.FCP},
+				{"added", got.Added, want.Added},
+			}
+			for _, c := range checks {
+				if math.Abs(c.got-c.want) > 0.001 {
+					t.Errorf("%s: got %.2f, expected %.2f", c.name, c.got, c.want)
+				}
+			}
+		})
+	}
+}
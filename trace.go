@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"github.com/dolphin-sistemas/computations-engine/core"
+	"github.com/dolphin-sistemas/computations-engine/pipeline"
+)
+
+// RuleTrace é uma regra do RulePack, na ordem do pipeline, e o que ela fez na execução
+type RuleTrace struct {
+	RuleID   string   `json:"ruleId"`
+	Phase    string   `json:"phase"`
+	Priority int      `json:"priority"`
+	Enabled  bool     `json:"enabled"`
+	Fired    bool     `json:"fired"`              // as ações executaram e registraram reasons
+	Messages []string `json:"messages,omitempty"` // mensagens (reasons) das ações
+}
+
+// TraceRules relaciona as regras do RulePack (inclusive as compiladas de promoções) com os
+// reasons de um resultado de RunEngine. Validações que geram violação não registram reason;
+// elas aparecem em result.Violations.
+func TraceRules(rules core.RulePack, result *core.RunEngineResult) ([]RuleTrace, error) {
+	compiled, err := pipeline.CompilePromotions(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	fired := make(map[string]bool)
+	messages := make(map[string][]string)
+	for _, reason := range result.Reasons {
+		fired[reason.RuleID] = true
+		if reason.Message != "" {
+			messages[reason.RuleID] = append(messages[reason.RuleID], reason.Message)
+		}
+	}
+
+	ordered := pipeline.OrderedRules(compiled)
+	trace := make([]RuleTrace, 0, len(ordered))
+	for _, rule := range ordered {
+		trace = append(trace, RuleTrace{
+			RuleID:   rule.ID,
+			Phase:    rule.Phase,
+			Priority: rule.Priority,
+			Enabled:  rule.Enabled,
+			Fired:    fired[rule.ID],
+			Messages: messages[rule.ID],
+		})
+	}
+	return trace, nil
+}
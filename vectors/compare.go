@@ -0,0 +1,125 @@
+package vectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Diff é uma diferença entre o esperado e o obtido, identificada pelo caminho
+// (ex: stateFragment.items[0].fields.total)
+type Diff struct {
+	Path     string
+	Expected string // JSON do valor esperado (vazio quando não se aplica)
+	Got      string // JSON do valor obtido (vazio quando ausente)
+	Message  string // descrição quando não é uma simples troca de valor
+}
+
+func (d Diff) String() string {
+	switch {
+	case d.Message != "":
+		return d.Path + ": " + d.Message
+	case d.Got == "":
+		return fmt.Sprintf("%s: missing, expected %s", d.Path, d.Expected)
+	}
+	return fmt.Sprintf("%s: got %s, expected %s", d.Path, d.Got, d.Expected)
+}
+
+// compare compara expected (parcial) com actual, ambos na forma genérica do JSON
+func (o options) compare(expected, actual interface{}, path string) []Diff {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return []Diff{{Path: path, Expected: "object", Got: compact(actual)}}
+		}
+		var diffs []Diff
+		for _, k := range sortedKeys(e) {
+			av, exists := a[k]
+			if !exists {
+				diffs = append(diffs, Diff{Path: path + "." + k, Expected: compact(e[k])})
+				continue
+			}
+			diffs = append(diffs, o.compare(e[k], av, path+"."+k)...)
+		}
+		if o.exact {
+			for _, k := range sortedKeys(a) {
+				if _, declared := e[k]; !declared {
+					diffs = append(diffs, Diff{Path: path + "." + k, Message: "unexpected key (got " + compact(a[k]) + ")"})
+				}
+			}
+		}
+		return diffs
+
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			return []Diff{{Path: path, Expected: "array", Got: compact(actual)}}
+		}
+		if len(a) != len(e) {
+			return []Diff{{Path: path, Message: fmt.Sprintf("got %d elements, expected %d", len(a), len(e))}}
+		}
+		var diffs []Diff
+		for i := range e {
+			diffs = append(diffs, o.compare(e[i], a[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return diffs
+
+	case float64:
+		a, ok := actual.(float64)
+		if !ok || !o.closeEnough(e, a) {
+			return []Diff{{Path: path, Expected: compact(e), Got: compact(actual)}}
+		}
+		return nil
+
+	default:
+		if expected != actual {
+			return []Diff{{Path: path, Expected: compact(expected), Got: compact(actual)}}
+		}
+		return nil
+	}
+}
+
+// closeEnough aceita a diferença dentro da tolerância absoluta ou da relativa
+func (o options) closeEnough(expected, actual float64) bool {
+	diff := math.Abs(actual - expected)
+	if diff <= o.tolerance {
+		return true
+	}
+	return o.relTolerance > 0 && diff <= o.relTolerance*math.Max(math.Abs(expected), math.Abs(actual))
+}
+
+// normalize converte para a forma genérica do JSON (mapas, slices, float64)
+func normalize(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func compact(v interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
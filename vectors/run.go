@@ -0,0 +1,180 @@
+package vectors
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine"
+	"github.com/dolphin-sistemas/computations-engine/coverage"
+)
+
+// DefaultTolerance é a diferença numérica aceita por padrão (um centavo)
+const DefaultTolerance = 0.01
+
+// Option ajusta a comparação
+type Option func(*options)
+
+type options struct {
+	tolerance    float64
+	relTolerance float64
+	exact        bool
+	update       bool
+	coverage     *coverage.Collector
+}
+
+// WithTolerance define a diferença absoluta aceita entre números (padrão DefaultTolerance)
+func WithTolerance(tolerance float64) Option {
+	return func(o *options) { o.tolerance = tolerance }
+}
+
+// WithRelativeTolerance aceita também diferenças proporcionais ao valor (ex: 1e-9 para grandezas grandes)
+func WithRelativeTolerance(tolerance float64) Option {
+	return func(o *options) { o.relTolerance = tolerance }
+}
+
+// Exact exige que os objetos esperados declarem todas as chaves obtidas (sem correspondência parcial)
+func Exact() Option {
+	return func(o *options) { o.exact = true }
+}
+
+// WithUpdate faz Run reescrever os vectors (UpdateFile) em vez de comparar. O pacote não
+// registra flags: o teste que chama Run decide (ex: a partir do próprio -update)
+func WithUpdate(update bool) Option {
+	return func(o *options) { o.update = update }
+}
+
+// WithCoverage acumula no coletor a cobertura das execuções dos vectors
+func WithCoverage(c *coverage.Collector) Option {
+	return func(o *options) { o.coverage = c }
+}
+
+// context é o contexto das execuções do motor (com o coletor de cobertura, se houver)
+func (o options) context() context.Context {
+	if o.coverage != nil {
+		return coverage.NewContext(context.Background(), o.coverage)
+	}
+	return context.Background()
+}
+
+func newOptions(opts []Option) options {
+	o := options{tolerance: DefaultTolerance}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Check executa o vector e retorna as diferenças (vazio = passou). O erro indica falha ao
+// executar ou comparar, não diferença de resultado.
+func Check(v Vector, opts ...Option) ([]Diff, error) {
+	o := newOptions(opts)
+
+	result, err := engine.RunEngine(o.context(), v.Input.Order, v.Input.RulePack, v.Input.Context)
+	if v.ExpectedError != "" {
+		switch {
+		case err == nil:
+			return []Diff{{Path: "error", Message: fmt.Sprintf("expected error containing %q, got success", v.ExpectedError)}}, nil
+		case !strings.Contains(strings.ToLower(err.Error()), strings.ToLower(v.ExpectedError)):
+			return []Diff{{Path: "error", Message: fmt.Sprintf("expected error containing %q, got: %v", v.ExpectedError, err)}}, nil
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("RunEngine failed: %w", err)
+	}
+	if v.Expected == nil {
+		return nil, fmt.Errorf("vector has neither expected nor expectedError")
+	}
+
+	var diffs []Diff
+	if result.RulesVersion != v.Expected.RulesVersion {
+		diffs = append(diffs, Diff{Path: "rulesVersion", Expected: compact(v.Expected.RulesVersion), Got: compact(result.RulesVersion)})
+	}
+
+	violations, err := normalize(result.Violations)
+	if err != nil {
+		return nil, err
+	}
+	if violations == nil {
+		violations = []interface{}{}
+	}
+	expectedViolations := v.Expected.Violations
+	if expectedViolations == nil {
+		expectedViolations = []interface{}{}
+	}
+	diffs = append(diffs, o.compare(expectedViolations, violations, "violations")...)
+
+	if len(v.Expected.StateFragment) > 0 {
+		fragment, err := normalize(result.StateFragment)
+		if err != nil {
+			return nil, err
+		}
+		expected, err := normalize(v.Expected.StateFragment)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, o.compare(expected, fragment, "stateFragment")...)
+	}
+
+	if v.Expected.Promotions != nil {
+		promotions, err := normalize(result.Promotions)
+		if err != nil {
+			return nil, err
+		}
+		if promotions == nil {
+			promotions = []interface{}{}
+		}
+		diffs = append(diffs, o.compare(v.Expected.Promotions, promotions, "promotions")...)
+	}
+	return diffs, nil
+}
+
+// Run executa cada .json do diretório como subteste (t.Run com o nome do arquivo) e reporta
+// cada diferença pelo caminho. Diretório inexistente marca o teste como pulado.
+// Com WithUpdate(true), reescreve expected a partir do resultado atual (UpdateFile) e registra
+// as alterações de cada vector no log do subteste (visível com -v).
+func Run(t *testing.T, dir string, opts ...Option) {
+	t.Helper()
+	o := newOptions(opts)
+	files, err := Files(dir)
+	if err != nil {
+		t.Skipf("vectors directory %s not found: %v", dir, err)
+		return
+	}
+	if len(files) == 0 {
+		t.Fatalf("no vectors in %s", dir)
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			if o.update {
+				report, err := UpdateFile(file, opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(report.Changes) > 0 {
+					t.Log(report)
+				}
+				for _, d := range report.Remaining {
+					t.Error(d)
+				}
+				return
+			}
+
+			v, err := Load(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diffs, err := Check(v, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range diffs {
+				t.Error(d)
+			}
+		})
+	}
+}
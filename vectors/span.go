@@ -0,0 +1,179 @@
+package vectors
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// node é um valor JSON com a posição dele no arquivo, para reescrever só o que mudou
+// e manter a formatação e a ordem das chaves
+type node struct {
+	start, end int  // [start, end) no arquivo
+	kind       byte // '{', '[', '"', 'n' (número) ou 'l' (true, false, null)
+	members    []member
+	elems      []*node
+}
+
+type member struct {
+	key      string
+	keyStart int
+	value    *node
+}
+
+func (n *node) member(key string) (int, *node) {
+	for i, m := range n.members {
+		if m.key == key {
+			return i, m.value
+		}
+	}
+	return -1, nil
+}
+
+// parseSpans lê o documento JSON registrando a posição de cada valor
+func parseSpans(data []byte) (*node, error) {
+	p := &spanParser{data: data}
+	n, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(data) {
+		return nil, fmt.Errorf("unexpected data at offset %d", p.pos)
+	}
+	return n, nil
+}
+
+type spanParser struct {
+	data []byte
+	pos  int
+}
+
+func (p *spanParser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *spanParser) value() (*node, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, fmt.Errorf("unexpected end of JSON")
+	}
+	n := &node{start: p.pos}
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		n.kind = '{'
+		p.pos++
+		p.skipSpace()
+		if p.peek('}') {
+			break
+		}
+		for {
+			p.skipSpace()
+			keyStart := p.pos
+			key, err := p.str()
+			if err != nil {
+				return nil, err
+			}
+			p.skipSpace()
+			if !p.peek(':') {
+				return nil, fmt.Errorf("expected ':' at offset %d", p.pos)
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			n.members = append(n.members, member{key: key, keyStart: keyStart, value: v})
+			p.skipSpace()
+			if p.peek(',') {
+				continue
+			}
+			if p.peek('}') {
+				break
+			}
+			return nil, fmt.Errorf("expected ',' or '}' at offset %d", p.pos)
+		}
+	case c == '[':
+		n.kind = '['
+		p.pos++
+		p.skipSpace()
+		if p.peek(']') {
+			break
+		}
+		for {
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			n.elems = append(n.elems, v)
+			p.skipSpace()
+			if p.peek(',') {
+				continue
+			}
+			if p.peek(']') {
+				break
+			}
+			return nil, fmt.Errorf("expected ',' or ']' at offset %d", p.pos)
+		}
+	case c == '"':
+		n.kind = '"'
+		if _, err := p.str(); err != nil {
+			return nil, err
+		}
+	default:
+		n.kind = 'l'
+		if c == '-' || (c >= '0' && c <= '9') {
+			n.kind = 'n'
+		}
+		for p.pos < len(p.data) && isLiteralByte(p.data[p.pos]) {
+			p.pos++
+		}
+		var v interface{}
+		if err := json.Unmarshal(p.data[n.start:p.pos], &v); err != nil {
+			return nil, fmt.Errorf("invalid value at offset %d: %w", n.start, err)
+		}
+	}
+	n.end = p.pos
+	return n, nil
+}
+
+// peek consome c se for o próximo byte
+func (p *spanParser) peek(c byte) bool {
+	if p.pos < len(p.data) && p.data[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *spanParser) str() (string, error) {
+	start := p.pos
+	if !p.peek('"') {
+		return "", fmt.Errorf("expected string at offset %d", p.pos)
+	}
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case '\\':
+			p.pos += 2
+			continue
+		case '"':
+			p.pos++
+			var s string
+			if err := json.Unmarshal(p.data[start:p.pos], &s); err != nil {
+				return "", fmt.Errorf("invalid string at offset %d: %w", start, err)
+			}
+			return s, nil
+		}
+		p.pos++
+	}
+	return "", fmt.Errorf("unterminated string at offset %d", start)
+}
+
+func isLiteralByte(c byte) bool {
+	return c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
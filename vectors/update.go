@@ -0,0 +1,445 @@
+package vectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dolphin-sistemas/computations-engine"
+)
+
+// Change é uma alteração feita em expected por UpdateFile
+type Change struct {
+	Path string
+	Old  string // JSON do valor anterior (vazio quando adicionado)
+	New  string // JSON do valor novo (vazio quando removido)
+}
+
+func (c Change) String() string {
+	switch {
+	case c.Old == "":
+		return fmt.Sprintf("%s: added %s", c.Path, c.New)
+	case c.New == "":
+		return fmt.Sprintf("%s: removed (was %s)", c.Path, c.Old)
+	}
+	return fmt.Sprintf("%s: %s -> %s", c.Path, c.Old, c.New)
+}
+
+// Update é o resultado de UpdateFile para um vector
+type Update struct {
+	Path      string
+	Changes   []Change // alterações gravadas no arquivo
+	Remaining []Diff   // diferenças que continuam após a atualização (ex: vectors de erro)
+}
+
+// String é o relatório do vector para revisão: uma linha por alteração e por diferença restante
+func (u Update) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d changes", u.Path, len(u.Changes))
+	for _, c := range u.Changes {
+		b.WriteString("\n  " + c.String())
+	}
+	for _, d := range u.Remaining {
+		b.WriteString("\n  not updated: " + d.String())
+	}
+	return b.String()
+}
+
+// UpdateFile reescreve a seção expected do vector a partir do resultado atual do motor.
+// Só os valores declarados que diferem são substituídos (no lugar, mantendo a formatação e a ordem
+// das chaves do arquivo); chaves que deixaram de existir são removidas e, com Exact, as obtidas e não
+// declaradas são adicionadas. Números dentro da tolerância não mudam. Vectors de erro (expectedError)
+// não são reescritos: as diferenças ficam em Remaining.
+func UpdateFile(path string, opts ...Option) (Update, error) {
+	o := newOptions(opts)
+	report := Update{Path: path}
+
+	v, err := Load(path)
+	if err != nil {
+		return report, err
+	}
+	if v.ExpectedError != "" {
+		report.Remaining, err = Check(v, opts...)
+		return report, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return report, err
+	}
+	root, err := parseSpans(data)
+	if err != nil {
+		return report, fmt.Errorf("%s: %w", path, err)
+	}
+	_, expected := root.member("expected")
+	if expected == nil || expected.kind != '{' {
+		return report, fmt.Errorf("%s: expected must be an object", path)
+	}
+
+	result, err := engine.RunEngine(o.context(), v.Input.Order, v.Input.RulePack, v.Input.Context)
+	if err != nil {
+		return report, fmt.Errorf("RunEngine failed: %w", err)
+	}
+	violations, err := normalize(result.Violations)
+	if err != nil {
+		return report, err
+	}
+	if violations == nil {
+		violations = []interface{}{}
+	}
+
+	u := newUpdater(data, o)
+	u.set(expected, "rulesVersion", result.RulesVersion)
+	u.set(expected, "violations", violations)
+	if _, fragment := expected.member("stateFragment"); fragment != nil && len(v.Expected.StateFragment) > 0 {
+		actual, err := normalize(result.StateFragment)
+		if err != nil {
+			return report, err
+		}
+		u.value(fragment, actual, "stateFragment", false)
+	}
+	if _, promotions := expected.member("promotions"); promotions != nil {
+		actual, err := normalize(result.Promotions)
+		if err != nil {
+			return report, err
+		}
+		if actual == nil {
+			actual = []interface{}{}
+		}
+		u.value(promotions, actual, "promotions", false)
+	}
+
+	report.Changes = u.changes
+	if len(u.edits) == 0 {
+		return report, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return report, err
+	}
+	if err := os.WriteFile(path, u.apply(), info.Mode().Perm()); err != nil {
+		return report, err
+	}
+
+	// Conferir o arquivo reescrito (sem contar de novo a cobertura)
+	v, err = Load(path)
+	if err != nil {
+		return report, fmt.Errorf("rewritten vector is invalid: %w", err)
+	}
+	report.Remaining, err = Check(v, append(opts, WithCoverage(nil))...)
+	return report, err
+}
+
+type edit struct {
+	start, end int
+	text       string
+}
+
+type updater struct {
+	o       options
+	data    []byte
+	unit    string // indentação do arquivo
+	padded  bool   // objetos em uma linha escritos como { "k": v }
+	edits   []edit
+	changes []Change
+}
+
+func newUpdater(data []byte, o options) *updater {
+	u := &updater{o: o, data: data, unit: "  ", padded: bytes.Contains(data, []byte(`{ "`))}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if trimmed := bytes.TrimLeft(line, " \t"); len(trimmed) > 0 && len(trimmed) < len(line) {
+			u.unit = string(line[:len(line)-len(trimmed)])
+			break
+		}
+	}
+	return u
+}
+
+// set atualiza (ou adiciona ao objeto) a chave de primeiro nível de expected
+func (u *updater) set(obj *node, key string, actual interface{}) {
+	if _, n := obj.member(key); n != nil {
+		u.value(n, actual, key, false)
+		return
+	}
+	u.add(obj, key, actual, key)
+}
+
+// value atualiza o valor declarado n para actual; inline indica que o pai está em uma linha
+func (u *updater) value(n *node, actual interface{}, path string, inline bool) {
+	switch n.kind {
+	case '{':
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			u.replace(n, actual, path, inline)
+			return
+		}
+		u.object(n, a, path, inline || !u.multiline(n))
+
+	case '[':
+		a, ok := actual.([]interface{})
+		if !ok {
+			u.replace(n, actual, path, inline)
+			return
+		}
+		if len(n.elems) == 0 || len(a) == 0 {
+			if len(n.elems) != len(a) {
+				u.replace(n, a, path, inline)
+			}
+			return
+		}
+		u.array(n, a, path, inline || !u.multiline(n))
+
+	case 'n':
+		var e float64
+		json.Unmarshal(u.data[n.start:n.end], &e)
+		if a, ok := actual.(float64); !ok || !u.o.closeEnough(e, a) {
+			u.replace(n, actual, path, inline)
+		}
+
+	default:
+		if string(u.data[n.start:n.end]) != compact(actual) {
+			var e interface{}
+			json.Unmarshal(u.data[n.start:n.end], &e)
+			if compact(e) != compact(actual) {
+				u.replace(n, actual, path, inline)
+			}
+		}
+	}
+}
+
+func (u *updater) object(n *node, actual map[string]interface{}, path string, inline bool) {
+	kept := -1 // último membro mantido
+	removed := make([]bool, len(n.members))
+	for i, m := range n.members {
+		a, ok := actual[m.key]
+		if !ok {
+			removed[i] = true
+			u.changes = append(u.changes, Change{Path: path + "." + m.key, Old: u.raw(m.value)})
+			continue
+		}
+		kept = i
+		u.value(m.value, a, path+"."+m.key, inline)
+	}
+
+	var added []string
+	if u.o.exact {
+		for _, k := range sortedKeys(actual) {
+			if i, _ := n.member(k); i < 0 {
+				added = append(added, k)
+			}
+		}
+	}
+
+	if kept < 0 {
+		// Nenhuma chave mantida: reescrever o objeto com as adicionadas (ou vazio)
+		obj := orderedObject{}
+		for _, k := range added {
+			obj = append(obj, orderedMember{k, actual[k]})
+			u.changes = append(u.changes, Change{Path: path + "." + k, New: compact(actual[k])})
+		}
+		u.edits = append(u.edits, edit{n.start, n.end, u.format(obj, u.indent(n.start), inline)})
+		return
+	}
+
+	seenKept := false
+	for i, m := range n.members {
+		if !removed[i] {
+			seenKept = true
+			continue
+		}
+		if seenKept {
+			// remove a vírgula anterior junto com o membro
+			u.edits = append(u.edits, edit{n.members[i-1].value.end, m.value.end, ""})
+		} else {
+			u.edits = append(u.edits, edit{m.keyStart, n.members[i+1].keyStart, ""})
+		}
+	}
+	for _, k := range added {
+		u.add(n, k, actual[k], path+"."+k)
+	}
+}
+
+// array atualiza os elementos em comum, remove os que sobraram e acrescenta os novos
+// no formato do último elemento declarado
+func (u *updater) array(n *node, actual []interface{}, path string, inline bool) {
+	common := min(len(n.elems), len(actual))
+	for i := 0; i < common; i++ {
+		u.value(n.elems[i], actual[i], fmt.Sprintf("%s[%d]", path, i), inline)
+	}
+	for i := common; i < len(n.elems); i++ {
+		u.changes = append(u.changes, Change{Path: fmt.Sprintf("%s[%d]", path, i), Old: u.raw(n.elems[i])})
+	}
+	if common < len(n.elems) {
+		u.edits = append(u.edits, edit{n.elems[common-1].end, n.elems[len(n.elems)-1].end, ""})
+		return
+	}
+
+	last := n.elems[len(n.elems)-1]
+	sep, indent := " ", u.indent(last.start)
+	if !inline {
+		sep = "\n" + indent
+	}
+	var text strings.Builder
+	for i := common; i < len(actual); i++ {
+		value := project(last, actual[i])
+		text.WriteString("," + sep + u.format(value, indent, inline || !u.multiline(last)))
+		u.changes = append(u.changes, Change{Path: fmt.Sprintf("%s[%d]", path, i), New: compact(value)})
+	}
+	if text.Len() > 0 {
+		u.edits = append(u.edits, edit{last.end, last.end, text.String()})
+	}
+}
+
+// add insere a chave no fim do objeto (não vazio)
+func (u *updater) add(obj *node, key string, value interface{}, path string) {
+	if len(obj.members) == 0 {
+		u.edits = append(u.edits, edit{obj.start, obj.end, u.format(orderedObject{{key, value}}, u.indent(obj.start), !u.multiline(obj))})
+		u.changes = append(u.changes, Change{Path: path, New: compact(value)})
+		return
+	}
+	last := obj.members[len(obj.members)-1]
+	sep, indent, inline := " ", u.indent(last.keyStart), true
+	if u.multiline(obj) {
+		sep, inline = "\n"+indent, false
+	}
+	text := "," + sep + compact(key) + ": " + u.format(value, indent, inline)
+	u.edits = append(u.edits, edit{last.value.end, last.value.end, text})
+	u.changes = append(u.changes, Change{Path: path, New: compact(value)})
+}
+
+func (u *updater) replace(n *node, value interface{}, path string, inline bool) {
+	composite := (n.kind == '{' && len(n.members) > 0) || (n.kind == '[' && len(n.elems) > 0)
+	inline = inline || (composite && !u.multiline(n))
+	u.edits = append(u.edits, edit{n.start, n.end, u.format(value, u.indent(n.start), inline)})
+	u.changes = append(u.changes, Change{Path: path, Old: u.raw(n), New: compact(value)})
+}
+
+func (u *updater) apply() []byte {
+	sort.SliceStable(u.edits, func(i, j int) bool { return u.edits[i].start > u.edits[j].start })
+	out := append([]byte(nil), u.data...)
+	for _, e := range u.edits {
+		out = append(out[:e.start], append([]byte(e.text), out[e.end:]...)...)
+	}
+	return out
+}
+
+func (u *updater) multiline(n *node) bool {
+	return bytes.IndexByte(u.data[n.start:n.end], '\n') >= 0
+}
+
+// indent é a indentação da linha onde pos está
+func (u *updater) indent(pos int) string {
+	start := bytes.LastIndexByte(u.data[:pos], '\n') + 1
+	end := start
+	for end < pos && (u.data[end] == ' ' || u.data[end] == '\t') {
+		end++
+	}
+	return string(u.data[start:end])
+}
+
+// raw é o valor do arquivo em JSON compacto
+func (u *updater) raw(n *node) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, u.data[n.start:n.end]); err != nil {
+		return string(u.data[n.start:n.end])
+	}
+	return buf.String()
+}
+
+// format escreve value em uma linha ou indentado a partir de indent
+func (u *updater) format(value interface{}, indent string, inline bool) string {
+	var keys []string
+	var values []interface{}
+	open, close := "[", "]"
+	switch v := value.(type) {
+	case orderedObject:
+		open, close = "{", "}"
+		for _, m := range v {
+			keys, values = append(keys, m.key), append(values, m.value)
+		}
+	case map[string]interface{}:
+		open, close = "{", "}"
+		for _, k := range sortedKeys(v) {
+			keys, values = append(keys, k), append(values, v[k])
+		}
+	case []interface{}:
+		values = v
+	default:
+		return compact(value)
+	}
+	if len(values) == 0 {
+		return open + close
+	}
+
+	parts := make([]string, len(values))
+	for i, v := range values {
+		if inline {
+			parts[i] = u.format(v, indent, true)
+		} else {
+			parts[i] = u.format(v, indent+u.unit, false)
+		}
+		if keys != nil {
+			parts[i] = compact(keys[i]) + ": " + parts[i]
+		}
+	}
+	if !inline {
+		inner := indent + u.unit
+		return open + "\n" + inner + strings.Join(parts, ",\n"+inner) + "\n" + indent + close
+	}
+	if open == "{" && u.padded {
+		return "{ " + strings.Join(parts, ", ") + " }"
+	}
+	return open + strings.Join(parts, ", ") + close
+}
+
+// orderedObject é um objeto novo com a ordem de chaves de um valor já declarado
+type orderedObject []orderedMember
+
+type orderedMember struct {
+	key   string
+	value interface{}
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(compact(m.key) + ":" + compact(m.value))
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// project recorta actual no formato declarado em shape: objetos só com as chaves declaradas
+// (na ordem do arquivo); elementos novos de arrays seguem o último elemento declarado
+func project(shape *node, actual interface{}) interface{} {
+	switch a := actual.(type) {
+	case map[string]interface{}:
+		if shape == nil || shape.kind != '{' || len(shape.members) == 0 {
+			return actual
+		}
+		obj := orderedObject{}
+		for _, m := range shape.members {
+			if v, ok := a[m.key]; ok {
+				obj = append(obj, orderedMember{m.key, project(m.value, v)})
+			}
+		}
+		return obj
+	case []interface{}:
+		if shape == nil || shape.kind != '[' || len(shape.elems) == 0 {
+			return actual
+		}
+		out := make([]interface{}, len(a))
+		for i, v := range a {
+			out[i] = project(shape.elems[min(i, len(shape.elems)-1)], v)
+		}
+		return out
+	}
+	return actual
+}
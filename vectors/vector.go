@@ -0,0 +1,82 @@
+// Package vectors executa test vectors de RulePacks: arquivos JSON com a entrada do motor
+// (State, RulePack, ContextMeta) e o resultado esperado, no formato de testdata/vectors e
+// testdata/errors.
+//
+//	func TestMeuPack(t *testing.T) {
+//		vectors.Run(t, "testdata/vectors", vectors.WithTolerance(0.001))
+//	}
+package vectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/dolphin-sistemas/computations-engine/core"
+)
+
+// Vector é um caso de teste: a entrada do motor e o resultado (Expected) ou o erro (ExpectedError) esperado
+type Vector struct {
+	Name          string    `json:"name"`
+	Description   string    `json:"description,omitempty"`
+	Input         Input     `json:"input"`
+	Expected      *Expected `json:"expected,omitempty"`
+	ExpectedError string    `json:"expectedError,omitempty"` // trecho da mensagem de erro (sem diferenciar maiúsculas)
+	Note          string    `json:"note,omitempty"`
+
+	Path string `json:"-"` // arquivo de origem (preenchido por Load)
+}
+
+// Input é a entrada de RunEngine
+type Input struct {
+	Order    core.State       `json:"order"`
+	RulePack core.RulePack    `json:"rulePack"`
+	Context  core.ContextMeta `json:"context"`
+}
+
+// Expected é o resultado esperado. StateFragment e Promotions são comparados parcialmente
+// (só as chaves declaradas); Violations compara a quantidade e os campos declarados de cada uma.
+type Expected struct {
+	StateFragment map[string]interface{} `json:"stateFragment,omitempty"`
+	RulesVersion  string                 `json:"rulesVersion"`
+	Violations    []interface{}          `json:"violations"`
+	Promotions    []interface{}          `json:"promotions,omitempty"`
+}
+
+// Load lê um vector
+func Load(path string) (Vector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Vector{}, err
+	}
+	var v Vector
+	if err := json.Unmarshal(data, &v); err != nil {
+		return Vector{}, fmt.Errorf("%s: invalid vector: %w", path, err)
+	}
+	if v.Expected == nil && v.ExpectedError == "" {
+		return Vector{}, fmt.Errorf("%s: vector has neither expected nor expectedError", path)
+	}
+	v.Path = path
+	if v.Name == "" {
+		v.Name = filepath.Base(path)
+	}
+	return v, nil
+}
+
+// Files lista os .json de um diretório (sem recursão), em ordem alfabética
+func Files(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,256 @@
+package vectors
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func decode(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func diffStrings(diffs []Diff) []string {
+	out := make([]string, len(diffs))
+	for i, d := range diffs {
+		out[i] = d.String()
+	}
+	return out
+}
+
+func TestCompare_PartialMatchAndPaths(t *testing.T) {
+	expected := decode(t, `{"totals": {"total": 11}, "items": [{"fields": {"total": 10, "extra": 1}}]}`)
+	actual := decode(t, `{"totals": {"total": 10, "subtotal": 10}, "items": [{"id": "a", "fields": {"total": 10.004}}]}`)
+
+	got := diffStrings(newOptions(nil).compare(expected, actual, "stateFragment"))
+	want := []string{
+		"stateFragment.items[0].fields.extra: missing, expected 1",
+		"stateFragment.totals.total: got 10, expected 11",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diffs:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestCompare_Tolerance(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             []Option
+		expected, actual float64
+		ok               bool
+	}{
+		{"default", nil, 10, 10.01, true},
+		{"default exceeded", nil, 10, 10.02, false},
+		{"absolute", []Option{WithTolerance(0.5)}, 10, 10.4, true},
+		{"zero", []Option{WithTolerance(0)}, 10, 10.001, false},
+		{"relative", []Option{WithTolerance(0), WithRelativeTolerance(1e-6)}, 1e9, 1e9 + 100, true},
+		{"relative exceeded", []Option{WithTolerance(0), WithRelativeTolerance(1e-9)}, 1e9, 1e9 + 100, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs := newOptions(tt.opts).compare(tt.expected, tt.actual, "x")
+			if (len(diffs) == 0) != tt.ok {
+				t.Errorf("diffs = %v, want ok=%v", diffStrings(diffs), tt.ok)
+			}
+		})
+	}
+}
+
+func TestCompare_ExactAndArrays(t *testing.T) {
+	o := newOptions([]Option{Exact()})
+	diffs := diffStrings(o.compare(decode(t, `{"a": 1}`), decode(t, `{"a": 1, "b": 2}`), "f"))
+	if len(diffs) != 1 || diffs[0] != "f.b: unexpected key (got 2)" {
+		t.Errorf("exact diffs = %v", diffs)
+	}
+
+	diffs = diffStrings(newOptions(nil).compare(decode(t, `[1, 2]`), decode(t, `[1]`), "violations"))
+	if len(diffs) != 1 || diffs[0] != "violations: got 1 elements, expected 2" {
+		t.Errorf("array diffs = %v", diffs)
+	}
+}
+
+func TestCheck_ErrorVector(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "err.json")
+	content := `{"input": {"order": {}, "rulePack": {"version": "v1"}}, "expectedError": "RULEPACK.ID"}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != "err.json" {
+		t.Errorf("name = %q", v.Name)
+	}
+	diffs, err := Check(v)
+	if err != nil || len(diffs) != 0 {
+		t.Errorf("Check = %v, %v", diffStrings(diffs), err)
+	}
+
+	v.ExpectedError = "something else"
+	diffs, _ = Check(v)
+	if len(diffs) != 1 || !strings.Contains(diffs[0].String(), `expected error containing "something else"`) {
+		t.Errorf("diffs = %v", diffStrings(diffs))
+	}
+}
+
+func TestLoad_RequiresExpectation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "v.json")
+	if err := os.WriteFile(path, []byte(`{"input": {}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected error for vector without expected/expectedError")
+	}
+}
+
+const updatePack = `{"id": "upd", "version": "v2", "phases": [{"name": "baseline", "rules": [
+  {"id": "total", "phase": "baseline", "priority": 1, "enabled": true, "actions": [
+    {"type": "compute", "target": "items[*].fields.total", "logic": {"*": [{"var": "price"}, {"var": "amount"}]}},
+    {"type": "compute", "target": "totals.total", "logic": {"sumPath": ["items[*].fields.total"]}}
+  ]}
+]}]}`
+
+func writeVector(t *testing.T, expected string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "v.json")
+	content := `{
+  "name": "update",
+  "input": {
+    "order": {"items": [{"id": "a", "amount": 2, "fields": {"price": 5}}, {"id": "b", "amount": 1, "fields": {"price": 3}}]},
+    "rulePack": ` + updatePack + `
+  },
+  "expected": ` + expected + `
+}
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestUpdateFile_RewritesOnlyChangedValues(t *testing.T) {
+	path := writeVector(t, `{
+    "stateFragment": {
+      "totals": { "total": 12, "gone": 1 },
+      "items": [
+        { "fields": { "total": 10 } }
+      ]
+    },
+    "rulesVersion": "v1",
+    "violations": []
+  }`)
+
+	report, err := UpdateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Remaining) != 0 {
+		t.Errorf("remaining diffs: %v", report.Remaining)
+	}
+	var changes []string
+	for _, c := range report.Changes {
+		changes = append(changes, c.String())
+	}
+	want := []string{
+		`rulesVersion: "v1" -> "v2"`,
+		`stateFragment.totals.total: 12 -> 13`,
+		`stateFragment.totals.gone: removed (was 1)`,
+		`stateFragment.items[1]: added {"fields":{"total":3}}`,
+	}
+	if strings.Join(changes, "\n") != strings.Join(want, "\n") {
+		t.Errorf("changes:\n%s\nwant:\n%s", strings.Join(changes, "\n"), strings.Join(want, "\n"))
+	}
+
+	data, _ := os.ReadFile(path)
+	wantFile := `    "stateFragment": {
+      "totals": { "total": 13 },
+      "items": [
+        { "fields": { "total": 10 } },
+        { "fields": { "total": 3 } }
+      ]
+    },
+    "rulesVersion": "v2",
+    "violations": []`
+	if !strings.Contains(string(data), wantFile) {
+		t.Errorf("rewritten file:\n%s", data)
+	}
+
+	// Segunda execução: nada a mudar, arquivo intacto
+	report, err = UpdateFile(path)
+	if err != nil || len(report.Changes) != 0 {
+		t.Errorf("second update = %v, %v", report, err)
+	}
+	again, _ := os.ReadFile(path)
+	if string(again) != string(data) {
+		t.Error("second update rewrote the file")
+	}
+}
+
+// TestRun_WithUpdate: Run reescreve os vectors só quando o chamador pede (sem flag global)
+func TestRun_WithUpdate(t *testing.T) {
+	path := writeVector(t, `{ "rulesVersion": "v1", "violations": [] }`)
+	dir := filepath.Dir(path)
+
+	Run(t, dir, WithUpdate(true))
+	v, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Expected.RulesVersion != "v2" {
+		t.Errorf("rulesVersion = %q after update, want v2", v.Expected.RulesVersion)
+	}
+}
+
+func TestUpdateFile_ExactAddsKeys(t *testing.T) {
+	path := writeVector(t, `{
+    "stateFragment": {
+      "totals": {
+        "total": 13
+      }
+    },
+    "rulesVersion": "v2",
+    "violations": []
+  }`)
+
+	report, err := UpdateFile(path, Exact())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Changes) == 0 || len(report.Remaining) != 0 {
+		t.Fatalf("report = %v", report)
+	}
+	v, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diffs, err := Check(v, Exact()); err != nil || len(diffs) != 0 {
+		t.Errorf("Check after exact update = %v, %v", diffStrings(diffs), err)
+	}
+}
+
+func TestUpdateFile_ErrorVectorNotRewritten(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "err.json")
+	content := `{"input": {"order": {}, "rulePack": {"version": "v1"}}, "expectedError": "something else"}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	report, err := UpdateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Changes) != 0 || len(report.Remaining) != 1 {
+		t.Errorf("report = %v", report)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != content {
+		t.Error("error vector was rewritten")
+	}
+}
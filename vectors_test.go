@@ -0,0 +1,19 @@
+package engine_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/dolphin-sistemas/computations-engine/vectors"
+)
+
+var update = flag.Bool("update", false, "reescreve os expected dos vectors a partir do resultado atual")
+
+func TestRunEngine_WithTestVectors(t *testing.T) {
+	vectors.Run(t, "testdata/vectors", vectors.WithUpdate(*update))
+}
+
+// TestRunEngine_ErrorVectors testa vectors de erro do diretório testdata/errors
+func TestRunEngine_ErrorVectors(t *testing.T) {
+	vectors.Run(t, "testdata/errors", vectors.WithUpdate(*update))
+}